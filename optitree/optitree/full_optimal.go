@@ -1,24 +1,28 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"optitree/opt"
+)
 
 // OptimalTree finds the optimal tree for the given tree size and branch factor.
 // This includes all latencies between the nodes in the tree.
 func (l Latencies) OptimalTree(treeSize, branchFactor int) {
 	type result struct {
 		tree           []int
-		latency        Latency
+		latency        opt.Latency
 		numUniqueTrees int
 	}
 	results := make(chan result, treeSize)
 	for root := 0; root < treeSize; root++ {
 		go func() {
-			bestLatency := Latency(10000000)
+			bestLatency := opt.Latency(10000000)
 			var bestTree []int
 			tree := generateBaseTree(treeSize, root)
 			numUniqueTrees := 0
-			UniqueTrees(tree, branchFactor, func(tree []int) {
-				latency := l.treeLatency(root, branchFactor, tree)
+			opt.UniqueTrees(tree, branchFactor, func(tree []int) {
+				latency := l.TreeLatency(root, branchFactor, tree)
 				if latency < bestLatency {
 					bestLatency = latency
 					bestTree = append([]int{root}, tree...)
@@ -34,7 +38,7 @@ func (l Latencies) OptimalTree(treeSize, branchFactor int) {
 			results <- result{bestTree, bestLatency, numUniqueTrees}
 		}()
 	}
-	optimalLatency := Latency(10000000)
+	optimalLatency := opt.Latency(10000000)
 	var optimalTree []int
 	totalTrees := 0
 	for range treeSize {
@@ -6,9 +6,12 @@ require (
 	github.com/google/go-cmp v0.6.0
 	github.com/pkg/profile v1.7.0
 	gonum.org/v1/gonum v0.15.0
+	optitree/opt v0.0.0-00010101000000-000000000000
 )
 
 require (
 	github.com/felixge/fgprof v0.9.3 // indirect
 	github.com/google/pprof v0.0.0-20211214055906-6f57359322fd // indirect
 )
+
+replace optitree/opt => ./opt
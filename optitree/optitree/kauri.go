@@ -25,8 +25,8 @@ func (l Latencies) KauriFaultLatency(params treeParams) result {
 		otherTreeLatencies := make([]float64, 0, params.iterations)
 		for range params.iterations {
 			newTree := changeCluster(baseTree, i, params.bf)
-			treeLatency := l.qcLatency(params.scf, params.bf, newTree.AsNodes(), true)
-			otherTreeLatency := l.qcLatency(params.scf+params.scd, params.bf, newTree.AsNodes(), true)
+			treeLatency := l.QCLatency(params.scf, params.bf, newTree.AsNodes(), true)
+			otherTreeLatency := l.QCLatency(params.scf+params.scd, params.bf, newTree.AsNodes(), true)
 			latencies = append(latencies, float64(treeLatency))
 			otherTreeLatencies = append(otherTreeLatencies, float64(otherTreeLatency))
 		}
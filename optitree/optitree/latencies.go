@@ -2,112 +2,53 @@ package main
 
 import (
 	"fmt"
-	"math"
-	"math/rand/v2"
 	"os"
 	"slices"
-	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
+
+	"optitree/opt"
 )
 
 // When running benchmarks, this can be set to true to avoid printing during the benchmark.
 var benchmarking = false
 
-var (
-	regions []string
-	cities  []string
-)
-
-type Latency int32
+// cities holds the name of each node in the loaded latency matrix.
+var cities []string
 
-func (l Latency) String() string {
-	// convert from microseconds to milliseconds
-	return time.Duration(l * 1000).String()
-}
-
-type Latencies [][]Latency
-
-func NewLatencies(size int) Latencies {
-	latencies := make(Latencies, size)
-	for i := range latencies {
-		latencies[i] = make([]Latency, size)
-	}
-	return latencies
+// Latencies wraps the optimizer's latency matrix with the analyses run by this command.
+type Latencies struct {
+	opt.Latencies
 }
 
+// NewRand returns a random latency matrix for size nodes.
 func NewRand(size int) Latencies {
-	const maxLatency = 1000 // 1s
-	r := rand.New(rand.NewPCG(1, 2))
-	latencies := make(Latencies, size)
-	for i := range latencies {
-		latencies[i] = make([]Latency, size)
-		for j := range latencies[i] {
-			if i == j {
-				latencies[i][j] = 0
-				continue
-			}
-			latencies[i][j] = Latency(r.Int32N(maxLatency))
-		}
-	}
-	return latencies
-}
-
-// duration returns the latency between two cities in the latencies matrix as a time.Duration.
-func (l Latencies) duration(from, to int) time.Duration {
-	return time.Duration(l[from][to] * 1000)
-}
-
-// pathLatency returns the latency between two cities in the latencies matrix.
-// The unit of the latency is microseconds.
-func (l Latencies) pathLatency(from, to int) Latency {
-	return l[from][to]
-}
-
-// distance returns the latency difference between (base, i) and (base, j).
-// This is meant to used as cmp function for sorting.
-func (l Latencies) distance(base, i, j int) int {
-	return int(l[base][i] - l[base][j])
-}
-
-// treeLatency calculates the total latency from the root, through one intermediate level, to the leaf nodes.
-func (l Latencies) treeLatency(root, branchFactor int, tree []int) (total Latency) {
-	// latency from root to each intermediate node
-	for i := range branchFactor {
-		total += l.pathLatency(root, tree[i])
-
-		// latency from intermediate node to leaves
-		for j := range branchFactor {
-			leafIndex := branchFactor*i + j + branchFactor
-			total += l.pathLatency(tree[i], tree[leafIndex])
-		}
-	}
-	return total
+	return Latencies{opt.NewRand(size)}
 }
 
-func (l Latencies) PrintNodes(nodes []node, bf int) {
+func (l Latencies) PrintNodes(nodes []opt.Node, bf int) {
 	if benchmarking {
 		return
 	}
 	tot := time.Duration(0)
 	root, nodes := nodes[0], nodes[1:]
 	tw := tabwriter.NewWriter(os.Stdout, 2, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, "%2d (%s)\n", root.id, cityName(root.id))
+	fmt.Fprintf(tw, "%2d (%s)\n", root.ID, cityName(root.ID))
 	for i := range bf {
-		lat := l.duration(root.id, nodes[i].id)
+		lat := l.Duration(root.ID, nodes[i].ID)
 		tot += lat
 		fmt.Fprintf(tw, " |-- %2d (%s)\tlat: %s\tvotes: %d\tdisseminated: %d\taggregated: %d\tdelivered: %d\n",
-			nodes[i].id, cityName(nodes[i].id), lat, nodes[i].votes, nodes[i].disseminated, nodes[i].aggregated, nodes[i].delivered)
+			nodes[i].ID, cityName(nodes[i].ID), lat, nodes[i].Votes, nodes[i].Disseminated, nodes[i].Aggregated, nodes[i].Delivered)
 		for j := range bf {
 			leafIndex := bf*i + j + bf
 			if leafIndex >= len(nodes) {
 				break
 			}
-			lat = l.duration(nodes[i].id, nodes[leafIndex].id)
+			lat = l.Duration(nodes[i].ID, nodes[leafIndex].ID)
 			tot += lat
 			fmt.Fprintf(tw, "      |-- %2d (%s)\tlat: %s\tvotes: %d\tdisseminated: %d\taggregated: %d\tdelivered: %d\n",
-				nodes[leafIndex].id, cityName(nodes[leafIndex].id), lat, nodes[leafIndex].votes, nodes[leafIndex].disseminated, nodes[leafIndex].aggregated, nodes[leafIndex].delivered)
+				nodes[leafIndex].ID, cityName(nodes[leafIndex].ID), lat, nodes[leafIndex].Votes, nodes[leafIndex].Disseminated, nodes[leafIndex].Aggregated, nodes[leafIndex].Delivered)
 		}
 	}
 	tw.Flush()
@@ -120,12 +61,12 @@ func (l Latencies) Print(tree []int, bf int) {
 	tw := tabwriter.NewWriter(os.Stdout, 2, 8, 2, ' ', 0)
 	fmt.Fprintf(tw, "%2d (%s)\n", root, cityName(root))
 	for i := range bf {
-		lat := l.duration(root, tree[i])
+		lat := l.Duration(root, tree[i])
 		tot += lat
 		fmt.Fprintf(tw, " |-- %2d (%s)\tlat: %s\n", tree[i], cityName(tree[i]), lat)
 		for j := range bf {
 			leafIndex := bf*i + j + bf
-			lat = l.duration(tree[i], tree[leafIndex])
+			lat = l.Duration(tree[i], tree[leafIndex])
 			tot += lat
 			fmt.Fprintf(tw, "      |-- %2d (%s)\tlat: %s\n", tree[leafIndex], cityName(tree[leafIndex]), lat)
 		}
@@ -141,11 +82,11 @@ func cityName(index int) string {
 func loadLatencies(csvFile string, citiesStr string) (Latencies, error) {
 	b, err := os.ReadFile(csvFile)
 	if err != nil {
-		return nil, err
+		return Latencies{}, err
 	}
 	latencies, err := parseLatencies(string(b))
 	if err != nil {
-		return nil, err
+		return Latencies{}, err
 	}
 	if citiesStr != "random" {
 		filteredCities := strings.Split(citiesStr, ",")
@@ -153,65 +94,23 @@ func loadLatencies(csvFile string, citiesStr string) (Latencies, error) {
 		for i, city := range filteredCities {
 			index := slices.Index(cities, city)
 			if index == -1 {
-				return nil, fmt.Errorf("city %s not found", city)
+				return Latencies{}, fmt.Errorf("city %s not found", city)
 			}
 			cityIndices[i] = index
 		}
-		latencies = filterLatencies(latencies, cityIndices)
+		latencies = Latencies{latencies.Filter(cityIndices)}
 		// update global cities slice to match the filtered latencies
 		cities = filteredCities
 	}
 	return latencies, nil
 }
 
-func filterLatencies(latencies Latencies, indices []int) Latencies {
-	filtered := NewLatencies(len(indices))
-	for i, from := range indices {
-		for j, to := range indices {
-			filtered[i][j] = latencies[from][to]
-		}
-	}
-	return filtered
-}
-
 func parseLatencies(csvData string) (Latencies, error) {
-	regionLine := strings.Split(csvData, "\n")
-	if regionLine[len(regionLine)-1] == "" {
-		// remove empty line at the end
-		regionLine = regionLine[:len(regionLine)-1]
-	}
-	if regionLine[0][0] == ',' {
-		// remove heading line, if present
-		regionLine = regionLine[1:]
+	latencies, names, err := opt.ParseCSV(csvData)
+	if err != nil {
+		return Latencies{}, err
 	}
 	// reset global city names slice
-	cities = make([]string, 0, len(regionLine))
-	latencies := NewLatencies(len(regionLine))
-	for from, row := range regionLine {
-		cols := strings.Split(row, ",")
-		region := cols[0]
-		regions = append(regions, region)
-		if strings.Contains(region, "(") {
-			cities = append(cities, city(region))
-		} else {
-			cities = append(cities, region)
-		}
-		for to, col := range cols[1:] {
-			if col == "" {
-				break
-			}
-			value, err := strconv.ParseFloat(col, 64)
-			if err != nil {
-				return nil, err
-			}
-			value *= 1000                                    // multiply to keep three decimal places
-			value /= 2                                       // divide by 2 to convert RTT latency to one-way
-			latencies[from][to] = Latency(math.Round(value)) // microseconds
-		}
-	}
-	return latencies, nil
-}
-
-func city(region string) string {
-	return region[strings.Index(region, "(")+1 : strings.Index(region, ")")]
+	cities = names
+	return Latencies{latencies}, nil
 }
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/pkg/profile"
+
+	"optitree/opt"
 )
 
 const (
@@ -18,7 +20,7 @@ const (
 func main() {
 	var (
 		mode = flag.String("profile", "", "enable profiling mode, one of [cpu, mem, mutex, block, trace]")
-		opt  = flag.String("opt", "channel", "optimization algorithm to run, one of [channel, mutex, sa]")
+		algo = flag.String("opt", "channel", "optimization algorithm to run, one of [channel, mutex, sa]")
 		bf   = flag.Int("bf", 3, "branch factor of the tree")
 		sz   = flag.Int("size", 0, "size of the tree, if zero, bf is used to compute the tree size")
 		tree = flag.String("tree", "", "starting tree [0,1,2,3,4,5,6]")
@@ -53,7 +55,7 @@ func main() {
 		// don't profile
 	}
 
-	size := TreeSize(*bf)
+	size := opt.TreeSize(*bf)
 	if *sz > 0 {
 		size = *sz
 	}
@@ -83,8 +85,8 @@ func main() {
 		log.Fatalf("Invalid tree size: %d, expected: %d", len(startTree), size)
 	}
 
-	if len(startTree) < *faults+quorumSize(len(startTree)) {
-		log.Fatalf("Invalid number of faults: %d, should be less than %d", *faults, len(startTree)-quorumSize(len(startTree)))
+	if len(startTree) < *faults+opt.QuorumSize(len(startTree)) {
+		log.Fatalf("Invalid number of faults: %d, should be less than %d", *faults, len(startTree)-opt.QuorumSize(len(startTree)))
 	}
 	params := NewTreeParams(startTree, *bf, *emit, *faults, *scd)
 	fmt.Printf("Number of CPUs: %d\n", runtime.NumCPU())
@@ -93,14 +95,14 @@ func main() {
 	fmt.Printf("Will emit every %d trees for a total of %d emit events\n", params.cadence, params.emitEvents())
 
 	var optimize func(params treeParams) result
-	switch *opt {
+	switch *algo {
 	case "channel":
 		optimize = latencies.QCOptimalTreeChannel
 	case "mutex":
 		optimize = latencies.QCOptimalTreeMutex
 	case "sa":
 		if *scf == 0 {
-			*scf = quorumSize(len(startTree))
+			*scf = opt.QuorumSize(len(startTree))
 		}
 		params.SetSimulatedAnnealingParams(simulatedAnnealingParams{
 			temp:        25000.0,
@@ -127,7 +129,7 @@ func main() {
 			}
 		}
 	default:
-		log.Fatalf("Invalid optimization algorithm: %s", *opt)
+		log.Fatalf("Invalid optimization algorithm: %s", *algo)
 	}
 
 	now := time.Now()
@@ -0,0 +1,182 @@
+package opt
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// Annealing holds the parameters for simulated annealing.
+type Annealing struct {
+	Temp        float64
+	CoolingRate float64
+	Threshold   float64
+	// Timeout stops the search early; zero means no timeout.
+	// A timeout makes the result depend on the speed of the machine.
+	Timeout time.Duration
+}
+
+// DefaultAnnealing is the annealing schedule that Kauri uses to optimize its trees.
+// The optitree command uses a ten times slower cooling rate by default; see its -cool flag.
+var DefaultAnnealing = Annealing{
+	Temp:        25000.0,
+	CoolingRate: 0.0055,
+	Threshold:   0.5,
+}
+
+// Result is the outcome of a tree search.
+type Result struct {
+	Nodes         []Node
+	Latency       Latency
+	AnalyzedTrees int
+}
+
+// Tree returns the node IDs of the result in position order.
+func (r Result) Tree() []int {
+	return ToTree(r.Nodes)
+}
+
+// Search describes the tree search space for simulated annealing.
+type Search struct {
+	// BaseTree is the starting tree, including the root.
+	BaseTree []int
+	// BranchFactor is the branch factor of the tree.
+	BranchFactor int
+	// QuorumSize is the number of votes needed for a QC.
+	QuorumSize int
+	// Pinned is the number of leading positions that are never moved, e.g. 1 to keep the root.
+	Pinned int
+	// FaultIndex is the index of the first faulty position; nodes are only swapped
+	// within the same group (above or below FaultIndex).
+	FaultIndex int
+	// NoSort disables reordering internal nodes by latency when scoring a tree.
+	NoSort bool
+}
+
+// Anneal searches for a tree with low QC latency using simulated annealing.
+// If rnd is nil, the global random source is used.
+func (l Latencies) Anneal(s Search, a Annealing, rnd *rand.Rand) Result {
+	var timeout <-chan time.Time
+	if a.Timeout > 0 {
+		timer := time.NewTimer(a.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	tree := slices.Clone(s.BaseTree)
+	nodes := AsNodes(tree)
+	best := Result{
+		Latency: l.QCLatency(s.QuorumSize, s.BranchFactor, nodes, s.NoSort),
+		Nodes:   nodes,
+	}
+	current := best.Latency
+	temp := a.Temp
+	for temp > a.Threshold {
+		select {
+		case <-timeout:
+			return best
+		default:
+			newSolution := Mutate(tree, s.Pinned, s.FaultIndex, rnd)
+			nodes = AsNodes(newSolution)
+			latency := l.QCLatency(s.QuorumSize, s.BranchFactor, nodes, s.NoSort)
+			if latency < current || math.Exp(-(float64(latency-current)/temp)) > float64Of(rnd) {
+				tree = newSolution
+				current = latency
+			}
+			if latency < best.Latency {
+				best.Latency = latency
+				best.Nodes = nodes
+			}
+			// Cool system down
+			temp *= 1 - a.CoolingRate
+			best.AnalyzedTrees++
+		}
+	}
+	return best
+}
+
+// Mutate returns a copy of the tree with two nodes swapped, such that the two nodes are
+// swapped within the same group of nodes (above or below faultIdx) and the first pinned
+// positions are left in place. The tree is returned unchanged if no such swap exists.
+func Mutate(tree []int, pinned, faultIdx int, rnd *rand.Rand) []int {
+	newTree := slices.Clone(tree)
+	if !canMutate(len(tree), pinned, faultIdx) {
+		return newTree
+	}
+	idx1, idx2 := intN(rnd, pinned, len(tree)), intN(rnd, pinned, len(tree))
+	// If the two nodes (idx1 and idx2) aren't in the same group (above or below faultIdx), we try again.
+	for idx1 == idx2 || ((idx1 >= faultIdx) != (idx2 >= faultIdx)) {
+		idx2 = intN(rnd, pinned, len(tree))
+		idx1 = intN(rnd, pinned, len(tree))
+	}
+	// swap the two nodes in the same group.
+	newTree[idx1], newTree[idx2] = newTree[idx2], newTree[idx1]
+	return newTree
+}
+
+// canMutate returns true if at least one of the groups has two movable positions.
+func canMutate(n, pinned, faultIdx int) bool {
+	upper := max(faultIdx-pinned, 0)
+	lower := n - max(faultIdx, pinned)
+	return upper >= 2 || lower >= 2
+}
+
+// intN returns a random index in the range [lo, hi).
+func intN(rnd *rand.Rand, lo, hi int) int {
+	if rnd == nil {
+		return lo + rand.IntN(hi-lo)
+	}
+	return lo + rnd.IntN(hi-lo)
+}
+
+func float64Of(rnd *rand.Rand) float64 {
+	if rnd == nil {
+		return rand.Float64()
+	}
+	return rnd.Float64()
+}
+
+// BaseTree returns a tree rooted at root, where the root's nearest nodes are
+// internal nodes and each internal node gets its nearest remaining nodes as leaves.
+func (l Latencies) BaseTree(treeSize, root, bf int) []int {
+	tree := make([]int, treeSize)
+	tree[0] = root
+	taken := make([]bool, treeSize)
+	taken[root] = true
+	nearest := l.KNearest(root, bf, remaining(taken))
+	for i := range bf {
+		tree[i+1] = nearest[i]
+		taken[nearest[i]] = true
+	}
+	for i := range bf {
+		nearest := l.KNearest(tree[i+1], bf, remaining(taken))
+		for j := range len(nearest) {
+			taken[nearest[j]] = true
+			index := (i+1)*bf + j + 1
+			tree[index] = nearest[j]
+		}
+	}
+	return tree
+}
+
+// KNearest returns the k nearest nodes to the given node.
+// remainingNodes is modified in place.
+func (l Latencies) KNearest(node int, k int, remainingNodes []int) []int {
+	slices.SortFunc(remainingNodes, func(i, j int) int {
+		return l.distance(node, i, j)
+	})
+	if len(remainingNodes) > k {
+		return remainingNodes[:k]
+	}
+	return remainingNodes
+}
+
+func remaining(taken []bool) []int {
+	remaining := make([]int, 0)
+	for i, value := range taken {
+		if !value {
+			remaining = append(remaining, i)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,8 @@
+module optitree/opt
+
+go 1.22
+
+require (
+	github.com/google/go-cmp v0.5.8
+	gonum.org/v1/gonum v0.11.0
+)
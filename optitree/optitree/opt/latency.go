@@ -0,0 +1,139 @@
+// Package opt implements the OptiTree tree optimizer.
+//
+// A tree is represented as a slice of node IDs in position order:
+// the root is at index 0, the branchFactor internal nodes follow at indices 1..bf,
+// and the leaves of internal node i are at indices bf*i+bf+1 .. bf*i+2*bf.
+// Node IDs are indices into the latency matrix and the suspicion graph.
+package opt
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Latency is a one-way latency in microseconds.
+type Latency int32
+
+func (l Latency) String() string {
+	// convert from microseconds to milliseconds
+	return time.Duration(l * 1000).String()
+}
+
+// Latencies is a matrix of one-way latencies between nodes.
+type Latencies [][]Latency
+
+// NewLatencies returns a zeroed latency matrix for size nodes.
+func NewLatencies(size int) Latencies {
+	latencies := make(Latencies, size)
+	for i := range latencies {
+		latencies[i] = make([]Latency, size)
+	}
+	return latencies
+}
+
+// NewRand returns a latency matrix for size nodes with random latencies below one second.
+// The matrix is the same for every call with the same size.
+func NewRand(size int) Latencies {
+	const maxLatency = 1000 // 1s
+	r := rand.New(rand.NewPCG(1, 2))
+	latencies := make(Latencies, size)
+	for i := range latencies {
+		latencies[i] = make([]Latency, size)
+		for j := range latencies[i] {
+			if i == j {
+				latencies[i][j] = 0
+				continue
+			}
+			latencies[i][j] = Latency(r.Int32N(maxLatency))
+		}
+	}
+	return latencies
+}
+
+// Duration returns the latency between two nodes as a time.Duration.
+func (l Latencies) Duration(from, to int) time.Duration {
+	return time.Duration(l[from][to] * 1000)
+}
+
+// Path returns the latency between two nodes in microseconds.
+func (l Latencies) Path(from, to int) Latency {
+	return l[from][to]
+}
+
+// distance returns the latency difference between (base, i) and (base, j).
+// This is meant to used as cmp function for sorting.
+func (l Latencies) distance(base, i, j int) int {
+	return int(l[base][i] - l[base][j])
+}
+
+// TreeLatency calculates the total latency from the root, through one intermediate level, to the leaf nodes.
+func (l Latencies) TreeLatency(root, branchFactor int, tree []int) (total Latency) {
+	// latency from root to each intermediate node
+	for i := range branchFactor {
+		total += l.Path(root, tree[i])
+
+		// latency from intermediate node to leaves
+		for j := range branchFactor {
+			leafIndex := branchFactor*i + j + branchFactor
+			total += l.Path(tree[i], tree[leafIndex])
+		}
+	}
+	return total
+}
+
+// Filter returns the latency matrix for the nodes at the given indices.
+func (l Latencies) Filter(indices []int) Latencies {
+	filtered := NewLatencies(len(indices))
+	for i, from := range indices {
+		for j, to := range indices {
+			filtered[i][j] = l[from][to]
+		}
+	}
+	return filtered
+}
+
+// ParseCSV parses a matrix of round-trip times in milliseconds and returns the
+// corresponding one-way latencies along with the name of each row.
+// Rows named "Region (City) id" are reduced to the city name.
+func ParseCSV(csvData string) (Latencies, []string, error) {
+	regionLine := strings.Split(csvData, "\n")
+	if regionLine[len(regionLine)-1] == "" {
+		// remove empty line at the end
+		regionLine = regionLine[:len(regionLine)-1]
+	}
+	if regionLine[0][0] == ',' {
+		// remove heading line, if present
+		regionLine = regionLine[1:]
+	}
+	names := make([]string, 0, len(regionLine))
+	latencies := NewLatencies(len(regionLine))
+	for from, row := range regionLine {
+		cols := strings.Split(row, ",")
+		region := cols[0]
+		if strings.Contains(region, "(") {
+			names = append(names, city(region))
+		} else {
+			names = append(names, region)
+		}
+		for to, col := range cols[1:] {
+			if col == "" {
+				break
+			}
+			value, err := strconv.ParseFloat(col, 64)
+			if err != nil {
+				return nil, nil, err
+			}
+			value *= 1000                                    // multiply to keep three decimal places
+			value /= 2                                       // divide by 2 to convert RTT latency to one-way
+			latencies[from][to] = Latency(math.Round(value)) // microseconds
+		}
+	}
+	return latencies, names, nil
+}
+
+func city(region string) string {
+	return region[strings.Index(region, "(")+1 : strings.Index(region, ")")]
+}
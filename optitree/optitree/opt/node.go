@@ -0,0 +1,78 @@
+package opt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node holds the state of a node while computing the QC latency of a tree.
+type Node struct {
+	ID           int
+	Votes        int
+	Disseminated Latency
+	Aggregated   Latency
+	Delivered    Latency
+}
+
+func (n Node) String() string {
+	return fmt.Sprintf("id %d: votes: %d, disseminated: %d, aggregated: %d, delivered: %d", n.ID, n.Votes, n.Disseminated, n.Aggregated, n.Delivered)
+}
+
+// NewNodes returns the nodes of the given tree, with the root at index 0.
+// The tree slice must not include the root.
+func NewNodes(root int, tree []int) []Node {
+	nodes := make([]Node, len(tree)+1)
+	nodes[0] = Node{ID: root, Votes: 1}
+	for i := range tree {
+		nodes[i+1] = Node{ID: tree[i], Votes: 1}
+	}
+	return nodes
+}
+
+// AsNodes returns the nodes of the given tree, including the root at index 0.
+func AsNodes(tree []int) []Node {
+	nodes := make([]Node, len(tree))
+	for i, id := range tree {
+		nodes[i] = Node{ID: id, Votes: 1}
+	}
+	return nodes
+}
+
+// ResetNodes resets the provided nodes for reuse avoiding reallocation.
+// The tree slice must not include the root.
+func ResetNodes(nodes []Node, tree []int) {
+	for i := range nodes {
+		if i > 0 {
+			// since the root is at index 0 (and doesn't change),
+			// while the tree does not include the root
+			nodes[i].ID = tree[i-1]
+		}
+		nodes[i].Votes = 1
+		nodes[i].Disseminated = 0
+		nodes[i].Aggregated = 0
+		nodes[i].Delivered = 0
+	}
+}
+
+// ToTree returns the node IDs in position order.
+func ToTree(nodes []Node) []int {
+	tree := make([]int, len(nodes))
+	for i, node := range nodes {
+		tree[i] = node.ID
+	}
+	return tree
+}
+
+// ToString returns the node IDs formatted as a Go slice literal.
+func ToString(nodes []Node) string {
+	var builder strings.Builder
+	builder.WriteString("[]int{")
+	for i, node := range nodes {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(fmt.Sprintf("%d", node.ID))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
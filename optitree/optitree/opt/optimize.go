@@ -0,0 +1,123 @@
+package opt
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+type options struct {
+	root      int
+	seed      uint64
+	annealing Annealing
+}
+
+// Option configures Optimize.
+type Option func(*options)
+
+// WithRoot fixes the root of the tree, typically to the current leader.
+// By default, every candidate root is tried.
+func WithRoot(root int) Option {
+	return func(o *options) {
+		o.root = root
+	}
+}
+
+// WithSeed sets the seed of the random source used by the search.
+// Callers that compute the same tree on several machines must use the same seed.
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithAnnealing sets the simulated annealing parameters.
+// The default is DefaultAnnealing, which has no timeout.
+func WithAnnealing(a Annealing) Option {
+	return func(o *options) {
+		o.annealing = a
+	}
+}
+
+// Optimize returns a tree for the nodes of the latency matrix with low QC latency.
+// Nodes that are excluded by the suspicion graph are only placed as leaves,
+// and the tree is scored for a quorum that tolerates the given number of faults.
+// The result is deterministic for the same inputs and options,
+// unless a timeout is set with WithAnnealing.
+func Optimize(latencies Latencies, suspicions Suspicions, faults, bf int, opts ...Option) Result {
+	o := options{root: -1, annealing: DefaultAnnealing}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	n := len(latencies)
+	if suspicions == nil {
+		suspicions = NewSuspicions(n)
+	}
+	trusted, suspected := partition(n, suspicions.Candidates())
+	search := Search{
+		BranchFactor: bf,
+		QuorumSize:   min(QuorumSize(n)+faults, n),
+		Pinned:       1,
+		NoSort:       true,
+	}
+	roots := trusted
+	if o.root >= 0 {
+		roots = []int{o.root}
+	} else if len(roots) == 0 {
+		roots = suspected
+	}
+	best := Result{Latency: Latency(10000000)}
+	for _, root := range roots {
+		search.BaseTree = latencies.baseTree(root, bf, trusted, suspected)
+		search.FaultIndex = len(trusted)
+		if !slices.Contains(trusted, root) {
+			// the root was taken from the suspected nodes
+			search.FaultIndex++
+		}
+		if search.FaultIndex < bf+1 {
+			// too few trusted nodes to fill the internal positions; allow any placement.
+			search.FaultIndex = n
+		}
+		rnd := rand.New(rand.NewPCG(o.seed, uint64(root)))
+		r := latencies.Anneal(search, o.annealing, rnd)
+		if r.Latency < best.Latency {
+			best.Latency = r.Latency
+			best.Nodes = r.Nodes
+		}
+		best.AnalyzedTrees += r.AnalyzedTrees
+	}
+	return best
+}
+
+// partition splits the nodes into trusted candidates and suspected nodes.
+func partition(n int, candidates []int) (trusted, suspected []int) {
+	trusted = make([]int, 0, len(candidates))
+	suspected = make([]int, 0, n-len(candidates))
+	for node := range n {
+		if slices.Contains(candidates, node) {
+			trusted = append(trusted, node)
+		} else {
+			suspected = append(suspected, node)
+		}
+	}
+	return trusted, suspected
+}
+
+// baseTree returns a starting tree with root at index 0 followed by the trusted nodes
+// and then the suspected nodes. Internal nodes are the trusted nodes nearest to the root,
+// and each internal node gets its nearest remaining trusted nodes as leaves.
+func (l Latencies) baseTree(root, bf int, trusted, suspected []int) []int {
+	rest := slices.DeleteFunc(slices.Clone(trusted), func(id int) bool { return id == root })
+	tail := slices.DeleteFunc(slices.Clone(suspected), func(id int) bool { return id == root })
+	tree := make([]int, 0, 1+len(rest)+len(tail))
+	tree = append(tree, root)
+	internal := slices.Clone(l.KNearest(root, bf, rest))
+	tree = append(tree, internal...)
+	rest = slices.DeleteFunc(rest, func(id int) bool { return slices.Contains(internal, id) })
+	for _, id := range internal {
+		leaves := slices.Clone(l.KNearest(id, bf, rest))
+		tree = append(tree, leaves...)
+		rest = slices.DeleteFunc(rest, func(id int) bool { return slices.Contains(leaves, id) })
+	}
+	tree = append(tree, rest...)
+	return append(tree, tail...)
+}
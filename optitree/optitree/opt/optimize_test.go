@@ -0,0 +1,168 @@
+package opt
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+)
+
+func loadAWS(t testing.TB) Latencies {
+	t.Helper()
+	b, err := os.ReadFile("../latencies/aws.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latencies, _, err := ParseCSV(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return latencies
+}
+
+func TestTreeQCLatency(t *testing.T) {
+	latencies := loadAWS(t)
+	tests := []struct {
+		tree    []int
+		bf      int
+		wantLat Latency
+	}{
+		{bf: 3, tree: []int{0, 1, 2, 3, 5, 7, 8, 9, 12, 15, 16, 17, 19}, wantLat: 602110},
+		{bf: 3, tree: []int{3, 0, 1, 2, 9, 12, 16, 5, 7, 15, 8, 17, 19}, wantLat: 191495},
+		{bf: 4, tree: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, wantLat: 613165},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("size=%d/bf=%d", len(tt.tree), tt.bf), func(t *testing.T) {
+			orig := slices.Clone(tt.tree)
+			if got := latencies.TreeQCLatency(QuorumSize(len(tt.tree)), tt.bf, tt.tree); got != tt.wantLat {
+				t.Errorf("TreeQCLatency() = %d; want %d", got, tt.wantLat)
+			}
+			if !slices.Equal(orig, tt.tree) {
+				t.Errorf("TreeQCLatency() modified the tree: %v", tt.tree)
+			}
+		})
+	}
+}
+
+func TestIntN(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		for _, r := range []*rand.Rand{nil, rnd} {
+			if got := intN(r, 1, 7); got < 1 || got >= 7 {
+				t.Errorf("intN(1, 7) = %d, want in [1, 7)", got)
+			}
+		}
+	}
+}
+
+func TestMutate(t *testing.T) {
+	tree := []int{0, 1, 2, 3, 4, 5, 6}
+	for range 1000 {
+		mutated := Mutate(tree, 0, len(tree), nil)
+		if !slices.Equal(sorted(mutated), basicTree(len(tree))) {
+			t.Fatalf("Mutate() = %v, want a permutation of %v", mutated, tree)
+		}
+		if slices.Equal(mutated, tree) {
+			t.Fatalf("Mutate() = %v, want a different tree", mutated)
+		}
+		tree = mutated
+	}
+}
+
+func TestMutatePinned(t *testing.T) {
+	tree := []int{0, 1, 2, 3, 4, 5, 6}
+	for range 100 {
+		tree = Mutate(tree, 1, 5, nil)
+		if tree[0] != 0 {
+			t.Fatalf("Mutate() moved the pinned root: %v", tree)
+		}
+		if tail := sorted(tree[5:]); !slices.Equal(tail, []int{5, 6}) {
+			t.Fatalf("Mutate() moved nodes across the fault index: %v", tree)
+		}
+	}
+	// nothing can be swapped: one movable node above and below the fault index
+	if got := Mutate([]int{0, 1, 2}, 1, 2, nil); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Mutate() = %v, want unchanged tree", got)
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	const bf = 3
+	latencies := loadAWS(t).Filter([]int{0, 1, 2, 3, 5, 7, 8, 9, 12, 15, 16, 17, 19})
+	n := len(latencies)
+	suspicions := NewSuspicions(n)
+	suspicions.Suspect(4, 7)
+	suspicions.Suspect(4, 9)
+
+	tests := []struct {
+		name       string
+		suspicions Suspicions
+		faults     int
+		root       int
+	}{
+		{name: "no-suspicions", root: -1},
+		{name: "no-suspicions/root=5", root: 5},
+		{name: "suspicions/root=0", suspicions: suspicions, faults: 1, root: 0},
+		{name: "suspicions/root=4", suspicions: suspicions, faults: 1, root: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithSeed(42)}
+			if tt.root >= 0 {
+				opts = append(opts, WithRoot(tt.root))
+			}
+			got := Optimize(latencies, tt.suspicions, tt.faults, bf, opts...)
+			tree := got.Tree()
+			if sorted := sorted(tree); !slices.Equal(sorted, basicTree(n)) {
+				t.Fatalf("Optimize() = %v, want a permutation of all nodes", tree)
+			}
+			if tt.root >= 0 && tree[0] != tt.root {
+				t.Errorf("Optimize() root = %d, want %d", tree[0], tt.root)
+			}
+			if tt.suspicions != nil {
+				candidates := tt.suspicions.Candidates()
+				for pos, id := range tree[1 : bf+1] {
+					if !slices.Contains(candidates, id) {
+						t.Errorf("Optimize() placed suspected node %d at internal position %d: %v", id, pos+1, tree)
+					}
+				}
+			}
+			qs := min(QuorumSize(n)+tt.faults, n)
+			if lat := latencies.QCLatency(qs, bf, AsNodes(tree), true); lat != got.Latency {
+				t.Errorf("Optimize() latency = %d, but tree has latency %d", got.Latency, lat)
+			}
+			again := Optimize(latencies, tt.suspicions, tt.faults, bf, opts...)
+			if !slices.Equal(tree, again.Tree()) {
+				t.Errorf("Optimize() is not deterministic: %v != %v", tree, again.Tree())
+			}
+		})
+	}
+}
+
+func TestOptimizeImprovesBaseTree(t *testing.T) {
+	const bf = 4
+	latencies := loadAWS(t)
+	n := len(latencies)
+	base := basicTree(n)
+	qs := QuorumSize(n)
+	baseLatency := latencies.QCLatency(qs, bf, AsNodes(base), true)
+	got := Optimize(latencies, nil, 0, bf, WithRoot(0))
+	if got.Latency > baseLatency {
+		t.Errorf("Optimize() latency = %s, want at most %s", got.Latency, baseLatency)
+	}
+}
+
+func basicTree(size int) []int {
+	tree := make([]int, size)
+	for i := range size {
+		tree[i] = i
+	}
+	return tree
+}
+
+func sorted(tree []int) []int {
+	s := slices.Clone(tree)
+	slices.Sort(s)
+	return s
+}
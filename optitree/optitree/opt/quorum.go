@@ -0,0 +1,46 @@
+package opt
+
+import (
+	"math"
+	"math/big"
+
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// QuorumSize returns the number of nodes needed to form a quorum
+// for an arbitrary number of nodes.
+func QuorumSize(n int) int {
+	f := (n - 1) / 3
+	return int(math.Ceil(float64(n+f+1) / 2.0))
+}
+
+// QuorumSizeSimple returns the number of nodes needed to form a quorum
+// given that the number of nodes n is different from 3f+3.
+func QuorumSizeSimple(n int) int {
+	f := (n - 1) / 3
+	return n - f
+}
+
+// TreeSize returns the number of nodes in a tree of height two with the given branch factor.
+func TreeSize(bf int) int {
+	return bf*bf + bf + 1
+}
+
+// NumTrees returns the number of unique trees of n nodes with branch factor k.
+func NumTrees(n, k int) int64 {
+	result := int64(n)
+	for i := 0; i <= k; i++ {
+		result *= int64(combin.Binomial(n-1-i*k, k))
+	}
+	return result
+}
+
+// NumTrees2 is like NumTrees, but does not overflow for large trees.
+func NumTrees2(n, k int) *big.Int {
+	result := big.NewInt(int64(n))
+	for i := 0; i <= k; i++ {
+		temp := big.NewInt(1)
+		result.Mul(result, temp.Binomial(int64(n-1-i*k), int64(k)))
+	}
+	return result
+}
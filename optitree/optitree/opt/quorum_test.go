@@ -1,4 +1,4 @@
-package main
+package opt
 
 import (
 	"fmt"
@@ -55,8 +55,8 @@ func TestQuorumSize(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("n=%d", tt.n), func(t *testing.T) {
-			if got := quorumSize(tt.n); got != tt.want {
-				t.Errorf("quorumSize(%d) = %d; want %d", tt.n, got, tt.want)
+			if got := QuorumSize(tt.n); got != tt.want {
+				t.Errorf("QuorumSize(%d) = %d; want %d", tt.n, got, tt.want)
 			}
 		})
 	}
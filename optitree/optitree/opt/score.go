@@ -1,41 +1,43 @@
-package main
+package opt
 
 import (
 	"slices"
 )
 
-// qcLatency returns the latency to obtain a quorum certificate (QC) from the give tree.
-func (l Latencies) qcLatency(quorumSize, branchFactor int, all []node, noSort bool) Latency {
+// QCLatency returns the latency to obtain a quorum certificate (QC) from the given tree.
+// Unless noSort is set, the internal nodes and their leaves are reordered
+// according to the arrival time of their aggregated votes at the root.
+func (l Latencies) QCLatency(quorumSize, branchFactor int, all []Node, noSort bool) Latency {
 	root, nodes := all[0], all[1:]
 
 	// Top-down dissemination of votes:
 	// Disseminate from root to internal nodes:
 	for i := range branchFactor {
-		nodes[i].disseminated = root.disseminated + l.pathLatency(root.id, nodes[i].id)
+		nodes[i].Disseminated = root.Disseminated + l.Path(root.ID, nodes[i].ID)
 		// Disseminate from internal nodes to all leaves
 		for j := range branchFactor {
 			leafIndex := branchFactor*i + j + branchFactor
 			if leafIndex >= len(nodes) {
 				break
 			}
-			nodes[leafIndex].disseminated = nodes[i].disseminated + l.pathLatency(nodes[i].id, nodes[leafIndex].id)
-			nodes[leafIndex].aggregated = nodes[leafIndex].disseminated // Return instantly the vote
-			nodes[leafIndex].delivered = nodes[leafIndex].aggregated + l.pathLatency(nodes[leafIndex].id, nodes[i].id)
+			nodes[leafIndex].Disseminated = nodes[i].Disseminated + l.Path(nodes[i].ID, nodes[leafIndex].ID)
+			nodes[leafIndex].Aggregated = nodes[leafIndex].Disseminated // Return instantly the vote
+			nodes[leafIndex].Delivered = nodes[leafIndex].Aggregated + l.Path(nodes[leafIndex].ID, nodes[i].ID)
 		}
 	}
 	// Dissemination finished!
 	// Aggregation starts!
 	for i := range branchFactor {
-		nodes[i].aggregated = nodes[i].disseminated
+		nodes[i].Aggregated = nodes[i].Disseminated
 		for j := range branchFactor {
 			leafIndex := branchFactor*i + j + branchFactor
 			if leafIndex >= len(nodes) {
 				break
 			}
-			nodes[i].aggregated = max(nodes[i].aggregated, nodes[leafIndex].delivered)
-			nodes[i].votes += nodes[leafIndex].votes
+			nodes[i].Aggregated = max(nodes[i].Aggregated, nodes[leafIndex].Delivered)
+			nodes[i].Votes += nodes[leafIndex].Votes
 		}
-		nodes[i].delivered = nodes[i].aggregated + l.pathLatency(nodes[i].id, root.id)
+		nodes[i].Delivered = nodes[i].Aggregated + l.Path(nodes[i].ID, root.ID)
 	}
 	// Sort ascending arrival times of votes at leader
 	internalNodes := nodes[:branchFactor]
@@ -44,22 +46,28 @@ func (l Latencies) qcLatency(quorumSize, branchFactor int, all []node, noSort bo
 	}
 	// Collect QC latency
 	for _, internal := range internalNodes {
-		if root.votes >= quorumSize {
-			return root.aggregated
+		if root.Votes >= quorumSize {
+			return root.Aggregated
 		}
-		root.aggregated = max(root.aggregated, internal.delivered)
-		root.votes += internal.votes
+		root.Aggregated = max(root.Aggregated, internal.Delivered)
+		root.Votes += internal.Votes
 	}
-	return root.aggregated
+	return root.Aggregated
+}
+
+// TreeQCLatency returns the latency to obtain a quorum certificate from the given tree,
+// where tree includes the root at index 0. The tree slice is not modified.
+func (l Latencies) TreeQCLatency(quorumSize, branchFactor int, tree []int) Latency {
+	return l.QCLatency(quorumSize, branchFactor, AsNodes(tree), false)
 }
 
 // orderByLatency reorders the internal nodes and the leaves according
 // to the latency of the internal nodes.
 // Note that if we only care about the QC latency we can ignore the leaves.
-func orderByLatency(bf int, internalNodes, nodes []node) {
+func orderByLatency(bf int, internalNodes, nodes []Node) {
 	original := slices.Clone(internalNodes)
-	slices.SortFunc(internalNodes, func(i, j node) int {
-		return int(i.delivered - j.delivered)
+	slices.SortFunc(internalNodes, func(i, j Node) int {
+		return int(i.Delivered - j.Delivered)
 	})
 	if slices.Equal(original, internalNodes) {
 		return
@@ -0,0 +1,132 @@
+package opt
+
+import "slices"
+
+// Suspicions is the suspicion graph as an adjacency matrix;
+// s[a][b] > 0 means that node a suspects node b.
+// A nil row means that the node suspects no one.
+type Suspicions [][]int
+
+// NewSuspicions returns an empty suspicion graph for size nodes.
+func NewSuspicions(size int) Suspicions {
+	s := make(Suspicions, size)
+	for i := range s {
+		s[i] = make([]int, size)
+	}
+	return s
+}
+
+// Suspect records that a suspects b and that b suspects a.
+func (s Suspicions) Suspect(a, b int) {
+	s[a][b]++
+	s[b][a]++
+}
+
+// suspected returns true if a suspects b.
+func (s Suspicions) suspected(a, b int) bool {
+	return a < len(s) && b < len(s[a]) && s[a][b] > 0
+}
+
+// degree returns the number of nodes that node suspects.
+func (s Suspicions) degree(node int) int {
+	count := 0
+	for _, suspicion := range s[node] {
+		if suspicion > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// zeroDegreeNodes returns the nodes whose degree is 0 in the suspicion graph.
+func (s Suspicions) zeroDegreeNodes() []int {
+	zeroDegreeNodes := make([]int, 0, len(s))
+	for node := range s {
+		if s.degree(node) == 0 {
+			zeroDegreeNodes = append(zeroDegreeNodes, node)
+		}
+	}
+	return zeroDegreeNodes
+}
+
+// isEdgeAcceptable returns true if neither a nor b has degree 1,
+// that is, if the edge between a and b can be added to a matching.
+func (s Suspicions) isEdgeAcceptable(a, b int) bool {
+	return s.degree(a) != 1 && s.degree(b) != 1
+}
+
+// findDegreeOneNeighbor returns a neighbor of node with degree 1, other than except.
+// If no such neighbor exists, it returns -1.
+func (s Suspicions) findDegreeOneNeighbor(node, except int) int {
+	for neighbor := range s[node] {
+		if neighbor != except && s.suspected(node, neighbor) && s.degree(neighbor) == 1 {
+			return neighbor
+		}
+	}
+	return -1
+}
+
+func (s Suspicions) set(a, b int, value int) {
+	s[a][b] = value
+	s[b][a] = value
+}
+
+// matching returns a maximal matching of the suspicion graph, improved
+// by replacing an edge (a, b) with two edges (a, a') and (b, b')
+// whenever a' and b' are only suspected by a and b respectively.
+func (s Suspicions) matching() Suspicions {
+	mg1 := NewSuspicions(len(s))
+	for node := range s {
+		for neighbor := range s[node] {
+			if node != neighbor && s.suspected(node, neighbor) && mg1.isEdgeAcceptable(node, neighbor) {
+				mg1.set(node, neighbor, 1)
+			}
+		}
+	}
+	for node := range mg1 {
+		for neighbor := node + 1; neighbor < len(mg1); neighbor++ {
+			if !mg1.suspected(node, neighbor) {
+				continue
+			}
+			nodeEdge := s.findDegreeOneNeighbor(node, neighbor)
+			neighborEdge := s.findDegreeOneNeighbor(neighbor, node)
+			if nodeEdge < 0 || neighborEdge < 0 || nodeEdge == neighborEdge ||
+				mg1.degree(nodeEdge) > 0 || mg1.degree(neighborEdge) > 0 {
+				continue
+			}
+			mg1.set(node, neighbor, 0)
+			mg1.set(node, nodeEdge, 1)
+			mg1.set(neighbor, neighborEdge, 1)
+		}
+	}
+	return mg1
+}
+
+// Candidates returns the nodes that may be placed as internal nodes, in increasing order.
+// These are the nodes that are not part of the matching of the suspicion graph,
+// excluding nodes that are suspected by both end points of a matched edge.
+// If there are no suspicions, all nodes are candidates.
+func (s Suspicions) Candidates() []int {
+	mg1 := s.matching()
+	v0 := mg1.zeroDegreeNodes()
+	v2 := make([]int, 0)
+	for a := range mg1 {
+		for b := a + 1; b < len(mg1); b++ {
+			if !mg1.suspected(a, b) {
+				continue
+			}
+			for _, v := range v0 {
+				if (s.suspected(a, v) && s.suspected(v, b)) || (s.suspected(b, v) && s.suspected(v, a)) {
+					v2 = append(v2, v)
+				}
+			}
+		}
+	}
+	candidates := make([]int, 0, len(v0))
+	for _, v := range v0 {
+		if !slices.Contains(v2, v) {
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,150 @@
+package opt
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var suspicionTests = []struct {
+	name           string
+	suspicions     Suspicions
+	wantDegrees    []int
+	wantZeroNodes  []int
+	wantCandidates []int
+}{
+	{
+		name: "All nodes suspect every other node",
+		suspicions: Suspicions{
+			{0, 1, 1, 1, 1},
+			{1, 0, 1, 1, 1},
+			{1, 1, 0, 1, 1},
+			{1, 1, 1, 0, 1},
+			{1, 1, 1, 1, 0},
+		},
+		wantDegrees:    []int{4, 4, 4, 4, 4},
+		wantZeroNodes:  []int{},
+		wantCandidates: []int{},
+	},
+	{
+		name: "No nodes suspect any other node",
+		suspicions: Suspicions{
+			{0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0},
+		},
+		wantDegrees:    []int{0, 0, 0, 0, 0},
+		wantZeroNodes:  []int{0, 1, 2, 3, 4},
+		wantCandidates: []int{0, 1, 2, 3, 4},
+	},
+	{
+		name: "Node 0 suspects all other nodes",
+		suspicions: Suspicions{
+			{0, 1, 1, 1, 1},
+			{1, 0, 0, 0, 0},
+			{1, 0, 0, 0, 0},
+			{1, 0, 0, 0, 0},
+			{1, 0, 0, 0, 0},
+		},
+		wantDegrees:    []int{4, 1, 1, 1, 1},
+		wantZeroNodes:  []int{},
+		wantCandidates: []int{2, 3, 4},
+	},
+	{
+		name: "Node suspicions: 0->{1,2}, 1->{0,3}, 2->{0}, 3->{0}, 4->{}",
+		suspicions: Suspicions{
+			{0, 1, 1, 0, 0}, // 0->{1,2}
+			{1, 0, 0, 1, 0}, // 1->{0,3}
+			{1, 0, 0, 0, 0}, // 2->{0}
+			{1, 0, 0, 0, 0}, // 3->{0}
+			{0, 0, 0, 0, 0}, // 4->{}
+		},
+		wantDegrees:    []int{2, 2, 1, 1, 0},
+		wantZeroNodes:  []int{4},
+		wantCandidates: []int{4},
+	},
+	{
+		name: "Node suspicions: 0->{1,2,3}, 1->{0,2,3}, 2->{0,1}, 3->{0,1}, 4->{}",
+		suspicions: Suspicions{
+			{0, 1, 1, 1, 0}, // 0->{1,2,3}
+			{1, 0, 1, 1, 0}, // 1->{0,2,3}
+			{1, 1, 0, 0, 0}, // 2->{0,1}
+			{1, 1, 0, 0, 0}, // 3->{0,1}
+			{0, 0, 0, 0, 0}, // 4->{}
+		},
+		wantDegrees:    []int{3, 3, 2, 2, 0},
+		wantZeroNodes:  []int{4},
+		wantCandidates: []int{4},
+	},
+	{
+		name: "Single suspicion between 1 and 3 in a graph of 7 nodes",
+		suspicions: func() Suspicions {
+			s := NewSuspicions(7)
+			s.Suspect(1, 3)
+			return s
+		}(),
+		wantDegrees:    []int{0, 1, 0, 1, 0, 0, 0},
+		wantZeroNodes:  []int{0, 2, 4, 5, 6},
+		wantCandidates: []int{0, 2, 4, 5, 6},
+	},
+}
+
+func TestNodeDegree(t *testing.T) {
+	for _, tt := range suspicionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			for node := range tt.suspicions {
+				if got := tt.suspicions.degree(node); got != tt.wantDegrees[node] {
+					t.Errorf("degree(%d) = %d, want %d", node, got, tt.wantDegrees[node])
+				}
+			}
+			if got := tt.suspicions.zeroDegreeNodes(); !cmp.Equal(got, tt.wantZeroNodes) {
+				t.Errorf("zeroDegreeNodes() = %v, want %v", got, tt.wantZeroNodes)
+			}
+		})
+	}
+}
+
+func TestIsEdgeAcceptable(t *testing.T) {
+	for _, tt := range suspicionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			for a := range tt.suspicions {
+				for b := range tt.suspicions {
+					want := tt.wantDegrees[a] != 1 && tt.wantDegrees[b] != 1
+					if got := tt.suspicions.isEdgeAcceptable(a, b); got != want {
+						t.Errorf("isEdgeAcceptable(%d, %d) = %t, want %t", a, b, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	for _, tt := range suspicionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.suspicions.Candidates(); !cmp.Equal(got, tt.wantCandidates) {
+				t.Errorf("Candidates() = %v, want %v", got, tt.wantCandidates)
+			}
+		})
+	}
+}
+
+func TestMatchingIsMatching(t *testing.T) {
+	for _, tt := range suspicionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg1 := tt.suspicions.matching()
+			for node := range mg1 {
+				if d := mg1.degree(node); d > 1 {
+					t.Errorf("degree(%d) = %d in matching, want at most 1", node, d)
+				}
+				for neighbor := range mg1[node] {
+					if mg1.suspected(node, neighbor) && !tt.suspicions.suspected(node, neighbor) && !tt.suspicions.suspected(neighbor, node) {
+						t.Errorf("matching has edge (%d, %d) not in the suspicion graph", node, neighbor)
+					}
+				}
+			}
+		})
+	}
+}
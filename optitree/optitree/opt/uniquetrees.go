@@ -1,4 +1,4 @@
-package main
+package opt
 
 // UniqueTrees generates all unique permutations of a root's subtree
 // such that each subtree group of size branchFactor is sorted.
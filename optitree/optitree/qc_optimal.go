@@ -3,32 +3,34 @@ package main
 import (
 	"fmt"
 	"time"
+
+	"optitree/opt"
 )
 
 // QCOptimalTreeChannel finds the tree with the lowest latency
 // to collect votes from a quorum of nodes.
 func (l Latencies) QCOptimalTreeChannel(params treeParams) result {
 	treeSize := len(params.baseTree)
-	qs := quorumSize(treeSize)
+	qs := opt.QuorumSize(treeSize)
 	results := make(chan result, treeSize)
 	for _, root := range params.baseTree {
 		go func() {
 			// Find the best latency for this root
 			best := result{
-				latency: Latency(10000000),
-				nodes:   make([]node, treeSize),
+				latency: opt.Latency(10000000),
+				nodes:   make([]opt.Node, treeSize),
 			}
 			tree := newSubtree(root, params.baseTree)
-			nodes := newNodes(root, tree)
+			nodes := opt.NewNodes(root, tree)
 			now := time.Now()
 
-			UniqueTrees(tree, params.bf, func(tree []int) {
-				resetNodes(nodes, tree)
-				latency := l.qcLatency(qs, params.bf, nodes, true)
+			opt.UniqueTrees(tree, params.bf, func(tree []int) {
+				opt.ResetNodes(nodes, tree)
+				latency := l.QCLatency(qs, params.bf, nodes, true)
 				if latency < best.latency {
 					best.latency = latency
 					copy(best.nodes, nodes)
-					// printf("%2d: Tree %v has best latency %s\n", root, toTree(best.nodes), best.latency)
+					// printf("%2d: Tree %v has best latency %s\n", root, opt.ToTree(best.nodes), best.latency)
 				}
 				best.analyzedTrees++
 				if params.logNow(best.analyzedTrees, root, now) {
@@ -38,7 +40,7 @@ func (l Latencies) QCOptimalTreeChannel(params treeParams) result {
 			results <- best
 		}()
 	}
-	optimal := result{latency: Latency(10000000)}
+	optimal := result{latency: opt.Latency(10000000)}
 	for range treeSize {
 		r := <-results
 		if r.latency < optimal.latency {
@@ -51,21 +53,17 @@ func (l Latencies) QCOptimalTreeChannel(params treeParams) result {
 }
 
 type result struct {
-	nodes         []node
-	latency       Latency
+	nodes         []opt.Node
+	latency       opt.Latency
 	analyzedTrees int
 	mean          float64
 	stdDev        float64
 }
 
 func (r result) String() string {
-	return fmt.Sprintf("tree: %v has latency: %s", toString(r.nodes), r.latency)
+	return fmt.Sprintf("tree: %v has latency: %s", opt.ToString(r.nodes), r.latency)
 }
 
 func (r result) GeTree() TreeConfig {
-	res := make([]int, 0, len(r.nodes))
-	for _, node := range r.nodes {
-		res = append(res, node.id)
-	}
-	return res
+	return opt.ToTree(r.nodes)
 }
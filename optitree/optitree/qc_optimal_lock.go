@@ -2,17 +2,19 @@ package main
 
 import (
 	"sync"
+
+	"optitree/opt"
 )
 
 // QCOptimalTreeMutex finds the tree with the lowest latency
 // to collect votes from a quorum of nodes.
 func (l Latencies) QCOptimalTreeMutex(params treeParams) result {
 	treeSize := len(params.baseTree)
-	qs := quorumSize(treeSize)
+	qs := opt.QuorumSize(treeSize)
 	var mutex sync.Mutex
 	optimal := result{
-		latency: Latency(10000000),
-		nodes:   make([]node, treeSize),
+		latency: opt.Latency(10000000),
+		nodes:   make([]opt.Node, treeSize),
 	}
 
 	var wg sync.WaitGroup
@@ -20,13 +22,13 @@ func (l Latencies) QCOptimalTreeMutex(params treeParams) result {
 		wg.Add(1)
 		go func(root int) {
 			defer wg.Done()
-			bestLatency := Latency(10000000)
+			bestLatency := opt.Latency(10000000)
 			tree := newSubtree(root, params.baseTree)
-			nodes := newNodes(root, tree)
+			nodes := opt.NewNodes(root, tree)
 
-			UniqueTrees(tree, params.bf, func(tree []int) {
-				resetNodes(nodes, tree)
-				latency := l.qcLatency(qs, params.bf, nodes, false)
+			opt.UniqueTrees(tree, params.bf, func(tree []int) {
+				opt.ResetNodes(nodes, tree)
+				latency := l.QCLatency(qs, params.bf, nodes, false)
 				if latency < bestLatency {
 					mutex.Lock()
 					optLat := optimal.latency
@@ -36,7 +38,7 @@ func (l Latencies) QCOptimalTreeMutex(params treeParams) result {
 						mutex.Unlock()
 
 						bestLatency = latency
-						printf("%2d: Tree %v has best latency %s\n", root, toTree(nodes), bestLatency)
+						printf("%2d: Tree %v has best latency %s\n", root, opt.ToTree(nodes), bestLatency)
 					} else {
 						mutex.Unlock()
 						bestLatency = optLat
@@ -3,56 +3,15 @@ package main
 import (
 	"math"
 	"math/rand/v2"
-	"slices"
 	"time"
 
 	"gonum.org/v1/gonum/stat"
+	"optitree/opt"
 )
 
+// ComputeBaseTree returns a tree rooted at root, where each node has its nearest nodes as children.
 func ComputeBaseTree(treeSize, root, bf int, l Latencies) []int {
-	tree := make([]int, treeSize)
-	tree[0] = root
-	taken := make([]bool, treeSize)
-	for i := range treeSize {
-		taken[i] = false
-	}
-	taken[root] = true
-	nearest := l.GetKNearest(root, bf, remaining(taken))
-	for i := range bf {
-		tree[i+1] = nearest[i]
-		taken[nearest[i]] = true
-	}
-	for i := range bf {
-		nearest := l.GetKNearest(tree[i+1], bf, remaining(taken))
-		for j := range len(nearest) {
-			taken[nearest[j]] = true
-			index := (i+1)*bf + j + 1
-			tree[index] = nearest[j]
-		}
-	}
-	return tree
-}
-
-// GetKNearest returns the k nearest nodes to the given node.
-// remainingNodes is modified in place.
-func (l Latencies) GetKNearest(node int, k int, remainingNodes []int) []int {
-	slices.SortFunc(remainingNodes, func(i, j int) int {
-		return l.distance(node, i, j)
-	})
-	if len(remainingNodes) > k {
-		return remainingNodes[:k]
-	}
-	return remainingNodes
-}
-
-func remaining(taken []bool) []int {
-	remaining := make([]int, 0)
-	for i, value := range taken {
-		if !value {
-			remaining = append(remaining, i)
-		}
-	}
-	return remaining
+	return l.BaseTree(treeSize, root, bf)
 }
 
 func evenFaults(n int) int {
@@ -70,7 +29,7 @@ func (l Latencies) SimulatedAnnealingWithFaults(params treeParams) result {
 			treeLatencies = append(treeLatencies, float64(result.latency))
 			newQuorum := params.scf + (j * params.scd)
 			newTree := result.GeTree()
-			otherTreeLatencies = append(otherTreeLatencies, float64(l.qcLatency(newQuorum, params.bf, newTree.AsNodes(), true)))
+			otherTreeLatencies = append(otherTreeLatencies, float64(l.QCLatency(newQuorum, params.bf, newTree.AsNodes(), true)))
 		}
 		mean, st1 := stat.MeanStdDev(treeLatencies, nil)
 		stat.MeanStdDev(otherTreeLatencies, nil)
@@ -85,11 +44,14 @@ func (l Latencies) SimulatedAnnealingWithFaults(params treeParams) result {
 	return result{}
 }
 
+// SimulatedAnnealing searches for a tree with low QC latency. Unlike opt.Anneal, which Kauri uses,
+// it compares each mutation with the last accepted tree, starting from a latency that any tree beats,
+// and returns the last accepted tree; the published results were produced with this search.
 func (l Latencies) SimulatedAnnealing(params treeParams) result {
 	timer := time.NewTimer(params.timeout)
 	tree := TreeConfig(params.baseTree)
 	nodes := tree.AsNodes()
-	quorumSize := quorumSize(params.nNodes)
+	quorumSize := opt.QuorumSize(params.nNodes)
 	if params.scf > 0 {
 		quorumSize = params.scf
 	}
@@ -97,7 +59,7 @@ func (l Latencies) SimulatedAnnealing(params treeParams) result {
 		quorumSize += params.faults
 	}
 	best := result{
-		latency: Latency(10000000),
+		latency: opt.Latency(10000000),
 		nodes:   nodes,
 	}
 	for params.temp > params.threshold {
@@ -108,22 +70,17 @@ func (l Latencies) SimulatedAnnealing(params treeParams) result {
 		default:
 			newSolution := mutate(tree, params.faultIndex)
 			nodes = newSolution.AsNodes()
-			latency := l.qcLatency(quorumSize, params.bf, nodes, (params.faultIndex > 0))
+			latency := l.QCLatency(quorumSize, params.bf, nodes, (params.faultIndex > 0))
 			if latency < best.latency {
 				tree = newSolution
 				best.latency = latency
 				copy(best.nodes, nodes)
-				//copy(best.tree, tree)
-				// printf("new tree %v\n", nodes)
-				// printf("quorum size %d, bf %d latency %v\n", quorumSize, params.bf, latency)
 			} else {
 				random := rand.Float64()
 				if math.Exp(-(float64(latency-best.latency) / params.temp)) > random {
 					tree = newSolution
 					best.latency = latency
 					copy(best.nodes, nodes)
-					//copy(best.tree, tree)
-					// printf("new tree %v\n", nodes)
 				}
 			}
 			// Cool system down
@@ -141,27 +98,14 @@ func (tc TreeConfig) IntN() int {
 	return rand.IntN(len(tc))
 }
 
-func (tc TreeConfig) AsNodes() []node {
-	nodes := make([]node, len(tc))
-	for i, id := range tc {
-		nodes[i] = node{id: id, votes: 1}
-	}
-	return nodes
+func (tc TreeConfig) AsNodes() []opt.Node {
+	return opt.AsNodes(tc)
 }
 
 // mutate the tree by swapping two nodes, such that the two nodes are swapped within the same
 // group of nodes (above or below faultIdx). The faultIdx is the index of the first faulty node.
 func mutate(tree TreeConfig, faultIdx int) TreeConfig {
-	idx1, idx2 := tree.IntN(), tree.IntN()
-	// If the two nodes (idx1 and idx2) aren't in the same group (above or below faultIdx), we try again.
-	for idx1 == idx2 || ((idx1 >= faultIdx) != (idx2 >= faultIdx)) {
-		idx2 = tree.IntN()
-		idx1 = tree.IntN()
-	}
-	// swap the two nodes in the same group.
-	newTree := slices.Clone(tree)
-	newTree[idx1], newTree[idx2] = newTree[idx2], newTree[idx1]
-	return newTree
+	return opt.Mutate(tree, 0, faultIdx, nil)
 }
 
 func (l Latencies) SimulatedAnnealingPerformance(params treeParams) result {
@@ -190,7 +134,7 @@ func (l Latencies) ParallelSimulatedAnnealing(params treeParams) result {
 			results <- l.SimulatedAnnealing(params)
 		}()
 	}
-	optimal := result{latency: Latency(10000000)}
+	optimal := result{latency: opt.Latency(10000000)}
 	for range treeSize {
 		r := <-results
 		if r.latency < optimal.latency {
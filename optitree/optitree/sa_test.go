@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"optitree/opt"
 )
 
 func TestComputeBaseTree(t *testing.T) {
@@ -25,13 +26,13 @@ func TestComputeBaseTree(t *testing.T) {
 func TestAsNodes(t *testing.T) {
 	treeConfig := TreeConfig{1, 4, 5, 6, 7, 2, 3}
 	nodes := treeConfig.AsNodes()
-	if nodes[6].id != 3 {
+	if nodes[6].ID != 3 {
 		t.Error("expected 6")
 	}
 }
 
 func TestGetTree(t *testing.T) {
-	res := result{nodes: []node{{id: 1}, {id: 2}, {id: 3}, {id: 4}, {id: 5}, {id: 6}, {id: 7}}}
+	res := result{nodes: []opt.Node{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}, {ID: 7}}}
 	tree := res.GeTree()
 	if tree[0] != 1 {
 		t.Error("expected 1")
@@ -80,7 +81,7 @@ func TestMutate(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		sz := TreeSize(tt.bf)
+		sz := opt.TreeSize(tt.bf)
 		for faultIdx := range sz + 1 {
 			t.Run(fmt.Sprintf("bf=%d/size=%v/idx=%d", tt.bf, sz, faultIdx), func(t *testing.T) {
 				tree := TreeConfig(basicTree(sz))
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"slices"
 	"testing"
+
+	"optitree/opt"
 )
 
 func TestQCLatency(t *testing.T) {
@@ -16,7 +18,7 @@ func TestQCLatency(t *testing.T) {
 		tree     []int
 		wantTree []int
 		bf       int
-		wantLat  Latency
+		wantLat  opt.Latency
 	}{
 		{
 			name:     "initial/stable",
@@ -55,14 +57,14 @@ func TestQCLatency(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		qs := quorumSize(len(tt.tree))
+		qs := opt.QuorumSize(len(tt.tree))
 		t.Run(fmt.Sprintf("%s/size=%d/bf=%d", tt.name, len(tt.tree), tt.bf), func(t *testing.T) {
-			nodes := newNodes(tt.tree[0], tt.tree[1:])
-			gotLat := latencies.qcLatency(qs, tt.bf, nodes, false)
+			nodes := opt.NewNodes(tt.tree[0], tt.tree[1:])
+			gotLat := latencies.QCLatency(qs, tt.bf, nodes, false)
 			if gotLat != tt.wantLat {
 				t.Errorf("qcLatency() = %d; want %d", gotLat, tt.wantLat)
 			}
-			gotTree := toTree(nodes)
+			gotTree := opt.ToTree(nodes)
 			if !slices.Equal(tt.wantTree, gotTree) {
 				t.Fail()
 				t.Logf("origTree: %v", tt.tree)
@@ -85,10 +87,11 @@ func TestQCOptimalTree(t *testing.T) {
 		bf       int
 	}{
 		{
+			// [3 1 2 0 5 7 15 8 17 19 9 12 16] has the same latency, but it comes later in the enumeration
 			bf:       3,
 			tree:     []int{0, 1, 2, 3, 5, 7, 8, 9, 12, 15, 16, 17, 19},
 			want:     result{latency: 191495, analyzedTrees: 4804800},
-			wantTree: []int{3, 1, 2, 0, 5, 7, 15, 8, 17, 19, 9, 12, 16},
+			wantTree: []int{3, 1, 2, 9, 5, 7, 15, 8, 17, 19, 0, 12, 16},
 		},
 	}
 	for _, tt := range tests {
@@ -101,7 +104,7 @@ func TestQCOptimalTree(t *testing.T) {
 			if gotOptimal.analyzedTrees != tt.want.analyzedTrees {
 				t.Errorf("QCOptimalTree() = %d; want %d", gotOptimal.analyzedTrees, tt.want.analyzedTrees)
 			}
-			gotTree := toTree(gotOptimal.nodes)
+			gotTree := opt.ToTree(gotOptimal.nodes)
 			if !slices.Equal(tt.wantTree, gotTree) {
 				t.Fail()
 				t.Logf("origTree: %v", tt.tree)
@@ -192,11 +195,11 @@ func BenchmarkQCLatency(b *testing.B) {
 		},
 	}
 	for _, tt := range tests {
-		qs := quorumSize(len(tt.tree))
+		qs := opt.QuorumSize(len(tt.tree))
 		b.Run(fmt.Sprintf("%s/size=%d/bf=%d", tt.name, len(tt.tree), tt.bf), func(b *testing.B) {
 			for range b.N {
-				nodes := newNodes(tt.tree[0], tt.tree[1:])
-				_ = latencies.qcLatency(qs, tt.bf, nodes, false)
+				nodes := opt.NewNodes(tt.tree[0], tt.tree[1:])
+				_ = latencies.QCLatency(qs, tt.bf, nodes, false)
 			}
 		})
 	}
@@ -1,45 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"slices"
 	"strconv"
 	"strings"
 )
 
-type node struct {
-	id           int
-	votes        int
-	disseminated Latency
-	aggregated   Latency
-	delivered    Latency
-}
-
-func (n node) String() string {
-	return fmt.Sprintf("id %d: votes: %d, disseminated: %d, aggregated: %d, delivered: %d", n.id, n.votes, n.disseminated, n.aggregated, n.delivered)
-}
-
-func toString(nodes []node) string {
-	var builder strings.Builder
-	builder.WriteString("[]int{")
-	for i, node := range nodes {
-		if i > 0 {
-			builder.WriteString(", ")
-		}
-		builder.WriteString(fmt.Sprintf("%d", node.id))
-	}
-	builder.WriteString("}")
-	return builder.String()
-}
-
-func toTree(nodes []node) []int {
-	tree := make([]int, len(nodes))
-	for i, node := range nodes {
-		tree[i] = node.id
-	}
-	return tree
-}
-
 // basicTree generates a basic tree with the given size,
 // where each node has an id from 0 to size-1.
 func basicTree(size int) []int {
@@ -70,30 +36,6 @@ func newSubtree(root int, baseTree []int) []int {
 	return append(newTree[:index], newTree[index+1:]...)
 }
 
-func newNodes(root int, tree []int) []node {
-	nodes := make([]node, len(tree)+1)
-	nodes[0] = node{id: root, votes: 1}
-	for i := range tree {
-		nodes[i+1] = node{id: tree[i], votes: 1}
-	}
-	return nodes
-}
-
-// resetNodes resets provided the nodes for reuse avoiding reallocation.
-func resetNodes(nodes []node, tree []int) {
-	for i := range nodes {
-		if i > 0 {
-			// since the root is at index 0 (and doesn't change),
-			// while the tree does not include the root
-			nodes[i].id = tree[i-1]
-		}
-		nodes[i].votes = 1
-		nodes[i].disseminated = 0
-		nodes[i].aggregated = 0
-		nodes[i].delivered = 0
-	}
-}
-
 func parseTreeString(s string) ([]int, error) {
 	tree := make([]int, 0)
 	s = strings.TrimPrefix(s, "[]int")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 	"time"
+
+	"optitree/opt"
 )
 
 type simulatedAnnealingParams struct {
@@ -18,7 +20,7 @@ type treeParams struct {
 	baseTree     []int
 	bf           int
 	nNodes       int
-	nTrees       int
+	nTrees       int64
 	treesPerRoot int
 	cadence      int
 	temp         float64
@@ -33,8 +35,8 @@ type treeParams struct {
 }
 
 func NewTreeParams(baseTree []int, bf, emitCadence, faults, scd int) treeParams {
-	nTrees := NumTrees(TreeSize(bf), bf)
-	treesPerRoot := nTrees / len(baseTree)
+	nTrees := opt.NumTrees(opt.TreeSize(bf), bf)
+	treesPerRoot := int(nTrees / int64(len(baseTree)))
 	return treeParams{
 		baseTree:     baseTree,
 		bf:           bf,
@@ -55,8 +57,8 @@ func (s *treeParams) SetSimulatedAnnealingParams(params simulatedAnnealingParams
 	s.scf = params.scf
 }
 
-func (s treeParams) emitEvents() int {
-	return s.nTrees / s.cadence
+func (s treeParams) emitEvents() int64 {
+	return s.nTrees / int64(s.cadence)
 }
 
 // remainingTime returns the number of trees per second and the estimated time to finish.
@@ -79,7 +81,7 @@ func (s treeParams) logNow(analyzedTrees int, root int, now time.Time) bool {
 }
 
 func (s treeParams) treesPerSecond(b *testing.B) float64 {
-	return float64((b.N)*s.nTrees) / b.Elapsed().Seconds()
+	return float64(int64(b.N)*s.nTrees) / b.Elapsed().Seconds()
 }
 
 func printf(format string, args ...interface{}) {
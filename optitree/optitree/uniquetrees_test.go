@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"optitree/opt"
 )
 
 func TestLoadLatencies(t *testing.T) {
@@ -14,13 +15,13 @@ func TestLoadLatencies(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(latencies) != len(cities) {
-		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies))
+	if len(latencies.Latencies) != len(cities) {
+		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies.Latencies))
 	}
-	if len(latencies[0]) != len(cities) {
-		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies[0]))
+	if len(latencies.Latencies[0]) != len(cities) {
+		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies.Latencies[0]))
 	}
-	for i, row := range latencies {
+	for i, row := range latencies.Latencies {
 		t.Logf("%3d: %15s: %v ... %v", i, cityName(i), row[:5], row[len(row)-5:])
 	}
 
@@ -28,13 +29,13 @@ func TestLoadLatencies(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(latencies) != len(cities) {
-		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies))
+	if len(latencies.Latencies) != len(cities) {
+		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies.Latencies))
 	}
-	if len(latencies[0]) != len(cities) {
-		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies[0]))
+	if len(latencies.Latencies[0]) != len(cities) {
+		t.Errorf("Expected %d latencies, got %d", len(cities), len(latencies.Latencies[0]))
 	}
-	for i, row := range latencies {
+	for i, row := range latencies.Latencies {
 		t.Logf("%3d: %15s: %v ... %v", i, cityName(i), row[:5], row[len(row)-5:])
 	}
 }
@@ -54,18 +55,18 @@ func TestCitiesLatencies(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(latencies) != wantCities {
-		t.Errorf("Expected %d latencies, got %d: %v", wantCities, len(latencies), latencies)
+	if len(latencies.Latencies) != wantCities {
+		t.Errorf("Expected %d latencies, got %d: %v", wantCities, len(latencies.Latencies), latencies.Latencies)
 	}
-	if len(latencies[0]) != wantCities {
-		t.Errorf("Expected %d latencies, got %d: %v", wantCities, len(latencies[0]), latencies[0])
+	if len(latencies.Latencies[0]) != wantCities {
+		t.Errorf("Expected %d latencies, got %d: %v", wantCities, len(latencies.Latencies[0]), latencies.Latencies[0])
 	}
 	if len(cities) != wantCities {
 		t.Errorf("Expected %d cities, got %d: %v", wantCities, len(cities), cities)
 	}
 
 	// Map of latencies between cities (in µs); generated by ChatGPT-4o based on the above table.
-	wantLatency := map[string]map[string]Latency{
+	wantLatency := map[string]map[string]opt.Latency{
 		"Melbourne": {
 			"Melbourne": 0,
 			"Toronto":   109296,
@@ -116,21 +117,21 @@ func TestCitiesLatencies(t *testing.T) {
 		},
 	}
 
-	for i := range len(latencies) {
-		t.Logf("%d: %s: %v", i, cityName(i), latencies[i])
+	for i := range len(latencies.Latencies) {
+		t.Logf("%d: %s: %v", i, cityName(i), latencies.Latencies[i])
 	}
 
-	for from := range len(latencies) {
-		for to := range len(latencies) {
+	for from := range len(latencies.Latencies) {
+		for to := range len(latencies.Latencies) {
 			fCity, tCity := cityName(from), cityName(to)
 			name := fmt.Sprintf("from=%d=%s/to=%d=%s", from, fCity, to, tCity)
 			t.Run(name, func(t *testing.T) {
-				got := latencies.pathLatency(from, to)
+				got := latencies.Path(from, to)
 				want := wantLatency[fCity][tCity]
 				if got != want {
 					t.Errorf("latencies[%d=%s][%d=%s] = %d, want %d", from, fCity, to, tCity, got, want)
 				}
-				gotReverse := latencies.pathLatency(to, from)
+				gotReverse := latencies.Path(to, from)
 				wantReverse := wantLatency[tCity][fCity]
 				if gotReverse != wantReverse {
 					t.Errorf("latencies[%d=%s][%d=%s] = %d, want %d", to, tCity, from, fCity, gotReverse, wantReverse)
@@ -143,9 +144,9 @@ func TestCitiesLatencies(t *testing.T) {
 func TestNumTrees(t *testing.T) {
 	tree := []int{0, 1, 2, 3, 5, 7, 8, 9, 12, 15, 16, 17, 19}
 	var want int64 = 4804800
-	got := NumTrees(len(tree), 3)
+	got := opt.NumTrees(len(tree), 3)
 	if got != want {
-		t.Errorf("NumTrees(%d, %d) = %d, want %d", len(tree), 3, got, want)
+		t.Errorf("opt.NumTrees(%d, %d) = %d, want %d", len(tree), 3, got, want)
 	}
 }
 
@@ -153,9 +154,9 @@ func TestNumTreesBig(t *testing.T) {
 	// not a real test, just to see how big the numbers get
 	for bf := range 11 {
 		t.Run(fmt.Sprintf("bf=%d", bf), func(t *testing.T) {
-			sz := TreeSize(bf)
-			t.Logf("NumTreesInt64 (%d, %d) = %d", sz, bf, NumTrees(sz, bf))
-			t.Logf("NumTreesBigInt(%d, %d) = %d", sz, bf, NumTrees2(sz, bf))
+			sz := opt.TreeSize(bf)
+			t.Logf("NumTreesInt64 (%d, %d) = %d", sz, bf, opt.NumTrees(sz, bf))
+			t.Logf("NumTreesBigInt(%d, %d) = %d", sz, bf, opt.NumTrees2(sz, bf))
 		})
 	}
 }
@@ -179,7 +180,7 @@ func TestPrintTree(t *testing.T) {
 	tree = []int{3, 0, 1, 2, 4, 5, 6, 13, 14, 9, 10, 11, 15, 7, 8, 16, 18, 17, 12, 19, 20}
 	latencies.Print(tree, bf)
 
-	lat := latencies.treeLatency(tree[0], bf, tree[1:])
+	lat := latencies.TreeLatency(tree[0], bf, tree[1:])
 	t.Logf("Total latency: %d", lat)
 }
 
@@ -189,7 +190,7 @@ func TestTreeLatency(t *testing.T) {
 	branchFactor := 3
 	tree := []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
 
-	totalLatency := latencies.treeLatency(root, branchFactor, tree)
+	totalLatency := latencies.TreeLatency(root, branchFactor, tree)
 	t.Logf("Total latency: %d", totalLatency)
 }
 
@@ -254,12 +255,12 @@ func TestUniqueTrees(t *testing.T) {
 		// {bf: 4}, // 6416344935000 trees (will take forever)
 	}
 	for _, tt := range tests {
-		size := TreeSize(tt.bf)
-		t.Run(fmt.Sprintf("size=%v/bf=%d/trees=%d", size, tt.bf, NumTrees(size, tt.bf)), func(t *testing.T) {
+		size := opt.TreeSize(tt.bf)
+		t.Run(fmt.Sprintf("size=%v/bf=%d/trees=%d", size, tt.bf, opt.NumTrees(size, tt.bf)), func(t *testing.T) {
 			baseTree := generateBaseTree(size, 0)
 			uniqueTreesPerRoot := 0
 			// Count the number of unique trees for a single root
-			UniqueTrees(baseTree, tt.bf, func(tree []int) {
+			opt.UniqueTrees(baseTree, tt.bf, func(tree []int) {
 				uniqueTreesPerRoot++
 				if len(tree) != len(baseTree) {
 					t.Errorf("len(tree) = %d, want=%d", len(tree), len(baseTree))
@@ -268,7 +269,7 @@ func TestUniqueTrees(t *testing.T) {
 					t.Errorf("The generated tree %v is not sorted", tree)
 				}
 			})
-			wantUniqueTrees := NumTrees(size, tt.bf)
+			wantUniqueTrees := opt.NumTrees(size, tt.bf)
 			gotUniqueTrees := int64(uniqueTreesPerRoot * size)
 			if gotUniqueTrees != wantUniqueTrees {
 				t.Errorf("Number of unique trees = %d, want=%d", gotUniqueTrees, wantUniqueTrees)
@@ -289,14 +290,14 @@ func BenchmarkUniqueTrees(b *testing.B) {
 		name string
 		bf   int
 	}{
-		{name: "UniqueTrees", fn: UniqueTrees, bf: 2},
+		{name: "UniqueTrees", fn: opt.UniqueTrees, bf: 2},
 		{name: "UniqueTreesStruct", fn: UniqueTreesStruct, bf: 2},
-		{name: "UniqueTrees", fn: UniqueTrees, bf: 3},
+		{name: "UniqueTrees", fn: opt.UniqueTrees, bf: 3},
 		{name: "UniqueTreesStruct", fn: UniqueTreesStruct, bf: 3},
 	}
 	for _, tt := range tests {
-		size := TreeSize(tt.bf)
-		trees := NumTrees(size, tt.bf)
+		size := opt.TreeSize(tt.bf)
+		trees := opt.NumTrees(size, tt.bf)
 		b.Run(fmt.Sprintf("name=%s/size=%v/bf=%d/trees=%d", tt.name, size, tt.bf, trees), func(b *testing.B) {
 			tree := generateBaseTree(size, 0)
 			b.ResetTimer()
@@ -91,6 +91,9 @@ type Config struct {
 	mgr             *hotstuffpb.Manager
 	isActiveReplica bool
 	ranking         modules.Ranking
+	traffic         modules.TrafficCounter
+	// reconfigurations that take effect in a future view.
+	pendingReconfigurations []hotstuff.ReconfigurationMsg
 	subConfig
 }
 
@@ -102,6 +105,7 @@ type subConfig struct {
 	replicas             map[hotstuff.ID]modules.Replica
 	passiveConfiguration *hotstuffpb.Configuration
 	quorumMap            map[hotstuff.View]int
+	activeMap            map[hotstuff.View][]hotstuff.ID
 	locationInfo         map[hotstuff.ID]string
 	mgr                  *hotstuffpb.Manager
 }
@@ -115,6 +119,7 @@ func (cfg *Config) InitModule(mods *modules.Core) {
 		&cfg.synchronizer,
 	)
 	mods.TryGet(&cfg.ranking)
+	mods.TryGet(&cfg.traffic)
 	// We delay processing `replicaConnected` events until after the configurations `connected` event has occurred.
 	cfg.eventLoop.RegisterHandler(replicaConnected{}, func(event any) {
 		if !cfg.connected {
@@ -132,6 +137,9 @@ func (cfg *Config) InitModule(mods *modules.Core) {
 		checkLatencyVector := event.(hotstuff.CheckLatencyVector)
 		cfg.handleLatencyVector(checkLatencyVector)
 	})
+	cfg.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		cfg.applyPendingReconfigurations(event.(synchronizer.ViewChangeEvent).View)
+	})
 
 }
 
@@ -182,6 +190,7 @@ func (cfg *Config) createSubConfiguration(activeIDs []hotstuff.ID) (sub *subConf
 		cfg:                  newCfg,
 		passiveConfiguration: passiveCfg,
 		quorumMap:            cfg.quorumMap,
+		activeMap:            cfg.activeMap,
 		mgr:                  cfg.mgr,
 		locationInfo:         cfg.locationInfo,
 	}, nil
@@ -209,10 +218,43 @@ func (cfg *Config) handleLatencyVector(latencyVector hotstuff.CheckLatencyVector
 	}
 }
 
-// handleReconfigurationEvent handles the reconfiguration request.
+// handleReconfigurationEvent schedules the reconfiguration for its view.
+// The quorum size and leader schedule are recorded immediately, since they are indexed by view,
+// while the active sub-configuration is switched when the replica reaches the view.
 func (cfg *Config) handleReconfigurationEvent(reconfigurationMsg hotstuff.ReconfigurationMsg) {
-	cfg.logger.Info("handling the configuration update event")
+	activeIDs := append([]hotstuff.ID(nil), reconfigurationMsg.ActiveReplicas...)
+	sort.Slice(activeIDs, func(i, j int) bool { return activeIDs[i] < activeIDs[j] })
+	if scheduled, ok := cfg.activeMap[reconfigurationMsg.View]; ok && equalIDs(scheduled, activeIDs) {
+		// already scheduled, e.g., committed locally and also received from the proposer.
+		return
+	}
+	cfg.logger.Infof("scheduling reconfiguration to %v in view %d", activeIDs, reconfigurationMsg.View)
 	cfg.quorumMap[reconfigurationMsg.View] = reconfigurationMsg.QuorumSize
+	cfg.activeMap[reconfigurationMsg.View] = activeIDs
+	if cfg.synchronizer.View() < reconfigurationMsg.View {
+		cfg.pendingReconfigurations = append(cfg.pendingReconfigurations, reconfigurationMsg)
+		return
+	}
+	cfg.applyReconfiguration(reconfigurationMsg)
+}
+
+// applyPendingReconfigurations applies the pending reconfigurations that take effect at or before view.
+func (cfg *Config) applyPendingReconfigurations(view hotstuff.View) {
+	pending := cfg.pendingReconfigurations[:0]
+	for _, reconfigurationMsg := range cfg.pendingReconfigurations {
+		if reconfigurationMsg.View <= view {
+			cfg.applyReconfiguration(reconfigurationMsg)
+		} else {
+			pending = append(pending, reconfigurationMsg)
+		}
+	}
+	cfg.pendingReconfigurations = pending
+}
+
+// applyReconfiguration switches to the active sub-configuration of the reconfiguration
+// and pauses or resumes the synchronizer if this replica leaves or joins the active replicas.
+func (cfg *Config) applyReconfiguration(reconfigurationMsg hotstuff.ReconfigurationMsg) {
+	cfg.logger.Info("handling the configuration update event")
 	myId := cfg.subConfig.opts.ID()
 	isActive := false
 	for _, id := range reconfigurationMsg.ActiveReplicas {
@@ -248,6 +290,12 @@ func (cfg *subConfig) GetLatency(sender hotstuff.ID, receiver hotstuff.ID) time.
 	return latencies[cfg.locationInfo[sender]][cfg.locationInfo[receiver]]
 }
 
+// LocationLatency returns the latency between two locations, or false if either location is unknown.
+func LocationLatency(from, to string) (time.Duration, bool) {
+	latency, ok := latencies[from][to]
+	return latency, ok
+}
+
 // NewConfig creates a new configuration.
 func NewConfig(creds credentials.TransportCredentials, locationInfo map[hotstuff.ID]string, opts ...gorums.ManagerOption) *Config {
 	if creds == nil {
@@ -265,6 +313,7 @@ func NewConfig(creds credentials.TransportCredentials, locationInfo map[hotstuff
 		subConfig: subConfig{
 			replicas:     make(map[hotstuff.ID]modules.Replica),
 			quorumMap:    make(map[hotstuff.View]int),
+			activeMap:    make(map[hotstuff.View][]hotstuff.ID),
 			locationInfo: locationInfo,
 		},
 		opts:            opts,
@@ -341,8 +390,6 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 
 	opts = append(opts, gorums.WithMetadata(md))
 
-	cfg.mgr = hotstuffpb.NewManager(opts...)
-
 	// set up an ID mapping to give to gorums
 	idMapping := make(map[string]uint32, len(replicas))
 	for _, replica := range replicas {
@@ -361,6 +408,13 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 		}
 	}
 
+	if cfg.traffic != nil {
+		opts = append(opts, gorums.WithGrpcDialOptions(
+			grpc.WithChainStreamInterceptor(trafficClientInterceptor(cfg.traffic, idMapping)),
+		))
+	}
+	cfg.mgr = hotstuffpb.NewManager(opts...)
+
 	// this will connect to the replicas
 	cfg.cfg, err = cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeMap(idMapping))
 	if err != nil {
@@ -403,7 +457,7 @@ func (cfg *Config) SubConfig(ids []hotstuff.ID) (sub modules.Configuration, err
 		nids[i] = uint32(id)
 		replicas[id] = cfg.replicas[id]
 	}
-	newCfg, err := cfg.mgr.NewConfiguration(gorums.WithNodeIDs(nids))
+	newCfg, err := cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeIDs(nids))
 	if err != nil {
 		return nil, err
 	}
@@ -428,6 +482,16 @@ func (cfg *subConfig) ActiveReplicas() map[hotstuff.ID]modules.Replica {
 	return activeReplicas
 }
 
+// ActiveReplicasAt returns the IDs of the active replicas in the given view, in increasing order.
+// This is the set of the latest reconfiguration at or before the view.
+// It returns false if there has been no reconfiguration at or before the view.
+func (cfg *subConfig) ActiveReplicasAt(view hotstuff.View) ([]hotstuff.ID, bool) {
+	if v, ok := latestView(cfg.activeMap, view); ok {
+		return cfg.activeMap[v], true
+	}
+	return nil, false
+}
+
 func (cfg *subConfig) Reconfiguration(reconfigurationMsg hotstuff.ReconfigurationMsg) {
 	ctx, cancel := synchronizer.TimeoutContext(cfg.eventLoop.Context(), cfg.eventLoop)
 	defer cancel()
@@ -448,13 +512,35 @@ func (cfg *subConfig) Len() int {
 	return len(cfg.replicas)
 }
 
-// QuorumSize returns the size of a quorum
+// QuorumSize returns the size of a quorum in the given view,
+// as set by the latest reconfiguration at or before the view.
 func (cfg *subConfig) QuorumSize(view hotstuff.View) int {
-	qs, ok := cfg.quorumMap[view]
-	if !ok {
-		qs = hotstuff.QuorumSize(len(cfg.ActiveReplicas()))
+	if v, ok := latestView(cfg.quorumMap, view); ok {
+		return cfg.quorumMap[v]
 	}
-	return qs
+	return hotstuff.QuorumSize(len(cfg.ActiveReplicas()))
+}
+
+// latestView returns the highest view in m that is at or before view.
+func latestView[T any](m map[hotstuff.View]T, view hotstuff.View) (latest hotstuff.View, found bool) {
+	for v := range m {
+		if v <= view && (!found || v > latest) {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+func equalIDs(a, b []hotstuff.ID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // Propose sends the block to all replicas in the configuration
@@ -500,31 +586,91 @@ func (cfg *subConfig) Timeout(msg hotstuff.TimeoutMsg) {
 	)
 }
 
+// fetchConfiguration returns the configuration of both the active and the passive replicas,
+// which may all have the blocks that this replica is missing.
+func (cfg *subConfig) fetchConfiguration() (*hotstuffpb.Configuration, bool) {
+	if cfg.passiveConfiguration == nil {
+		return cfg.cfg, true
+	}
+	allConfig, err := cfg.mgr.NewConfiguration(qspec{}, cfg.cfg.And(cfg.passiveConfiguration))
+	if err != nil {
+		cfg.logger.Info("Error in fetch ", err)
+		return nil, false
+	}
+	return allConfig, true
+}
+
+// logFetchError logs the error of a fetch quorum call, unless the call was cancelled.
+func (cfg *subConfig) logFetchError(err error) {
+	qcErr, ok := err.(gorums.QuorumCallError)
+	// filter out context errors
+	if !ok || (qcErr.Reason != context.Canceled.Error() && qcErr.Reason != context.DeadlineExceeded.Error()) {
+		cfg.logger.Infof("Failed to fetch block: %v", err)
+	}
+}
+
 // Fetch requests a block from all the replicas in the configuration
 func (cfg *subConfig) Fetch(ctx context.Context, hash hotstuff.Hash) (*hotstuff.Block, bool) {
-	var allConfig *hotstuffpb.Configuration
-	var err error
-	if cfg.passiveConfiguration != nil {
-		allConfig, err = cfg.mgr.NewConfiguration(qspec{}, cfg.cfg.And(cfg.passiveConfiguration))
-		if err != nil {
-			cfg.logger.Info("Error in fetch ", err)
-			return nil, false
-		}
-	} else {
-		allConfig = cfg.cfg
+	allConfig, ok := cfg.fetchConfiguration()
+	if !ok {
+		return nil, false
 	}
 	protoBlock, err := allConfig.Fetch(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
 	if err != nil {
-		qcErr, ok := err.(gorums.QuorumCallError)
-		// filter out context errors
-		if !ok || (qcErr.Reason != context.Canceled.Error() && qcErr.Reason != context.DeadlineExceeded.Error()) {
-			cfg.logger.Infof("Failed to fetch block: %v", err)
-		}
+		cfg.logFetchError(err)
 		return nil, false
 	}
 	return hotstuffpb.BlockFromProto(protoBlock), true
 }
 
+// FetchRange requests a block and up to count-1 of its ancestors from all the replicas in the configuration,
+// stopping before the block with the stop hash.
+func (cfg *subConfig) FetchRange(ctx context.Context, hash, stop hotstuff.Hash, count int) ([]*hotstuff.Block, bool) {
+	allConfig, ok := cfg.fetchConfiguration()
+	if !ok {
+		return nil, false
+	}
+	protoBlocks, err := allConfig.FetchRange(ctx, &hotstuffpb.BlockRange{
+		Hash:  hash[:],
+		Count: uint32(count),
+		Stop:  stop[:],
+	})
+	if err != nil {
+		cfg.logFetchError(err)
+		return nil, false
+	}
+	blocks := make([]*hotstuff.Block, 0, len(protoBlocks.GetBlocks()))
+	for _, pb := range protoBlocks.GetBlocks() {
+		blocks = append(blocks, hotstuffpb.BlockFromProto(pb))
+	}
+	return blocks, true
+}
+
+// Checkpoint sends the signed checkpoint to all replicas.
+func (cfg *subConfig) Checkpoint(msg hotstuff.CheckpointMsg) {
+	if cfg.cfg == nil {
+		return
+	}
+	ctx, cancel := synchronizer.TimeoutContext(cfg.eventLoop.Context(), cfg.eventLoop)
+	defer cancel()
+	cfg.cfg.Checkpoint(ctx, hotstuffpb.CheckpointMsgToProto(msg))
+}
+
+// FetchCheckpoint requests a stable checkpoint whose height is at least the given height from all the replicas
+// in the configuration, along with the state that is needed to install it.
+func (cfg *subConfig) FetchCheckpoint(ctx context.Context, height uint64) (hotstuff.CheckpointSnapshot, bool) {
+	allConfig, ok := cfg.fetchConfiguration()
+	if !ok {
+		return hotstuff.CheckpointSnapshot{}, false
+	}
+	snapshot, err := allConfig.FetchCheckpoint(ctx, &hotstuffpb.CheckpointRequest{Height: height})
+	if err != nil {
+		cfg.logFetchError(err)
+		return hotstuff.CheckpointSnapshot{}, false
+	}
+	return hotstuffpb.CheckpointSnapshotFromProto(snapshot), true
+}
+
 // Close closes all connections made by this configuration.
 func (cfg *Config) Close() {
 	cfg.mgr.Close()
@@ -550,16 +696,28 @@ func (sub *subConfig) GetCommittees(size int, isPhase1 bool) map[int][]hotstuff.
 				}
 			}
 			for len(tempCommittee) < size {
+				found := false
 				for id, tempLocation := range sub.locationInfo {
 					if !isIdTaken[id] && tempLocation == location {
 						tempCommittee = append(tempCommittee, id)
 						isIdTaken[id] = true
+						found = true
 					}
 					if len(tempCommittee) == size {
 						break
 					}
 				}
-				location = findNearestLocation(location, sub.locationInfo)
+				if !found {
+					// the nearest location may have no replicas left
+					for id, tempLocation := range sub.locationInfo {
+						if !isIdTaken[id] {
+							location = tempLocation
+							break
+						}
+					}
+				} else {
+					location = findNearestLocation(location, sub.locationInfo)
+				}
 			}
 			committees[formed+1] = tempCommittee
 			formed += 1
@@ -717,7 +875,11 @@ func isPresent(location string, locationInfo map[hotstuff.ID]string) bool {
 	return false
 }
 
-var _ modules.Configuration = (*Config)(nil)
+var (
+	_ modules.Configuration           = (*Config)(nil)
+	_ modules.RangeFetcher            = (*Config)(nil)
+	_ modules.CheckpointConfiguration = (*Config)(nil)
+)
 
 type qspec struct{}
 
@@ -735,5 +897,49 @@ func (q qspec) FetchQF(in *hotstuffpb.BlockHash, replies map[uint32]*hotstuffpb.
 	return nil, false
 }
 
+// FetchRangeQF is the quorum function for the FetchRange quorum call method.
+// It returns the first reply that starts with the requested block and where each block is the parent of the previous.
+func (q qspec) FetchRangeQF(in *hotstuffpb.BlockRange, replies map[uint32]*hotstuffpb.Blocks) (*hotstuffpb.Blocks, bool) {
+	var h hotstuff.Hash
+	copy(h[:], in.GetHash())
+	for _, reply := range replies {
+		if len(reply.GetBlocks()) == 0 || len(reply.GetBlocks()) > int(in.GetCount()) {
+			continue
+		}
+		next := h
+		linked := true
+		for _, b := range reply.GetBlocks() {
+			block := hotstuffpb.BlockFromProto(b)
+			if block.Hash() != next {
+				linked = false
+				break
+			}
+			next = block.Parent()
+		}
+		if linked {
+			return reply, true
+		}
+	}
+	return nil, false
+}
+
+// FetchCheckpointQF is the quorum function for the FetchCheckpoint quorum call method.
+// It returns the first reply whose checkpoint has at least the requested height and includes the block of the checkpoint.
+// The certificate and the state of the checkpoint are verified by the synchronizer.
+func (q qspec) FetchCheckpointQF(in *hotstuffpb.CheckpointRequest, replies map[uint32]*hotstuffpb.CheckpointSnapshot) (*hotstuffpb.CheckpointSnapshot, bool) {
+	for _, reply := range replies {
+		checkpoint := reply.GetCert().GetCheckpoint()
+		if checkpoint.GetHeight() < in.GetHeight() || reply.GetBlock() == nil {
+			continue
+		}
+		var h hotstuff.Hash
+		copy(h[:], checkpoint.GetBlock())
+		if hotstuffpb.BlockFromProto(reply.GetBlock()).Hash() == h {
+			return reply, true
+		}
+	}
+	return nil, false
+}
+
 // ConnectedEvent is sent when the configuration has connected to the other replicas.
 type ConnectedEvent struct{}
@@ -0,0 +1,93 @@
+package backend
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+)
+
+func TestActiveReplicasAt(t *testing.T) {
+	cfg := NewConfig(nil, nil)
+	for id := hotstuff.ID(1); id <= 4; id++ {
+		cfg.replicas[id] = &Replica{id: id, active: true}
+	}
+	cfg.activeMap[10] = []hotstuff.ID{1, 3, 4}
+	cfg.activeMap[20] = []hotstuff.ID{1, 2}
+	tests := []struct {
+		view   hotstuff.View
+		want   []hotstuff.ID
+		wantOK bool
+	}{
+		{view: 1, want: nil, wantOK: false},
+		{view: 9, want: nil, wantOK: false},
+		{view: 10, want: []hotstuff.ID{1, 3, 4}, wantOK: true},
+		{view: 19, want: []hotstuff.ID{1, 3, 4}, wantOK: true},
+		{view: 25, want: []hotstuff.ID{1, 2}, wantOK: true},
+	}
+	for _, tt := range tests {
+		got, ok := cfg.ActiveReplicasAt(tt.view)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || ok != tt.wantOK {
+			t.Errorf("ActiveReplicasAt(%d) = (%v, %t), want (%v, %t)", tt.view, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestQuorumSizeAt(t *testing.T) {
+	cfg := NewConfig(nil, nil)
+	for id := hotstuff.ID(1); id <= 7; id++ {
+		cfg.replicas[id] = &Replica{id: id, active: true}
+	}
+	cfg.quorumMap[10] = 4
+	cfg.quorumMap[20] = 6
+	tests := []struct {
+		view hotstuff.View
+		want int
+	}{
+		{view: 1, want: 5},
+		{view: 10, want: 4},
+		{view: 19, want: 4},
+		{view: 20, want: 6},
+		{view: 100, want: 6},
+	}
+	for _, tt := range tests {
+		if got := cfg.QuorumSize(tt.view); got != tt.want {
+			t.Errorf("QuorumSize(%d) = %d, want %d", tt.view, got, tt.want)
+		}
+	}
+}
+
+func TestFetchRangeQF(t *testing.T) {
+	var blocks []*hotstuffpb.Block
+	parent := hotstuff.GetGenesis()
+	for view := hotstuff.View(1); view <= 3; view++ {
+		block := hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, view-1, parent.Hash(), nil), "cmd", view, 1, time.Unix(0, 0))
+		blocks = append([]*hotstuffpb.Block{hotstuffpb.BlockToProto(block)}, blocks...)
+		parent = block
+	}
+	hash := parent.Hash()
+	unlinked := []*hotstuffpb.Block{blocks[0], blocks[2]}
+	tests := []struct {
+		name    string
+		replies map[uint32]*hotstuffpb.Blocks
+		want    int
+		ok      bool
+	}{
+		{name: "Linked", replies: map[uint32]*hotstuffpb.Blocks{1: {Blocks: blocks}}, want: 3, ok: true},
+		{name: "Empty", replies: map[uint32]*hotstuffpb.Blocks{1: {}}, ok: false},
+		{name: "WrongBlock", replies: map[uint32]*hotstuffpb.Blocks{1: {Blocks: blocks[1:]}}, ok: false},
+		{name: "Unlinked", replies: map[uint32]*hotstuffpb.Blocks{1: {Blocks: unlinked}}, ok: false},
+		{name: "TooMany", replies: map[uint32]*hotstuffpb.Blocks{1: {Blocks: append(blocks, blocks[2])}}, ok: false},
+		{name: "OneLinked", replies: map[uint32]*hotstuffpb.Blocks{1: {Blocks: unlinked}, 2: {Blocks: blocks[:2]}}, want: 2, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := qspec{}.FetchRangeQF(&hotstuffpb.BlockRange{Hash: hash[:], Count: 3}, tt.replies)
+			if ok != tt.ok || len(got.GetBlocks()) != tt.want {
+				t.Errorf("got %d blocks (%t), want %d (%t)", len(got.GetBlocks()), ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
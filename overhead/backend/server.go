@@ -14,6 +14,7 @@ import (
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
@@ -33,6 +34,8 @@ type Server struct {
 	locationInfo  map[hotstuff.ID]string
 	latencyMatrix map[string]time.Duration
 	gorumsSrv     *gorums.Server
+	traffic       modules.TrafficCounter
+	checkpointer  modules.Checkpointer
 }
 
 // InitModule initializes the Server.
@@ -44,6 +47,8 @@ func (srv *Server) InitModule(mods *modules.Core) {
 		&srv.logger,
 		&srv.opts,
 	)
+	mods.TryGet(&srv.traffic)
+	mods.TryGet(&srv.checkpointer)
 }
 
 // NewServer creates a new Server.
@@ -60,12 +65,17 @@ func NewServer(opts ...ServerOptions) *Server {
 	options.gorumsSrvOpts = append(options.gorumsSrvOpts, gorums.WithConnectCallback(func(ctx context.Context) {
 		srv.eventLoop.AddEvent(replicaConnected{ctx})
 	}))
+	options.gorumsSrvOpts = append(options.gorumsSrvOpts, gorums.WithGRPCServerOptions(
+		grpc.ChainStreamInterceptor(srv.countTraffic),
+	))
 	srv.gorumsSrv = gorums.NewServer(options.gorumsSrvOpts...)
 	hotstuffpb.RegisterHotstuffServer(srv.gorumsSrv, &serviceImpl{srv})
 	return srv
 }
 
-func (srv *Server) induceLatency(sender hotstuff.ID) {
+// InduceLatency blocks for the latency between the locations of the sender and this replica.
+// It does nothing if this replica has the default location.
+func (srv *Server) InduceLatency(sender hotstuff.ID) {
 	if srv.location == hotstuff.DefaultLocation {
 		return
 	}
@@ -77,7 +87,7 @@ func (srv *Server) induceLatency(sender hotstuff.ID) {
 	senderLocation := srv.locationInfo[sender]
 	senderLatency := srv.latencyMatrix[senderLocation]
 	srv.logger.Debugf("latency from server %s to server %s is %s\n", srv.location, senderLocation, senderLatency)
-	senderLatency = time.Duration((int64(count) * int64(senderLatency) * int64(time.Millisecond)))
+	senderLatency = time.Duration(count * float64(senderLatency))
 	timer1 := time.NewTimer(senderLatency)
 	<-timer1.C
 }
@@ -168,10 +178,13 @@ func (impl *serviceImpl) Propose(ctx gorums.ServerCtx, proposal *hotstuffpb.Prop
 		return
 	}
 
-	proposal.Block.Proposer = uint32(id)
+	if !impl.srv.opts.ShouldUseKauri() {
+		// with Kauri, the proposal is forwarded by the sender's parent in the tree.
+		proposal.Block.Proposer = uint32(id)
+	}
 	proposeMsg := hotstuffpb.ProposalFromProto(proposal)
 	proposeMsg.ID = id
-	impl.srv.induceLatency(id)
+	impl.srv.InduceLatency(id)
 	impl.srv.eventLoop.AddEvent(proposeMsg)
 }
 
@@ -182,7 +195,7 @@ func (impl *serviceImpl) Vote(ctx gorums.ServerCtx, cert *hotstuffpb.PartialCert
 		impl.srv.logger.Infof("Failed to get client ID: %v", err)
 		return
 	}
-	impl.srv.induceLatency(id)
+	impl.srv.InduceLatency(id)
 	impl.srv.eventLoop.AddEvent(hotstuff.VoteMsg{
 		ID:          id,
 		PartialCert: hotstuffpb.PartialCertFromProto(cert),
@@ -196,7 +209,7 @@ func (impl *serviceImpl) NewView(ctx gorums.ServerCtx, msg *hotstuffpb.SyncInfo)
 		impl.srv.logger.Infof("Failed to get client ID: %v", err)
 		return
 	}
-	impl.srv.induceLatency(id)
+	impl.srv.InduceLatency(id)
 	impl.srv.eventLoop.AddEvent(hotstuff.NewViewMsg{
 		ID:       id,
 		SyncInfo: hotstuffpb.SyncInfoFromProto(msg),
@@ -218,6 +231,39 @@ func (impl *serviceImpl) Fetch(_ gorums.ServerCtx, pb *hotstuffpb.BlockHash) (*h
 	return hotstuffpb.BlockToProto(block), nil
 }
 
+// maxFetchRange is the maximum number of blocks that are returned for a FetchRange request.
+const maxFetchRange = 1000
+
+// FetchRange handles an incoming request for a block and its ancestors.
+// It returns the ancestors that are stored locally, up to the requested count or the stop block.
+func (impl *serviceImpl) FetchRange(_ gorums.ServerCtx, pb *hotstuffpb.BlockRange) (*hotstuffpb.Blocks, error) {
+	var hash, stop hotstuff.Hash
+	copy(hash[:], pb.GetHash())
+	copy(stop[:], pb.GetStop())
+
+	count := min(int(pb.GetCount()), maxFetchRange)
+	blocks := &hotstuffpb.Blocks{}
+	for len(blocks.Blocks) < count && (len(blocks.Blocks) == 0 || hash != stop) {
+		block, ok := impl.srv.blockChain.LocalGet(hash)
+		if !ok {
+			break
+		}
+		blocks.Blocks = append(blocks.Blocks, hotstuffpb.BlockToProto(block))
+		if block.View() == 0 {
+			// the genesis block has no parent
+			break
+		}
+		hash = block.Parent()
+	}
+	if len(blocks.Blocks) == 0 {
+		return nil, status.Errorf(codes.NotFound, "requested block was not found")
+	}
+
+	impl.srv.logger.Debugf("OnFetchRange: %.8s (%d blocks)", pb.GetHash(), len(blocks.Blocks))
+
+	return blocks, nil
+}
+
 // Timeout handles an incoming TimeoutMsg.
 func (impl *serviceImpl) Timeout(ctx gorums.ServerCtx, msg *hotstuffpb.TimeoutMsg) {
 	var err error
@@ -226,10 +272,36 @@ func (impl *serviceImpl) Timeout(ctx gorums.ServerCtx, msg *hotstuffpb.TimeoutMs
 	if err != nil {
 		impl.srv.logger.Infof("Could not get ID of replica: %v", err)
 	}
-	impl.srv.induceLatency(timeoutMsg.ID)
+	impl.srv.InduceLatency(timeoutMsg.ID)
 	impl.srv.eventLoop.AddEvent(timeoutMsg)
 }
 
+// Checkpoint handles a replica's signature of a checkpoint.
+func (impl *serviceImpl) Checkpoint(ctx gorums.ServerCtx, msg *hotstuffpb.SignedCheckpoint) {
+	id, err := GetPeerIDFromContext(ctx, impl.srv.configuration)
+	if err != nil {
+		impl.srv.logger.Infof("Failed to get client ID: %v", err)
+		return
+	}
+	checkpointMsg := hotstuffpb.CheckpointMsgFromProto(msg)
+	checkpointMsg.ID = id
+	impl.srv.eventLoop.AddEvent(checkpointMsg)
+}
+
+// FetchCheckpoint handles an incoming request for a stable checkpoint.
+// It returns the latest stable checkpoint of this replica, if its height is at least the requested height.
+func (impl *serviceImpl) FetchCheckpoint(_ gorums.ServerCtx, pb *hotstuffpb.CheckpointRequest) (*hotstuffpb.CheckpointSnapshot, error) {
+	if impl.srv.checkpointer == nil {
+		return nil, status.Errorf(codes.Unimplemented, "checkpoints are not enabled")
+	}
+	snapshot, ok := impl.srv.checkpointer.StableCheckpoint()
+	if !ok || snapshot.Cert.Checkpoint.Height < pb.GetHeight() {
+		return nil, status.Errorf(codes.NotFound, "requested checkpoint was not found")
+	}
+	impl.srv.logger.Debugf("OnFetchCheckpoint: %d", snapshot.Cert.Checkpoint.Height)
+	return hotstuffpb.CheckpointSnapshotToProto(snapshot), nil
+}
+
 // ReconfigurationRequest handles an incoming Reconfiguration request.
 func (impl *serviceImpl) ReconfigurationRequest(ctx gorums.ServerCtx, msg *hotstuffpb.ReconfigurationMsg) {
 	impl.srv.logger.Info("received reconfiguration request")
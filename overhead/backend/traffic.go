@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"context"
+	"net"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// messageSize returns the gRPC method and the serialized size of a gorums message,
+// as encoded by the gorums codec. It returns false if the message is not a gorums message.
+func messageSize(m any) (method string, size int, ok bool) {
+	msg, ok := m.(*gorums.Message)
+	if !ok {
+		return "", 0, false
+	}
+	mdSize := proto.Size(msg.Metadata)
+	msgSize := proto.Size(msg.Message)
+	size = protowire.SizeVarint(uint64(mdSize)) + mdSize + protowire.SizeVarint(uint64(msgSize)) + msgSize
+	return msg.Metadata.GetMethod(), size, true
+}
+
+// countedStream counts the messages sent and received on a gorums stream.
+type countedStream struct {
+	counter modules.TrafficCounter
+	peer    hotstuff.ID
+}
+
+func (cs countedStream) count(m any, sent bool) {
+	if method, size, ok := messageSize(m); ok {
+		cs.counter.CountMessage(cs.peer, method, size, sent)
+	}
+}
+
+type countedClientStream struct {
+	grpc.ClientStream
+	countedStream
+}
+
+func (s countedClientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.count(m, true)
+	}
+	return err
+}
+
+func (s countedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.count(m, false)
+	}
+	return err
+}
+
+type countedServerStream struct {
+	grpc.ServerStream
+	countedStream
+}
+
+func (s countedServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.count(m, true)
+	}
+	return err
+}
+
+func (s countedServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.count(m, false)
+	}
+	return err
+}
+
+// trafficClientInterceptor returns a stream interceptor that counts the messages on the streams
+// to the replicas with the given addresses.
+func trafficClientInterceptor(counter modules.TrafficCounter, addresses map[string]uint32) grpc.StreamClientInterceptor {
+	// gorums dials the resolved address of each node
+	resolved := make(map[string]uint32, len(addresses))
+	for addr, id := range addresses {
+		if tcpAddr, err := net.ResolveTCPAddr("tcp", addr); err == nil {
+			addr = tcpAddr.String()
+		}
+		resolved[addr] = id
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := resolved[cc.Target()]
+		if !ok {
+			return stream, nil
+		}
+		return countedClientStream{stream, countedStream{counter, hotstuff.ID(id)}}, nil
+	}
+}
+
+// countTraffic is a stream interceptor that counts the messages on the streams from the other replicas,
+// if a TrafficCounter module is present.
+func (srv *Server) countTraffic(server any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if srv.traffic == nil {
+		return handler(server, ss)
+	}
+	id, err := GetPeerIDFromContext(ss.Context(), srv.configuration)
+	if err != nil {
+		return handler(server, ss)
+	}
+	return handler(server, countedServerStream{ss, countedStream{srv.traffic, id}})
+}
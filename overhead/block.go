@@ -17,17 +17,26 @@ type Block struct {
 	cert     QuorumCert
 	view     View
 	time     time.Time
+	// the complaints of the proposer, which the replicas apply to their ranking state when they commit the block
+	complaints []*Complaint
 }
 
 // NewBlock creates a new Block
 func NewBlock(parent Hash, cert QuorumCert, cmd Command, view View, proposer ID, time time.Time) *Block {
+	return NewBlockWithComplaints(parent, cert, cmd, view, proposer, time, nil)
+}
+
+// NewBlockWithComplaints creates a new Block that carries complaints.
+// The complaints are part of the hash, such that the replicas that commit the block agree on its complaints.
+func NewBlockWithComplaints(parent Hash, cert QuorumCert, cmd Command, view View, proposer ID, time time.Time, complaints []*Complaint) *Block {
 	b := &Block{
-		parent:   parent,
-		cert:     cert,
-		cmd:      cmd,
-		view:     view,
-		proposer: proposer,
-		time:     time,
+		parent:     parent,
+		cert:       cert,
+		cmd:        cmd,
+		view:       view,
+		proposer:   proposer,
+		time:       time,
+		complaints: complaints,
 	}
 	// cache the hash immediately because it is too racy to do it in Hash()
 	b.hash = sha256.Sum256(b.ToBytes())
@@ -83,6 +92,11 @@ func (b *Block) View() View {
 	return b.view
 }
 
+// Complaints returns the complaints that the block carries.
+func (b *Block) Complaints() []*Complaint {
+	return b.complaints
+}
+
 // ToBytes returns the raw byte form of the Block, to be used for hashing, etc.
 func (b *Block) ToBytes() []byte {
 	buf := b.parent[:]
@@ -94,5 +108,12 @@ func (b *Block) ToBytes() []byte {
 	buf = append(buf, viewBuf[:]...)
 	buf = append(buf, []byte(b.cmd)...)
 	buf = append(buf, b.cert.ToBytes()...)
+	// the proofs are not included, since the replicas only use the complaints to rank each other
+	for _, complaint := range b.complaints {
+		buf = binary.LittleEndian.AppendUint64(buf, complaint.ID)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(complaint.Complainant))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(complaint.Complainee))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(complaint.ComplaintType))
+	}
 	return buf
 }
@@ -12,6 +12,9 @@ import (
 	"github.com/relab/hotstuff/synchronizer"
 )
 
+// fetchBatchSize is the number of blocks that are requested at a time when a replica fetches missing blocks.
+const fetchBatchSize = 100
+
 // blockChain stores a limited amount of blocks in a map.
 // blocks are evicted in LRU order.
 type blockChain struct {
@@ -22,6 +25,7 @@ type blockChain struct {
 
 	mut           sync.Mutex
 	pruneHeight   hotstuff.View
+	committed     hotstuff.Hash // the last committed block, which need not be fetched again
 	blocks        map[hotstuff.Hash]*hotstuff.Block
 	blockAtHeight map[hotstuff.View]*hotstuff.Block
 	pendingFetch  map[hotstuff.Hash]context.CancelFunc // allows a pending fetch operation to be cancelled
@@ -45,6 +49,7 @@ func New() modules.BlockChain {
 		pendingFetch:  make(map[hotstuff.Hash]context.CancelFunc),
 	}
 	bc.Store(hotstuff.GetGenesis())
+	bc.committed = hotstuff.GetGenesis().Hash()
 	return bc
 }
 
@@ -82,6 +87,8 @@ func (chain *blockChain) Get(hash hotstuff.Hash) (block *hotstuff.Block, ok bool
 	var (
 		ctx    context.Context
 		cancel context.CancelFunc
+		blocks []*hotstuff.Block
+		stop   hotstuff.Hash
 	)
 
 	chain.mut.Lock()
@@ -92,10 +99,11 @@ func (chain *blockChain) Get(hash hotstuff.Hash) (block *hotstuff.Block, ok bool
 
 	ctx, cancel = synchronizer.TimeoutContext(chain.eventLoop.Context(), chain.eventLoop)
 	chain.pendingFetch[hash] = cancel
+	stop = chain.committed
 
 	chain.mut.Unlock()
 	chain.logger.Debugf("Attempting to fetch block: %.8s", hash)
-	block, ok = chain.configuration.Fetch(ctx, hash)
+	blocks, ok = chain.fetch(ctx, hash, stop)
 	chain.mut.Lock()
 
 	delete(chain.pendingFetch, hash)
@@ -105,10 +113,15 @@ func (chain *blockChain) Get(hash hotstuff.Hash) (block *hotstuff.Block, ok bool
 		goto done
 	}
 
-	chain.logger.Debugf("Successfully fetched block: %.8s", hash)
+	chain.logger.Debugf("Successfully fetched block: %.8s (%d blocks)", hash, len(blocks))
 
-	chain.blocks[hash] = block
-	chain.blockAtHeight[block.View()] = block
+	block = blocks[0]
+	for _, b := range blocks {
+		chain.blocks[b.Hash()] = b
+		if b.View() > chain.pruneHeight {
+			chain.blockAtHeight[b.View()] = b
+		}
+	}
 
 done:
 	chain.mut.Unlock()
@@ -120,6 +133,38 @@ done:
 	return block, true
 }
 
+// fetch requests the block with the given hash from the other replicas. If the configuration is a RangeFetcher,
+// up to fetchBatchSize-1 ancestors of the block are fetched with it, back to the stop block, such that a replica
+// that has fallen behind can catch up in batches. The blocks are returned from child to parent, and are only accepted if they start with
+// the requested block and each block is the parent of the previous, which makes them as trustworthy as the hash.
+func (chain *blockChain) fetch(ctx context.Context, hash, stop hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	fetcher, ok := chain.configuration.(modules.RangeFetcher)
+	if !ok {
+		block, ok := chain.configuration.Fetch(ctx, hash)
+		if !ok || block.Hash() != hash {
+			return nil, false
+		}
+		return []*hotstuff.Block{block}, true
+	}
+	blocks, ok := fetcher.FetchRange(ctx, hash, stop, fetchBatchSize)
+	if !ok || len(blocks) == 0 {
+		return nil, false
+	}
+	next := hash
+	for i, block := range blocks {
+		if block.Hash() != next {
+			chain.logger.Warnf("Fetched block %d of %.8s does not match the requested chain", i, hash)
+			// the blocks before the mismatch are still linked to the requested hash
+			if i == 0 {
+				return nil, false
+			}
+			return blocks[:i], true
+		}
+		next = block.Parent()
+	}
+	return blocks, true
+}
+
 // Extends checks if the given block extends the branch of the target block.
 func (chain *blockChain) Extends(block, target *hotstuff.Block) bool {
 	current := block
@@ -134,7 +179,9 @@ func (chain *blockChain) PruneToHeight(height hotstuff.View) (forkedBlocks []*ho
 	chain.mut.Lock()
 	defer chain.mut.Unlock()
 
-	committedHeight := chain.consensus.CommittedBlock().View()
+	committedBlock := chain.consensus.CommittedBlock()
+	chain.committed = committedBlock.Hash()
+	committedHeight := committedBlock.View()
 	committedViews := make(map[hotstuff.View]bool)
 	committedViews[committedHeight] = true
 	for h := committedHeight; h >= chain.pruneHeight; {
@@ -164,4 +211,29 @@ func (chain *blockChain) PruneToHeight(height hotstuff.View) (forkedBlocks []*ho
 	return forkedBlocks
 }
 
-var _ modules.BlockChain = (*blockChain)(nil)
+// DiscardBefore removes the blocks whose view is lower than the view of the given block, which must be committed.
+// It is called when the block becomes the base of a stable checkpoint: the replicas that are missing the removed
+// blocks must then catch up by installing the checkpoint instead of fetching them.
+func (chain *blockChain) DiscardBefore(block *hotstuff.Block) (discarded int) {
+	chain.mut.Lock()
+	defer chain.mut.Unlock()
+
+	for hash, b := range chain.blocks {
+		if b.View() < block.View() {
+			delete(chain.blocks, hash)
+			discarded++
+		}
+	}
+	for view := range chain.blockAtHeight {
+		if view < block.View() {
+			delete(chain.blockAtHeight, view)
+		}
+	}
+	chain.blocks[block.Hash()] = block
+	return discarded
+}
+
+var (
+	_ modules.BlockChain     = (*blockChain)(nil)
+	_ modules.BlockDiscarder = (*blockChain)(nil)
+)
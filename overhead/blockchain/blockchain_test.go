@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// rangeConfig is a configuration whose FetchRange returns the blocks of a remote chain,
+// optionally replacing one of them with a forged block.
+type rangeConfig struct {
+	modules.Configuration
+	remote map[hotstuff.Hash]*hotstuff.Block
+	forged map[hotstuff.Hash]*hotstuff.Block
+	calls  []int
+}
+
+func (cfg *rangeConfig) FetchRange(_ context.Context, hash, stop hotstuff.Hash, count int) (blocks []*hotstuff.Block, ok bool) {
+	for len(blocks) < count && (len(blocks) == 0 || hash != stop) {
+		block, ok := cfg.remote[hash]
+		if !ok {
+			break
+		}
+		if forged, ok := cfg.forged[hash]; ok {
+			block = forged
+		}
+		blocks = append(blocks, block)
+		hash = block.Parent()
+	}
+	cfg.calls = append(cfg.calls, len(blocks))
+	return blocks, len(blocks) > 0
+}
+
+// testBlocks returns a chain of n blocks after the genesis block.
+func testBlocks(n int) []*hotstuff.Block {
+	var blocks []*hotstuff.Block
+	parent := hotstuff.GetGenesis()
+	for view := hotstuff.View(1); view <= hotstuff.View(n); view++ {
+		qc := hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash(), nil)
+		block := hotstuff.NewBlock(parent.Hash(), qc, hotstuff.Command("cmd"), view, 1, time.Unix(0, int64(view)))
+		blocks = append(blocks, block)
+		parent = block
+	}
+	return blocks
+}
+
+func newFetchingChain(t *testing.T, blocks []*hotstuff.Block) (*blockChain, *rangeConfig) {
+	t.Helper()
+	cfg := &rangeConfig{
+		remote: make(map[hotstuff.Hash]*hotstuff.Block),
+		forged: make(map[hotstuff.Hash]*hotstuff.Block),
+	}
+	for _, block := range blocks {
+		cfg.remote[block.Hash()] = block
+	}
+	chain := New().(*blockChain)
+	chain.configuration = cfg
+	chain.eventLoop = eventloop.New(10)
+	chain.logger = logging.New("test")
+	return chain, cfg
+}
+
+func TestFetchBatches(t *testing.T) {
+	blocks := testBlocks(2*fetchBatchSize + 50)
+	chain, cfg := newFetchingChain(t, blocks)
+
+	last := blocks[len(blocks)-1]
+	if block, ok := chain.Get(last.Hash()); !ok || block.Hash() != last.Hash() {
+		t.Fatalf("failed to fetch block %v", last)
+	}
+	if !chain.Extends(last, hotstuff.GetGenesis()) {
+		t.Fatal("the fetched chain does not extend the genesis block")
+	}
+	want := []int{fetchBatchSize, fetchBatchSize, 50}
+	if len(cfg.calls) != len(want) || cfg.calls[0] != want[0] || cfg.calls[1] != want[1] || cfg.calls[2] != want[2] {
+		t.Errorf("got fetch batches %v, want %v", cfg.calls, want)
+	}
+	for _, block := range blocks {
+		if _, ok := chain.LocalGet(block.Hash()); !ok {
+			t.Errorf("block %v was not stored", block)
+		}
+	}
+}
+
+func TestFetchForgedBatch(t *testing.T) {
+	blocks := testBlocks(10)
+	chain, cfg := newFetchingChain(t, blocks)
+	forged := hotstuff.NewBlock(blocks[3].Parent(), blocks[3].QuorumCert(), "forged", blocks[3].View(), 2, blocks[3].Time())
+	cfg.forged[blocks[3].Hash()] = forged
+
+	if _, ok := chain.Get(blocks[9].Hash()); !ok {
+		t.Fatal("failed to fetch the blocks before the forged block")
+	}
+	for _, block := range blocks[4:] {
+		if _, ok := chain.LocalGet(block.Hash()); !ok {
+			t.Errorf("block %v was not stored", block)
+		}
+	}
+	if _, ok := chain.LocalGet(forged.Hash()); ok {
+		t.Error("the forged block was stored")
+	}
+	if _, ok := chain.LocalGet(blocks[3].Hash()); ok {
+		t.Error("a block after the forged block was stored")
+	}
+}
+
+func TestDiscardBefore(t *testing.T) {
+	blocks := testBlocks(10)
+	chain := New().(*blockChain)
+	for _, block := range blocks {
+		chain.Store(block)
+	}
+
+	// the genesis block and the four blocks before blocks[4]
+	if discarded := chain.DiscardBefore(blocks[4]); discarded != 5 {
+		t.Errorf("discarded %d blocks, want 5", discarded)
+	}
+	for i, block := range blocks {
+		_, ok := chain.LocalGet(block.Hash())
+		if ok != (i >= 4) {
+			t.Errorf("block %d: stored = %v, want %v", i, ok, i >= 4)
+		}
+	}
+}
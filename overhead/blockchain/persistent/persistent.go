@@ -0,0 +1,320 @@
+// Package persistent implements a block chain that stores the state that a replica needs to restart in a log file.
+package persistent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+// The types of the records of the log.
+const (
+	recordVote    byte = iota + 1 // the uvarint view of the last vote
+	recordBlock                   // a block certified by the next high QC, or by the QC of that block
+	recordHighQC                  // the high QC
+	recordCommit                  // a committed block
+	recordRanking                 // a snapshot of the ranking state
+)
+
+// persistentChain is an in-memory block chain that also stores the committed blocks and the state that the replica needs to
+// restart safely in an append-only log. It implements modules.StableStorage.
+//
+// Each record of the log is a type byte, the uvarint length of the payload, the payload, and the CRC-32 checksum of
+// the type and payload. A torn record at the end of the log, left by a crash during a write, is discarded on recovery.
+type persistentChain struct {
+	modules.BlockChain
+	logger logging.Logger
+
+	storeMut     sync.Mutex
+	file         *os.File
+	lastVote     hotstuff.View
+	highQC       hotstuff.QuorumCert
+	hasHighQC    bool
+	committed    []*hotstuff.Block
+	rankingState []byte
+}
+
+// New returns a BlockChain that stores its state in the log file at the given path.
+// The state stored by a previous run is recovered from the log, and the log is compacted.
+func New(path string) (modules.BlockChain, error) {
+	chain := &persistentChain{BlockChain: blockchain.New()}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := chain.load(data); err != nil {
+		return nil, fmt.Errorf("failed to recover %s: %w", path, err)
+	}
+	if err := chain.compact(path); err != nil {
+		return nil, fmt.Errorf("failed to compact %s: %w", path, err)
+	}
+	chain.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// InitModule gives the module and the in-memory block chain a reference to the Core object.
+func (chain *persistentChain) InitModule(mods *modules.Core) {
+	if mod, ok := chain.BlockChain.(modules.Module); ok {
+		mod.InitModule(mods)
+	}
+	mods.Get(&chain.logger)
+}
+
+// DiscardBefore removes the blocks of the in-memory block chain whose view is lower than the view of the given block.
+// The log is not changed, since it only stores the committed blocks and the state after the high QC.
+func (chain *persistentChain) DiscardBefore(block *hotstuff.Block) int {
+	if discarder, ok := chain.BlockChain.(modules.BlockDiscarder); ok {
+		return discarder.DiscardBefore(block)
+	}
+	return 0
+}
+
+// load applies the records of the log, up to the first torn record.
+func (chain *persistentChain) load(data []byte) error {
+	for len(data) > 0 {
+		typ, payload, n, ok := decodeRecord(data)
+		if !ok {
+			return nil
+		}
+		if err := chain.apply(typ, payload); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func (chain *persistentChain) apply(typ byte, payload []byte) error {
+	switch typ {
+	case recordVote:
+		view, n := binary.Uvarint(payload)
+		if n <= 0 {
+			return errors.New("invalid vote record")
+		}
+		chain.lastVote = hotstuff.View(view)
+	case recordBlock, recordCommit:
+		pb := new(hotstuffpb.Block)
+		if err := proto.Unmarshal(payload, pb); err != nil {
+			return err
+		}
+		block := hotstuffpb.BlockFromProto(pb)
+		chain.Store(block)
+		if typ == recordCommit {
+			chain.committed = append(chain.committed, block)
+		}
+	case recordHighQC:
+		pb := new(hotstuffpb.QuorumCert)
+		if err := proto.Unmarshal(payload, pb); err != nil {
+			return err
+		}
+		chain.highQC, chain.hasHighQC = hotstuffpb.QuorumCertFromProto(pb), true
+	case recordRanking:
+		chain.rankingState = payload
+	default:
+		return fmt.Errorf("unknown record type %d", typ)
+	}
+	return nil
+}
+
+// compact writes the recovered state to a new log, which replaces the log at path.
+func (chain *persistentChain) compact(path string) error {
+	var buf []byte
+	for _, block := range chain.committed {
+		buf = appendBlock(buf, recordCommit, block)
+	}
+	if chain.rankingState != nil {
+		buf = appendRecord(buf, recordRanking, chain.rankingState)
+	}
+	if chain.hasHighQC {
+		for _, block := range chain.highQCBlocks(chain.highQC) {
+			buf = appendBlock(buf, recordBlock, block)
+		}
+		buf = appendQC(buf, chain.highQC)
+	}
+	if chain.lastVote > 0 {
+		buf = appendRecord(buf, recordVote, binary.AppendUvarint(nil, uint64(chain.lastVote)))
+	}
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, buf); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	return errors.Join(err, f.Close())
+}
+
+// write appends the records to the log, and syncs the log if sync is true.
+// The caller must hold storeMut.
+func (chain *persistentChain) write(records []byte, sync bool) error {
+	if _, err := chain.file.Write(records); err != nil {
+		return err
+	}
+	if sync {
+		return chain.file.Sync()
+	}
+	return nil
+}
+
+// StoreLastVote records that the replica voted, proposed or timed out in the view.
+func (chain *persistentChain) StoreLastVote(view hotstuff.View) error {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	if view <= chain.lastVote {
+		return nil
+	}
+	if err := chain.write(appendRecord(nil, recordVote, binary.AppendUvarint(nil, uint64(view))), true); err != nil {
+		return err
+	}
+	chain.lastVote = view
+	return nil
+}
+
+// LastVote returns the highest view recorded by StoreLastVote.
+func (chain *persistentChain) LastVote() hotstuff.View {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	return chain.lastVote
+}
+
+// highQCBlocks returns the block certified by the QC of the block certified by the high QC, if it is stored,
+// followed by the block certified by the high QC. The consensus rules may lock the first block,
+// so it is needed to restore the lock of a restarted replica even if it was not committed.
+func (chain *persistentChain) highQCBlocks(qc hotstuff.QuorumCert) []*hotstuff.Block {
+	block, ok := chain.LocalGet(qc.BlockHash())
+	if !ok {
+		return nil
+	}
+	if locked, ok := chain.LocalGet(block.QuorumCert().BlockHash()); ok && locked.View() > 0 {
+		return []*hotstuff.Block{locked, block}
+	}
+	return []*hotstuff.Block{block}
+}
+
+// StoreHighQC records the high QC, the block that it certifies and the block certified by the QC of that block.
+func (chain *persistentChain) StoreHighQC(qc hotstuff.QuorumCert) {
+	blocks := chain.highQCBlocks(qc)
+	if len(blocks) == 0 {
+		chain.logger.Warnf("StoreHighQC: block %.8s not found", qc.BlockHash())
+		return
+	}
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	if chain.hasHighQC && qc.View() <= chain.highQC.View() {
+		return
+	}
+	var records []byte
+	for _, block := range blocks {
+		records = appendBlock(records, recordBlock, block)
+	}
+	if err := chain.write(appendQC(records, qc), false); err != nil {
+		chain.logger.Errorf("Failed to store high QC: %v", err)
+		return
+	}
+	chain.highQC, chain.hasHighQC = qc, true
+}
+
+// HighQC returns the quorum certificate recorded by StoreHighQC, if any.
+func (chain *persistentChain) HighQC() (hotstuff.QuorumCert, bool) {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	return chain.highQC, chain.hasHighQC
+}
+
+// StoreCommit records the committed block, and the ranking snapshot if it changed.
+func (chain *persistentChain) StoreCommit(block *hotstuff.Block, rankingState []byte) {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	records := appendBlock(nil, recordCommit, block)
+	changed := rankingState != nil && !bytes.Equal(rankingState, chain.rankingState)
+	if changed {
+		records = appendRecord(records, recordRanking, rankingState)
+	}
+	if err := chain.write(records, false); err != nil {
+		chain.logger.Errorf("Failed to store committed block: %v", err)
+		return
+	}
+	chain.committed = append(chain.committed, block)
+	if changed {
+		chain.rankingState = rankingState
+	}
+}
+
+// Committed returns the committed blocks in commit order, and the last ranking snapshot.
+func (chain *persistentChain) Committed() (blocks []*hotstuff.Block, rankingState []byte) {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	return append([]*hotstuff.Block(nil), chain.committed...), chain.rankingState
+}
+
+// Close closes the log.
+func (chain *persistentChain) Close() error {
+	chain.storeMut.Lock()
+	defer chain.storeMut.Unlock()
+	return chain.file.Close()
+}
+
+func appendRecord(buf []byte, typ byte, payload []byte) []byte {
+	buf = append(buf, typ)
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	buf = append(buf, payload...)
+	checksum := crc32.Update(crc32.ChecksumIEEE([]byte{typ}), crc32.IEEETable, payload)
+	return binary.LittleEndian.AppendUint32(buf, checksum)
+}
+
+func appendBlock(buf []byte, typ byte, block *hotstuff.Block) []byte {
+	// the protobuf messages of blocks and QCs are always valid, so marshaling cannot fail.
+	payload, _ := proto.Marshal(hotstuffpb.BlockToProto(block))
+	return appendRecord(buf, typ, payload)
+}
+
+func appendQC(buf []byte, qc hotstuff.QuorumCert) []byte {
+	payload, _ := proto.Marshal(hotstuffpb.QuorumCertToProto(qc))
+	return appendRecord(buf, recordHighQC, payload)
+}
+
+// decodeRecord returns the first record of data and its encoded length, or false if the record is torn.
+func decodeRecord(data []byte) (typ byte, payload []byte, n int, ok bool) {
+	if len(data) < 1 {
+		return 0, nil, 0, false
+	}
+	typ = data[0]
+	length, size := binary.Uvarint(data[1:])
+	if size <= 0 || length > uint64(len(data)-1-size) || len(data)-1-size-int(length) < 4 {
+		return 0, nil, 0, false
+	}
+	start := 1 + size
+	payload = data[start : start+int(length)]
+	checksum := binary.LittleEndian.Uint32(data[start+int(length):])
+	if checksum != crc32.Update(crc32.ChecksumIEEE([]byte{typ}), crc32.IEEETable, payload) {
+		return 0, nil, 0, false
+	}
+	return typ, payload, start + int(length) + 4, true
+}
+
+var (
+	_ modules.StableStorage  = (*persistentChain)(nil)
+	_ modules.BlockDiscarder = (*persistentChain)(nil)
+)
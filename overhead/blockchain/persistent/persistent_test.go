@@ -0,0 +1,127 @@
+package persistent
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+)
+
+// testQC returns a quorum certificate for the block with made up signatures of replicas 1 to 3.
+func testQC(block *hotstuff.Block) hotstuff.QuorumCert {
+	var sigs []*ecdsa.Signature
+	for id := hotstuff.ID(1); id <= 3; id++ {
+		sigs = append(sigs, ecdsa.RestoreSignature(big.NewInt(int64(id)), big.NewInt(int64(block.View())), id))
+	}
+	return hotstuff.NewQuorumCert(ecdsa.RestoreMultiSignature(sigs), block.View(), block.Hash(), []uint32{1<<24 | 10})
+}
+
+// testBlocks returns a chain of n blocks after the genesis block.
+func testBlocks(n int) []*hotstuff.Block {
+	var blocks []*hotstuff.Block
+	parent := hotstuff.GetGenesis()
+	qc := hotstuff.NewQuorumCert(nil, 0, parent.Hash(), nil)
+	for view := hotstuff.View(1); view <= hotstuff.View(n); view++ {
+		block := hotstuff.NewBlock(parent.Hash(), qc, hotstuff.Command("cmd"), view, 1, time.Unix(0, int64(view)))
+		blocks = append(blocks, block)
+		parent, qc = block, testQC(block)
+	}
+	return blocks
+}
+
+func openPersistent(t *testing.T, path string) *persistentChain {
+	t.Helper()
+	chain, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = chain.(*persistentChain).Close() })
+	return chain.(*persistentChain)
+}
+
+func TestPersistentRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replica.log")
+	blocks := testBlocks(3)
+	qc := testQC(blocks[2])
+
+	chain := openPersistent(t, path)
+	for _, block := range blocks {
+		chain.Store(block)
+	}
+	for _, view := range []hotstuff.View{2, 3, 1} {
+		if err := chain.StoreLastVote(view); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain.StoreHighQC(qc)
+	chain.StoreCommit(blocks[0], []byte("ranking"))
+	chain.StoreCommit(blocks[1], []byte("ranking"))
+	if err := chain.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second run recovers the state from the compacted log of the first run
+	for run := 0; run < 2; run++ {
+		chain := openPersistent(t, path)
+		if got := chain.LastVote(); got != 3 {
+			t.Errorf("run %d: got last vote %d, want 3", run, got)
+		}
+		if got, ok := chain.HighQC(); !ok || !got.Equals(qc) {
+			t.Errorf("run %d: got high QC %v, want %v", run, got, qc)
+		}
+		if _, ok := chain.LocalGet(qc.BlockHash()); !ok {
+			t.Errorf("run %d: the block certified by the high QC was not recovered", run)
+		}
+		committed, rankingState := chain.Committed()
+		if len(committed) != 2 || committed[0].Hash() != blocks[0].Hash() || committed[1].Hash() != blocks[1].Hash() {
+			t.Errorf("run %d: got committed blocks %v, want %v", run, committed, blocks[:2])
+		}
+		if !bytes.Equal(rankingState, []byte("ranking")) {
+			t.Errorf("run %d: got ranking state %q, want %q", run, rankingState, "ranking")
+		}
+		if err := chain.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPersistentTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replica.log")
+	chain := openPersistent(t, path)
+	for _, view := range []hotstuff.View{1, 2} {
+		if err := chain.StoreLastVote(view); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := chain.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a crash during the last write leaves part of its record
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-2); err != nil {
+		t.Fatal(err)
+	}
+
+	chain = openPersistent(t, path)
+	if got := chain.LastVote(); got != 1 {
+		t.Errorf("got last vote %d, want 1", got)
+	}
+	if err := chain.StoreLastVote(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := openPersistent(t, path).LastVote(); got != 2 {
+		t.Errorf("got last vote %d after the torn record was discarded, want 2", got)
+	}
+}
@@ -0,0 +1,64 @@
+package hotstuff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// checkpointTag prefixes the signed encoding of a checkpoint, such that a checkpoint signature
+// cannot be mistaken for the signature of a block or a view.
+const checkpointTag = "checkpoint"
+
+// Checkpoint is the committed state of a replica after it has committed a number of blocks.
+// Correct replicas that have committed the same blocks take identical checkpoints.
+type Checkpoint struct {
+	Height         uint64 // the number of blocks that were committed after the genesis block
+	View           View   // the view of the last committed block
+	Block          Hash   // the hash of the last committed block
+	ActiveReplicas []ID   // the active replicas as of the last committed reconfiguration, or nil if there was none
+	StateDigest    []byte // the digest of the state of the executed commands
+	RankingDigest  []byte // the digest of the committed ranking state, or nil if the replicas do not rank each other
+}
+
+// ToBytes returns the encoding of the checkpoint that is signed by the replicas.
+func (c Checkpoint) ToBytes() []byte {
+	b := []byte(checkpointTag)
+	b = binary.LittleEndian.AppendUint64(b, c.Height)
+	b = append(b, c.View.ToBytes()...)
+	b = append(b, c.Block[:]...)
+	b = binary.AppendUvarint(b, uint64(len(c.ActiveReplicas)))
+	for _, id := range c.ActiveReplicas {
+		b = binary.LittleEndian.AppendUint32(b, uint32(id))
+	}
+	for _, digest := range [][]byte{c.StateDigest, c.RankingDigest} {
+		b = binary.AppendUvarint(b, uint64(len(digest)))
+		b = append(b, digest...)
+	}
+	return b
+}
+
+// Equals returns true if the checkpoints describe the same state.
+func (c Checkpoint) Equals(other Checkpoint) bool {
+	return bytes.Equal(c.ToBytes(), other.ToBytes())
+}
+
+func (c Checkpoint) String() string {
+	return fmt.Sprintf("Checkpoint{ height: %d, view: %d, block: %.8s, state: %.8x }", c.Height, c.View, c.Block, c.StateDigest)
+}
+
+// CheckpointCert is a checkpoint signed by a quorum of replicas. A certified checkpoint is stable:
+// at least one correct replica has committed it, so it will never be rolled back.
+type CheckpointCert struct {
+	Checkpoint Checkpoint
+	Signature  QuorumSignature
+}
+
+// CheckpointSnapshot is a stable checkpoint together with the state that is needed to install it on a replica
+// that has fallen too far behind to fetch the blocks that it is missing.
+type CheckpointSnapshot struct {
+	Cert           CheckpointCert
+	Block          *Block // the last committed block of the checkpoint
+	ExecutionState []byte // the state whose digest is Checkpoint.StateDigest
+	RankingState   []byte // the state whose digest is Checkpoint.RankingDigest
+}
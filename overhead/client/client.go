@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 	"sync"
@@ -19,28 +20,30 @@ import (
 	"github.com/relab/hotstuff/internal/proto/clientpb"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
-	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type qspec struct {
 	faulty int
 }
 
-func (q *qspec) ExecCommandQF(_ *clientpb.Command, signatures map[uint32]*emptypb.Empty) (*emptypb.Empty, bool) {
-	// if len(signatures) < q.faulty+1 {
+func (q *qspec) ExecCommandQF(_ *clientpb.Command, results map[uint32]*clientpb.Result) (*clientpb.Result, bool) {
+	// if len(results) < q.faulty+1 {
 	// 	return nil, false
 	// }
-	return &emptypb.Empty{}, true
+	for _, result := range results {
+		return result, true
+	}
+	return nil, false
 }
 
 type pendingCmd struct {
 	sequenceNumber uint64
 	sendTime       time.Time
-	promise        *clientpb.AsyncEmpty
+	payload        []byte
+	promise        *clientpb.AsyncResult
 	cancelCtx      context.CancelFunc
 }
 
@@ -56,6 +59,7 @@ type Config struct {
 	RateStep         float64       // rate limit step up
 	RateStepInterval time.Duration // step up interval
 	Timeout          time.Duration
+	Workload         Workload // determines when commands are sent; the rate workload of the options above if nil
 }
 
 // Client is a hotstuff client.
@@ -67,15 +71,12 @@ type Client struct {
 	mut              sync.Mutex
 	mgr              *clientpb.Manager
 	gorumsConfig     *clientpb.Configuration
-	payloadSize      uint32
 	highestCommitted uint64 // highest sequence number acknowledged by the replicas
 	pendingCmds      chan pendingCmd
 	cancel           context.CancelFunc
 	done             chan struct{}
 	reader           io.ReadCloser
-	limiter          *rate.Limiter
-	stepUp           float64
-	stepUpInterval   time.Duration
+	workload         Workload
 	timeout          time.Duration
 }
 
@@ -95,12 +96,18 @@ func New(conf Config, builder modules.Builder) (client *Client) {
 		highestCommitted: 1,
 		done:             make(chan struct{}),
 		reader:           conf.Input,
-		payloadSize:      conf.PayloadSize,
-		limiter:          rate.NewLimiter(rate.Limit(conf.RateLimit), 1),
-		stepUp:           conf.RateStep,
-		stepUpInterval:   conf.RateStepInterval,
+		workload:         conf.Workload,
 		timeout:          conf.Timeout,
 	}
+	if client.workload == nil {
+		// the default workload cannot fail
+		client.workload, _ = NewWorkload(WorkloadConfig{
+			PayloadSize:      conf.PayloadSize,
+			RateLimit:        conf.RateLimit,
+			RateStep:         conf.RateStep,
+			RateStepInterval: conf.RateStepInterval,
+		})
+	}
 
 	builder.Add(client)
 
@@ -171,6 +178,9 @@ func (c *Client) Run(ctx context.Context) {
 		"Done sending commands (executed: %d, failed: %d, timeouts: %d)",
 		commandStats.executed, commandStats.failed, commandStats.timeout,
 	)
+	if summary, ok := c.workload.(fmt.Stringer); ok {
+		c.logger.Infof("Results: %v", summary)
+	}
 	<-eventLoopDone
 	close(c.done)
 }
@@ -200,7 +210,6 @@ func (c *Client) sendCommands(ctx context.Context) error {
 	var (
 		num         uint64 = 1
 		lastCommand uint64 = math.MaxUint64
-		lastStep           = time.Now()
 	)
 
 loop:
@@ -209,14 +218,11 @@ loop:
 			break
 		}
 
-		// step up the rate limiter
-		now := time.Now()
-		if now.Sub(lastStep) > c.stepUpInterval {
-			c.limiter.SetLimit(c.limiter.Limit() + rate.Limit(c.stepUp))
-			lastStep = now
+		payloadSize, err := c.workload.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			c.logger.Info("Reached end of workload.")
+			break
 		}
-
-		err := c.limiter.Wait(ctx)
 		if err != nil && !errors.Is(err, context.Canceled) {
 			return err
 		}
@@ -230,25 +236,31 @@ loop:
 			break
 		}
 
-		data := make([]byte, c.payloadSize)
-		n, err := c.reader.Read(data)
-		if err != nil && err != io.EOF {
-			// if we get an error other than EOF
-			return err
-		} else if err == io.EOF && n == 0 && lastCommand > num {
-			lastCommand = num
-			c.logger.Info("Reached end of file. Sending empty commands until last command is executed...")
+		var data []byte
+		if generator, ok := c.workload.(PayloadGenerator); ok {
+			data = generator.Payload()
+		} else {
+			data = make([]byte, payloadSize)
+			n, err := c.reader.Read(data)
+			if err != nil && err != io.EOF {
+				// if we get an error other than EOF
+				return err
+			} else if err == io.EOF && n == 0 && lastCommand > num {
+				lastCommand = num
+				c.logger.Info("Reached end of file. Sending empty commands until last command is executed...")
+			}
+			data = data[:n]
 		}
 
 		cmd := &clientpb.Command{
 			ClientID:       uint32(c.opts.ID()),
 			SequenceNumber: num,
-			Data:           data[:n],
+			Data:           data,
 		}
 
 		ctx, cancel := context.WithTimeout(ctx, c.timeout)
 		promise := c.gorumsConfig.ExecCommand(ctx, cmd)
-		pending := pendingCmd{sequenceNumber: num, sendTime: time.Now(), promise: promise, cancelCtx: cancel}
+		pending := pendingCmd{sequenceNumber: num, sendTime: time.Now(), payload: data, promise: promise, cancelCtx: cancel}
 
 		num++
 		select {
@@ -282,7 +294,7 @@ func (c *Client) handleCommands(ctx context.Context) (executed, failed, timeout
 		case <-ctx.Done():
 			return
 		}
-		_, err := cmd.promise.Get()
+		result, err := cmd.promise.Get()
 		if err != nil {
 			qcError, ok := err.(gorums.QuorumCallError)
 			if ok && qcError.Reason == context.DeadlineExceeded.Error() {
@@ -294,6 +306,9 @@ func (c *Client) handleCommands(ctx context.Context) (executed, failed, timeout
 			}
 		} else {
 			executed++
+			if handler, ok := c.workload.(ResultHandler); ok {
+				handler.HandleResult(cmd.payload, result.GetData())
+			}
 		}
 		c.mut.Lock()
 		if cmd.sequenceNumber > c.highestCommitted {
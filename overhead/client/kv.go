@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/relab/hotstuff/kvstore"
+	"golang.org/x/time/rate"
+)
+
+// The fractions of the write operations of the kv workload that are puts and compare-and-swaps; the rest are deletes.
+const (
+	kvPuts = 0.6
+	kvCAS  = 0.2
+)
+
+// kvWorkload sends key-value operations as fast as the rate limit allows.
+// A fraction of the operations are gets, and the rest are puts, compare-and-swaps and deletes of uniformly chosen keys,
+// with values of the payload size. A compare-and-swap expects the value that the client last wrote to the key.
+type kvWorkload struct {
+	limiter   *rate.Limiter
+	rnd       *rand.Rand
+	keys      uint32
+	reads     float64
+	valueSize uint32
+	clientID  uint32
+	count     uint64
+	written   map[string][]byte // the last value that the client wrote to each key
+
+	mut     sync.Mutex
+	results map[kvstore.Op]map[kvstore.Status]int // the number of results of each operation with each status
+}
+
+func newKVWorkload(conf WorkloadConfig, limit float64) (*kvWorkload, error) {
+	if conf.KVKeys == 0 {
+		return nil, fmt.Errorf("the kv workload requires a positive number of keys")
+	}
+	if conf.KVReads < 0 || conf.KVReads > 1 {
+		return nil, fmt.Errorf("the fraction of reads of the kv workload must be between 0 and 1")
+	}
+	return &kvWorkload{
+		limiter:   rate.NewLimiter(rate.Limit(limit), 1),
+		rnd:       rand.New(rand.NewSource(conf.Seed)),
+		keys:      conf.KVKeys,
+		reads:     conf.KVReads,
+		valueSize: conf.PayloadSize,
+		clientID:  conf.ClientID,
+		written:   make(map[string][]byte),
+		results:   make(map[kvstore.Op]map[kvstore.Status]int),
+	}, nil
+}
+
+func (w *kvWorkload) Next(ctx context.Context) (uint32, error) {
+	return w.valueSize, w.limiter.Wait(ctx)
+}
+
+// Payload returns the encoding of the next operation.
+func (w *kvWorkload) Payload() []byte {
+	return kvstore.Encode(w.next())
+}
+
+func (w *kvWorkload) next() kvstore.Operation {
+	key := fmt.Sprintf("key-%d", w.rnd.Intn(int(w.keys)))
+	if w.rnd.Float64() < w.reads {
+		return kvstore.Operation{Op: kvstore.Get, Key: key}
+	}
+	switch r := w.rnd.Float64(); {
+	case r < kvPuts:
+		value := w.value()
+		w.written[key] = value
+		return kvstore.Operation{Op: kvstore.Put, Key: key, Value: value}
+	case r < kvPuts+kvCAS:
+		value := w.value()
+		expected := w.written[key]
+		w.written[key] = value
+		return kvstore.Operation{Op: kvstore.CAS, Key: key, Value: value, Expected: expected}
+	default:
+		delete(w.written, key)
+		return kvstore.Operation{Op: kvstore.Delete, Key: key}
+	}
+}
+
+// value returns a new value of the payload size, which is unique unless the payload size is too small.
+func (w *kvWorkload) value() []byte {
+	w.count++
+	value := make([]byte, w.valueSize)
+	copy(value, fmt.Sprintf("%d-%d:", w.clientID, w.count))
+	return value
+}
+
+// HandleResult counts the result of the operation.
+func (w *kvWorkload) HandleResult(payload, result []byte) {
+	op, err := kvstore.Decode(payload)
+	if err != nil {
+		return
+	}
+	status := kvstore.Invalid
+	if r, err := kvstore.DecodeResult(result); err == nil {
+		status = r.Status
+	}
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if w.results[op.Op] == nil {
+		w.results[op.Op] = make(map[kvstore.Status]int)
+	}
+	w.results[op.Op][status]++
+}
+
+// String returns the number of results of each operation with each status, such as "get: ok=10 not-found=2".
+func (w *kvWorkload) String() string {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	var ops []string
+	for _, op := range []kvstore.Op{kvstore.Get, kvstore.Put, kvstore.CAS, kvstore.Delete} {
+		statuses := make([]string, 0, len(w.results[op]))
+		for status, count := range w.results[op] {
+			statuses = append(statuses, fmt.Sprintf("%v=%d", status, count))
+		}
+		if len(statuses) == 0 {
+			continue
+		}
+		sort.Strings(statuses)
+		ops = append(ops, fmt.Sprintf("%v: %s", op, strings.Join(statuses, " ")))
+	}
+	return strings.Join(ops, ", ")
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Workload determines when a client sends its commands, and the size of their payloads.
+type Workload interface {
+	// Next waits until the next command should be sent, and returns the size of its payload.
+	// It returns io.EOF when there are no more commands to send.
+	Next(ctx context.Context) (payloadSize uint32, err error)
+}
+
+// PayloadGenerator is implemented by workloads that generate the payloads of their commands.
+// The payloads of the commands of other workloads are read from the input of the client.
+type PayloadGenerator interface {
+	// Payload returns the payload of the command that the last call to Next waited for.
+	Payload() []byte
+}
+
+// ResultHandler is implemented by workloads that inspect the results that the replicas return for their commands.
+type ResultHandler interface {
+	// HandleResult handles the result of the command with the given payload.
+	// It is called concurrently with Next and Payload.
+	HandleResult(payload, result []byte)
+}
+
+// WorkloadConfig contains the options of the workloads.
+type WorkloadConfig struct {
+	Name             string // one of rate (the default), poisson, onoff, trace or kv
+	ClientID         uint32
+	PayloadSize      uint32
+	RateLimit        float64       // commands per second
+	RateStep         float64       // rate limit step up of the rate workload
+	RateStepInterval time.Duration // step up interval of the rate workload
+	RateSkew         float64       // the rate limit of client i is RateLimit / i^RateSkew
+	BurstOn          time.Duration // duration of the bursts of the onoff workload
+	BurstOff         time.Duration // duration of the pauses of the onoff workload
+	Trace            []TraceEntry  // the commands replayed by the trace workload
+	Seed             int64         // seed of the poisson arrivals and the kv operations
+	KVKeys           uint32        // the number of keys of the kv workload
+	KVReads          float64       // the fraction of the operations of the kv workload that are gets
+}
+
+// NewWorkload returns the workload with the name given in the config.
+func NewWorkload(conf WorkloadConfig) (Workload, error) {
+	limit := conf.RateLimit
+	if conf.RateSkew != 0 && conf.ClientID > 0 {
+		limit /= math.Pow(float64(conf.ClientID), conf.RateSkew)
+	}
+	switch conf.Name {
+	case "", "rate":
+		return &rateWorkload{
+			limiter:        rate.NewLimiter(rate.Limit(limit), 1),
+			stepUp:         conf.RateStep,
+			stepUpInterval: conf.RateStepInterval,
+			payloadSize:    conf.PayloadSize,
+		}, nil
+	case "poisson":
+		if limit <= 0 || math.IsInf(limit, 1) {
+			return nil, fmt.Errorf("the poisson workload requires a finite rate limit")
+		}
+		return &poissonWorkload{
+			rate:        limit,
+			rnd:         rand.New(rand.NewSource(conf.Seed)),
+			payloadSize: conf.PayloadSize,
+		}, nil
+	case "onoff":
+		if conf.BurstOn <= 0 || conf.BurstOff < 0 {
+			return nil, fmt.Errorf("the onoff workload requires a positive burst duration")
+		}
+		return &onOffWorkload{
+			limiter:     rate.NewLimiter(rate.Limit(limit), 1),
+			on:          conf.BurstOn,
+			off:         conf.BurstOff,
+			payloadSize: conf.PayloadSize,
+		}, nil
+	case "trace":
+		return &traceWorkload{entries: conf.Trace}, nil
+	case "kv":
+		return newKVWorkload(conf, limit)
+	}
+	return nil, fmt.Errorf("unknown workload '%s'", conf.Name)
+}
+
+// rateWorkload sends commands of a fixed size as fast as the rate limit allows,
+// increasing the rate limit by stepUp every stepUpInterval.
+type rateWorkload struct {
+	limiter        *rate.Limiter
+	stepUp         float64
+	stepUpInterval time.Duration
+	lastStep       time.Time
+	payloadSize    uint32
+}
+
+func (w *rateWorkload) Next(ctx context.Context) (uint32, error) {
+	now := time.Now()
+	if w.lastStep.IsZero() {
+		w.lastStep = now
+	}
+	// step up the rate limiter
+	if now.Sub(w.lastStep) > w.stepUpInterval {
+		w.limiter.SetLimit(w.limiter.Limit() + rate.Limit(w.stepUp))
+		w.lastStep = now
+	}
+	return w.payloadSize, w.limiter.Wait(ctx)
+}
+
+// poissonWorkload sends commands of a fixed size with exponentially distributed inter-arrival times.
+// The arrivals are scheduled independently of when the previous commands were sent,
+// so the client catches up if it falls behind, for example because MaxConcurrent commands are pending.
+type poissonWorkload struct {
+	rate        float64
+	rnd         *rand.Rand
+	next        time.Time
+	payloadSize uint32
+}
+
+func (w *poissonWorkload) Next(ctx context.Context) (uint32, error) {
+	if w.next.IsZero() {
+		w.next = time.Now()
+	}
+	w.next = w.next.Add(time.Duration(w.rnd.ExpFloat64() / w.rate * float64(time.Second)))
+	return w.payloadSize, sleepUntil(ctx, w.next)
+}
+
+// onOffWorkload alternates between sending commands as fast as the rate limit allows for the duration on,
+// and sending no commands for the duration off.
+type onOffWorkload struct {
+	limiter     *rate.Limiter
+	on, off     time.Duration
+	start       time.Time
+	payloadSize uint32
+}
+
+func (w *onOffWorkload) Next(ctx context.Context) (uint32, error) {
+	if w.start.IsZero() {
+		w.start = time.Now()
+	}
+	period := w.on + w.off
+	if phase := time.Since(w.start) % period; phase >= w.on {
+		if err := sleepUntil(ctx, time.Now().Add(period-phase)); err != nil {
+			return 0, err
+		}
+	}
+	return w.payloadSize, w.limiter.Wait(ctx)
+}
+
+// TraceEntry is a command in a recorded trace.
+type TraceEntry struct {
+	Time        time.Duration // the time of the command since the start of the trace
+	PayloadSize uint32
+}
+
+// traceWorkload replays the commands of a trace at their recorded times.
+type traceWorkload struct {
+	entries []TraceEntry
+	start   time.Time
+	next    int
+}
+
+func (w *traceWorkload) Next(ctx context.Context) (uint32, error) {
+	if w.start.IsZero() {
+		w.start = time.Now()
+	}
+	if w.next >= len(w.entries) {
+		return 0, io.EOF
+	}
+	entry := w.entries[w.next]
+	w.next++
+	return entry.PayloadSize, sleepUntil(ctx, w.start.Add(entry.Time))
+}
+
+// ParseTrace reads the commands of the client with the given ID from a trace in CSV format.
+// Each line contains the time of a command, either in seconds or as a duration such as 1.5ms,
+// the size of its payload in bytes, and optionally the ID of the client that sends it.
+// Commands without a client ID are sent by all clients. Empty lines, lines starting with '#',
+// and a header line are ignored. The returned entries are sorted by time.
+func ParseTrace(rd io.Reader, clientID uint32) ([]TraceEntry, error) {
+	var entries []TraceEntry
+	scanner := bufio.NewScanner(rd)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("trace line %d: expected time,size[,client]", line)
+		}
+		t, err := parseTraceTime(fields[0])
+		if err != nil {
+			if line == 1 {
+				// header
+				continue
+			}
+			return nil, fmt.Errorf("trace line %d: invalid time: %w", line, err)
+		}
+		size, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("trace line %d: invalid size: %w", line, err)
+		}
+		if len(fields) == 3 {
+			id, err := strconv.ParseUint(fields[2], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("trace line %d: invalid client ID: %w", line, err)
+			}
+			if uint32(id) != clientID {
+				continue
+			}
+		}
+		entries = append(entries, TraceEntry{Time: t, PayloadSize: uint32(size)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time < entries[j].Time })
+	return entries, nil
+}
+
+func parseTraceTime(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// sleepUntil waits until the time t or until the context is canceled.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/kvstore"
+)
+
+func TestParseTrace(t *testing.T) {
+	const trace = `time,size,client
+# comment
+0.5,100
+1ms,10,2
+
+0.001,20,1
+0,30,2
+`
+	want := map[uint32][]TraceEntry{
+		1: {{Time: time.Millisecond, PayloadSize: 20}, {Time: 500 * time.Millisecond, PayloadSize: 100}},
+		2: {{Time: 0, PayloadSize: 30}, {Time: time.Millisecond, PayloadSize: 10}, {Time: 500 * time.Millisecond, PayloadSize: 100}},
+	}
+	for id, entries := range want {
+		got, err := ParseTrace(strings.NewReader(trace), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(entries) {
+			t.Fatalf("client %d: got %v, want %v", id, got, entries)
+		}
+		for i := range got {
+			if got[i] != entries[i] {
+				t.Errorf("client %d: got %v, want %v", id, got, entries)
+				break
+			}
+		}
+	}
+
+	if _, err := ParseTrace(strings.NewReader("0,10\nx,10\n"), 1); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
+
+func TestTraceWorkload(t *testing.T) {
+	w, err := NewWorkload(WorkloadConfig{Name: "trace", Trace: []TraceEntry{
+		{Time: 0, PayloadSize: 1},
+		{Time: 20 * time.Millisecond, PayloadSize: 2},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for _, want := range []uint32{1, 2} {
+		size, err := w.Next(context.Background())
+		if err != nil || size != want {
+			t.Fatalf("got (%d, %v), want (%d, nil)", size, err, want)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("the trace was replayed in %v, want at least 20ms", elapsed)
+	}
+	if _, err := w.Next(context.Background()); !errors.Is(err, io.EOF) {
+		t.Errorf("got %v at the end of the trace, want EOF", err)
+	}
+}
+
+func TestNewWorkload(t *testing.T) {
+	tests := []struct {
+		conf WorkloadConfig
+		ok   bool
+	}{
+		{WorkloadConfig{}, true},
+		{WorkloadConfig{Name: "rate", RateLimit: 10}, true},
+		{WorkloadConfig{Name: "poisson", RateLimit: 10}, true},
+		{WorkloadConfig{Name: "poisson"}, false},
+		{WorkloadConfig{Name: "onoff", RateLimit: 10, BurstOn: time.Second}, true},
+		{WorkloadConfig{Name: "onoff", RateLimit: 10}, false},
+		{WorkloadConfig{Name: "kv", RateLimit: 10, KVKeys: 100, KVReads: 0.5}, true},
+		{WorkloadConfig{Name: "kv", RateLimit: 10}, false},
+		{WorkloadConfig{Name: "kv", RateLimit: 10, KVKeys: 100, KVReads: 2}, false},
+		{WorkloadConfig{Name: "unknown"}, false},
+	}
+	for _, test := range tests {
+		if _, err := NewWorkload(test.conf); (err == nil) != test.ok {
+			t.Errorf("NewWorkload(%+v): got error %v", test.conf, err)
+		}
+	}
+}
+
+func TestKVWorkload(t *testing.T) {
+	conf := WorkloadConfig{Name: "kv", ClientID: 1, RateLimit: math.Inf(1), PayloadSize: 16, KVKeys: 10, KVReads: 0.5, Seed: 1}
+	payloads := func() [][]byte {
+		w, err := NewWorkload(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var payloads [][]byte
+		for i := 0; i < 100; i++ {
+			if _, err := w.Next(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			payloads = append(payloads, w.(PayloadGenerator).Payload())
+		}
+		return payloads
+	}
+	first, second := payloads(), payloads()
+	store := kvstore.NewStore()
+	w, _ := NewWorkload(conf)
+	ops := make(map[kvstore.Op]int)
+	for i, payload := range first {
+		if !bytes.Equal(payload, second[i]) {
+			t.Fatalf("payload %d differs between workloads with the same seed", i)
+		}
+		op, err := kvstore.Decode(payload)
+		if err != nil {
+			t.Fatalf("payload %d: %v", i, err)
+		}
+		ops[op.Op]++
+		w.(ResultHandler).HandleResult(payload, store.Apply(payload))
+	}
+	for _, op := range []kvstore.Op{kvstore.Get, kvstore.Put, kvstore.CAS, kvstore.Delete} {
+		if ops[op] == 0 {
+			t.Errorf("no %v operations", op)
+		}
+	}
+	// a single client is the only writer, so its compare-and-swaps of existing keys succeed
+	if summary := w.(fmt.Stringer).String(); !strings.Contains(summary, "cas: ") || strings.Contains(summary, "mismatch") {
+		t.Errorf("unexpected results: %s", summary)
+	}
+}
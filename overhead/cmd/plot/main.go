@@ -0,0 +1,140 @@
+// Plot is a tool for plotting measurements from a HotStuff experiment.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/relab/hotstuff/internal/proto/orchestrationpb"
+	"github.com/relab/hotstuff/metrics/plotting"
+	_ "github.com/relab/hotstuff/metrics/types"
+)
+
+var (
+	interval            = flag.Duration("interval", time.Second, "Length of time interval to group measurements by.")
+	latency             = flag.String("latency", "", "File to save latency plot to.")
+	throughput          = flag.String("throughput", "", "File to save throughput plot to.")
+	throughputVSLatency = flag.String("throughputvslatency", "", "File to save throughput vs latency plot to.")
+	timeouts            = flag.String("timeouts", "", "File to save view timeouts plot to, with markers for tree changes and reconfigurations.")
+	suspicions          = flag.String("suspicions", "", "File to save suspicion matrix heatmap to.")
+
+	compare    = flag.String("compare", "", "File to save a comparison of the runs of each configuration to, for the metric given by -metric.")
+	compareCSV = flag.String("comparecsv", "", "File to save the aggregated numbers of all metrics of each configuration to.")
+	metric     = flag.String("metric", plotting.MetricThroughput, "The metric to compare, e.g. latency, throughput or timeouts.")
+	line       = flag.Bool("line", false, "Plot the comparison as a line instead of bars.")
+	warmup     = flag.Duration("warmup", 0, "Ignore measurements taken within this duration after a client/replica started, when comparing runs.")
+	cooldown   = flag.Duration("cooldown", 0, "Ignore measurements taken within this duration before the last measurement of a client/replica, when comparing runs.")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] [path to measurements.json]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s -compare file [flags] label=path...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "When comparing, each path is a measurements file or an experiment output directory,\n")
+		fmt.Fprintf(os.Stderr, "and the paths with the same label are repeated runs of the same configuration.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *compare != "" || *compareCSV != "" {
+		if err := compareRuns(flag.Args()); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	srcPath := flag.Arg(0)
+	if srcPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	file, err := os.Open(srcPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	latencyPlot := plotting.NewClientLatencyPlot()
+	throughputPlot := plotting.NewThroughputPlot()
+	throughputVSLatencyPlot := plotting.NewThroughputVSLatencyPlot()
+	timeoutPlot := plotting.NewTimeoutPlot()
+	suspicionPlot := plotting.NewSuspicionPlot()
+
+	reader := plotting.NewReader(file, &latencyPlot, &throughputPlot, &throughputVSLatencyPlot, &timeoutPlot, &suspicionPlot)
+	if err := reader.ReadAll(); err != nil {
+		log.Fatalln(err)
+	}
+
+	if *latency != "" {
+		if err := latencyPlot.PlotAverage(*latency, *interval); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if *throughput != "" {
+		if err := throughputPlot.PlotAverage(*throughput, *interval); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if *throughputVSLatency != "" {
+		if err := throughputVSLatencyPlot.PlotAverage(*throughputVSLatency, *interval); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if *timeouts != "" {
+		if err := timeoutPlot.PlotAverage(*timeouts, *interval); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if *suspicions != "" {
+		if err := suspicionPlot.Plot(*suspicions); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+// compareRuns compares the runs given as label=path arguments.
+func compareRuns(args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	comparison := plotting.NewComparison()
+	for _, arg := range args {
+		label, path, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("expected label=path, got '%s'", arg)
+		}
+		run, err := plotting.ReadSummary(path)
+		if err != nil {
+			return err
+		}
+		comparison.AddRun(label, run)
+	}
+	rows := comparison.Compute(comparison.Metrics(), *warmup, *cooldown)
+
+	if *compare != "" {
+		if err := plotting.PlotComparison(*compare, *metric, rows, *line); err != nil {
+			return err
+		}
+	}
+
+	if *compareCSV != "" {
+		f, err := os.OpenFile(*compareCSV, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		if err := plotting.WriteComparisonCSV(f, rows); err != nil {
+			return err
+		}
+		return f.Close()
+	}
+	return nil
+}
@@ -20,6 +20,13 @@ type Byzantine interface {
 	Wrap(consensus.Rules) consensus.Rules
 }
 
+// Extension is implemented by byzantine strategies that alter the behavior of other modules than the consensus rules,
+// such as Kauri or the ranking.
+type Extension interface {
+	// Extensions returns the modules that must be added to the replica, in addition to the wrapped rules.
+	Extensions() []any
+}
+
 type silence struct {
 	consensus.Rules
 }
@@ -0,0 +1,165 @@
+package byzantine_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	_ "github.com/relab/hotstuff/consensus/chainedhotstuff"
+	_ "github.com/relab/hotstuff/kauri"
+	_ "github.com/relab/hotstuff/leaderrotation"
+	_ "github.com/relab/hotstuff/ranking"
+	"github.com/relab/hotstuff/twins"
+)
+
+// The byzantine replica in all tests.
+const byzantineID hotstuff.ID = 2
+
+func withoutFaultyNodes(t *testing.T) {
+	t.Helper()
+	faultyNodes := hotstuff.FaultyNodes
+	hotstuff.FaultyNodes = nil
+	t.Cleanup(func() { hotstuff.FaultyNodes = faultyNodes })
+}
+
+func simulate(t *testing.T, cfg twins.SimulationConfig, strategy string) twins.SimulationResult {
+	t.Helper()
+	if strategy != "" {
+		cfg.Byzantine = map[hotstuff.ID]string{byzantineID: strategy}
+	}
+	result, err := twins.Simulate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe {
+		t.Fatalf("%s: expected the replicas to commit the same blocks: %+v", strategy, result)
+	}
+	return result
+}
+
+// latencyConfig has a replica whose votes are late, such that the latency vectors of correct leaders report it as slow.
+func latencyConfig() twins.SimulationConfig {
+	return twins.SimulationConfig{
+		Replicas: 4,
+		Modules:  []string{"complaintcache"},
+		Latency:  10 * time.Millisecond,
+		Faults:   []twins.Fault{{Replica: 4, Delay: 50 * time.Millisecond}},
+		Duration: 2 * time.Second,
+	}
+}
+
+func TestForgeLatency(t *testing.T) {
+	withoutFaultyNodes(t)
+	correct := simulate(t, latencyConfig(), "")
+	forged := simulate(t, latencyConfig(), "forgelatency")
+
+	// the correct replicas suspect every replica that the forged latency vectors report as slow.
+	for _, id := range []hotstuff.ID{1, 3} {
+		suspicions := forged.Suspicions[id]
+		for _, suspect := range []hotstuff.ID{1, 3, 4} {
+			if suspicions[suspect][byzantineID] <= correct.Suspicions[id][suspect][byzantineID] {
+				t.Errorf("replica %d: expected more suspicions of replica %d by replica %d than without forged vectors: %v",
+					id, suspect, byzantineID, suspicions)
+			}
+		}
+	}
+}
+
+func TestOmitLatency(t *testing.T) {
+	withoutFaultyNodes(t)
+	correct := simulate(t, latencyConfig(), "")
+	omitted := simulate(t, latencyConfig(), "omitlatency")
+
+	// the ranking cannot tell an omitted latency vector from one that reports no slow replicas,
+	// so the omission goes unnoticed and the suspicions are the same as without the byzantine replica.
+	if !reflect.DeepEqual(omitted.Suspicions, correct.Suspicions) {
+		t.Errorf("expected the same suspicions as without omitted vectors:\ngot  %v\nwant %v",
+			omitted.Suspicions, correct.Suspicions)
+	}
+	if omitted.Commits != correct.Commits {
+		t.Errorf("expected %d commits, got %d", correct.Commits, omitted.Commits)
+	}
+}
+
+func TestFalseSuspicion(t *testing.T) {
+	withoutFaultyNodes(t)
+	cfg := twins.SimulationConfig{
+		Replicas: 4,
+		Modules:  []string{"complaintcache"},
+		Latency:  10 * time.Millisecond,
+		Duration: time.Second,
+	}
+	correct := simulate(t, cfg, "")
+	suspicious := simulate(t, cfg, "falsesuspicion")
+
+	for _, id := range []hotstuff.ID{1, 3, 4} {
+		for _, suspect := range []hotstuff.ID{1, 3, 4} {
+			if correct.Suspicions[id][suspect][byzantineID] != 0 {
+				t.Errorf("replica %d: expected no suspicions of replica %d without the byzantine replica: %v",
+					id, suspect, correct.Suspicions[id])
+			}
+			if suspicious.Suspicions[id][suspect][byzantineID] == 0 {
+				t.Errorf("replica %d: expected the false suspicions of replica %d to be committed: %v",
+					id, suspect, suspicious.Suspicions[id])
+			}
+		}
+	}
+}
+
+func TestContribution(t *testing.T) {
+	withoutFaultyNodes(t)
+	trees := []struct {
+		name      string
+		positions []hotstuff.ID
+	}{
+		// replica 2 is an internal node with children 4 and 5.
+		{name: "internal", positions: []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}},
+		// replica 2 is a leaf below replica 3.
+		{name: "leaf", positions: []hotstuff.ID{1, 3, 4, 2, 5, 6, 7}},
+	}
+	tests := []struct {
+		strategy string
+		// suspected is true if the root suspects the internal node, i.e., the contribution misses the timeout.
+		suspected bool
+	}{
+		{strategy: "dropcontribution", suspected: true},
+		{strategy: "delaycontribution", suspected: false},
+		{strategy: "slowinternal", suspected: true},
+	}
+	for _, tree := range trees {
+		cfg := twins.SimulationConfig{
+			Replicas:       7,
+			LeaderRotation: "fixed",
+			Modules:        []string{"kauri", "complaintcache"},
+			TreePositions:  tree.positions,
+			Latency:        10 * time.Millisecond,
+			Duration:       time.Second,
+		}
+		correct := simulate(t, cfg, "")
+		for _, test := range tests {
+			t.Run(tree.name+"/"+test.strategy, func(t *testing.T) {
+				result := simulate(t, cfg, test.strategy)
+				// the root files the complaint about a missing child with itself as the complainee.
+				suspicions := result.Suspicions[1][1][byzantineID]
+
+				if tree.name == "leaf" {
+					if result.Commits != correct.Commits || suspicions != 0 {
+						t.Errorf("expected a leaf to behave like a correct replica: got %d commits and %d suspicions, want %d commits",
+							result.Commits, suspicions, correct.Commits)
+					}
+					return
+				}
+				if result.Commits >= correct.Commits {
+					t.Errorf("expected fewer than %d commits, got %d", correct.Commits, result.Commits)
+				}
+				if test.suspected && suspicions == 0 {
+					t.Errorf("expected the root to suspect replica %d: %v", byzantineID, result.Suspicions[1])
+				}
+				if !test.suspected && suspicions != 0 {
+					t.Errorf("expected the root not to suspect replica %d: %v", byzantineID, result.Suspicions[1])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package byzantine
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/kauripb"
+	"github.com/relab/hotstuff/kauri"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+func init() {
+	modules.RegisterModule("omitlatency", func() Byzantine { return &latencyVector{omit: true} })
+	modules.RegisterModule("forgelatency", func() Byzantine { return &latencyVector{} })
+	modules.RegisterModule("falsesuspicion", func() Byzantine { return &falseSuspicion{} })
+	modules.RegisterModule("dropcontribution", func() Byzantine { return &contribution{drop: true} })
+	modules.RegisterModule("delaycontribution", func() Byzantine { return &contribution{untilTimeout: true} })
+	modules.RegisterModule("slowinternal", func() Byzantine { return &contribution{delay: slowInternalDelay} })
+}
+
+// forgedLatency is the latency, in microseconds, that a forged latency vector reports for every replica.
+// It is the largest latency that fits in an entry of the vector.
+const forgedLatency = 0x00FFFFFF
+
+type latencyVector struct {
+	configuration modules.Configuration
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+	ranking       modules.Ranking
+	synchronizer  modules.Synchronizer
+	omit          bool
+	consensus.Rules
+}
+
+func (l *latencyVector) InitModule(mods *modules.Core) {
+	mods.Get(
+		&l.configuration,
+		&l.eventLoop,
+		&l.opts,
+		&l.synchronizer,
+	)
+	mods.TryGet(&l.ranking)
+
+	if mod, ok := l.Rules.(modules.Module); ok {
+		mod.InitModule(mods)
+	}
+}
+
+// ProposeRule proposes like a correct leader, except that the latency vector of the QC is omitted or forged.
+// The latency vector is not signed, so the other replicas accept the QC.
+func (l *latencyVector) ProposeRule(cert hotstuff.SyncInfo, cmd hotstuff.Command) (proposal hotstuff.ProposeMsg, ok bool) {
+	qc, ok := cert.QC()
+	if !ok {
+		qc = l.synchronizer.HighQC()
+	}
+	var vector []uint32
+	if !l.omit {
+		vector = l.forge()
+	}
+	qc = hotstuff.NewQuorumCert(qc.Signature(), qc.View(), qc.BlockHash(), vector)
+
+	var complaints []*hotstuff.Complaint
+	if l.ranking != nil {
+		complaints = l.ranking.GetPendingComplaints()
+	}
+	proposal = hotstuff.ProposeMsg{
+		ID: l.opts.ID(),
+		Block: hotstuff.NewBlockWithComplaints(
+			qc.BlockHash(),
+			qc,
+			cmd,
+			l.synchronizer.View(),
+			l.opts.ID(),
+			l.eventLoop.Now(),
+			complaints,
+		),
+	}
+	if aggQC, ok := cert.AggQC(); ok && l.opts.ShouldUseAggQC() {
+		proposal.AggregateQC = &aggQC
+	}
+	return proposal, true
+}
+
+// forge returns a latency vector that reports every other replica as slow.
+func (l *latencyVector) forge() []uint32 {
+	ids := maps.Keys(l.configuration.Replicas())
+	slices.Sort(ids)
+	vector := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		if id != l.opts.ID() {
+			vector = append(vector, uint32(id)<<24|forgedLatency)
+		}
+	}
+	return vector
+}
+
+func (l *latencyVector) Wrap(rules consensus.Rules) consensus.Rules {
+	l.Rules = rules
+	return l
+}
+
+// NewOmitLatency returns a byzantine replica that proposes QCs without latency vectors,
+// such that the replicas never learn the latencies that it measured.
+func NewOmitLatency(rules consensus.Rules) consensus.Rules {
+	return &latencyVector{Rules: rules, omit: true}
+}
+
+// NewForgeLatency returns a byzantine replica that proposes QCs with forged latency vectors,
+// which report every other replica as slow.
+func NewForgeLatency(rules consensus.Rules) consensus.Rules {
+	return &latencyVector{Rules: rules}
+}
+
+// falseSuspicion files a Suspicion complaint against every other replica in each view that it leads.
+// The complaints are included in its proposals, and the other replicas commit them to their suspicion matrices.
+type falseSuspicion struct {
+	configuration  modules.Configuration
+	eventLoop      *eventloop.EventLoop
+	leaderRotation modules.LeaderRotation
+	logger         logging.Logger
+	opts           *modules.Options
+	ranking        modules.Ranking
+}
+
+func (f *falseSuspicion) InitModule(mods *modules.Core) {
+	mods.Get(
+		&f.configuration,
+		&f.eventLoop,
+		&f.leaderRotation,
+		&f.logger,
+		&f.opts,
+	)
+	if !mods.TryGet(&f.ranking) {
+		f.logger.Warn("falsesuspicion: no ranking module to file complaints with")
+		return
+	}
+	f.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		f.viewChange(event.(synchronizer.ViewChangeEvent).View)
+	})
+}
+
+func (f *falseSuspicion) viewChange(view hotstuff.View) {
+	if f.leaderRotation.GetLeader(view) != f.opts.ID() {
+		return
+	}
+	ids := maps.Keys(f.configuration.Replicas())
+	slices.Sort(ids)
+	for _, id := range ids {
+		if id == f.opts.ID() {
+			continue
+		}
+		f.ranking.AddComplaint(&hotstuff.Complaint{
+			Complainee:    id,
+			Complainant:   f.opts.ID(),
+			ComplaintType: hotstuff.Suspicion,
+		})
+	}
+}
+
+// Wrap returns the rules unaltered, since the complaints are filed by the extension.
+func (f *falseSuspicion) Wrap(rules consensus.Rules) consensus.Rules {
+	return rules
+}
+
+func (f *falseSuspicion) Extensions() []any {
+	return []any{f}
+}
+
+// slowInternalDelay is the delay that the slowinternal strategy adds to the contributions of an internal node.
+const slowInternalDelay = 100 * time.Millisecond
+
+// contribution drops or delays the Kauri contributions that it sends to its parent, but only when it is an internal
+// node of the tree. As a leaf, it sends its contributions like a correct replica.
+type contribution struct {
+	configuration modules.Configuration
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+	network       kauri.Network
+
+	drop         bool          // drop the contributions
+	untilTimeout bool          // delay the contributions until just before the parent's aggregation timeout
+	delay        time.Duration // a fixed delay to add to the contributions
+
+	tree     kauri.TreeConfiguration // the current tree, or nil if Kauri has not built it yet
+	proposed time.Time               // when the replica received the proposal of the current view
+}
+
+func (c *contribution) InitModule(mods *modules.Core) {
+	mods.Get(
+		&c.configuration,
+		&c.eventLoop,
+		&c.opts,
+	)
+	c.eventLoop.RegisterObserver(hotstuff.TreeChangeEvent{}, func(event any) {
+		c.treeChanged(event.(hotstuff.TreeChangeEvent))
+	})
+	c.eventLoop.RegisterObserver(hotstuff.ProposeMsg{}, func(_ any) {
+		c.proposed = c.eventLoop.Now()
+	})
+	c.eventLoop.RegisterHandler(delayedContribution{}, func(event any) {
+		delayed := event.(delayedContribution)
+		c.network.SendContribution(delayed.parent, delayed.contribution)
+	})
+}
+
+func (c *contribution) treeChanged(event hotstuff.TreeChangeEvent) {
+	positions := make(map[hotstuff.ID]int, len(event.Tree))
+	for pos, id := range event.Tree {
+		positions[id] = pos
+	}
+	c.tree = kauri.CreateTree(len(event.Tree), c.opts.ID())
+	c.tree.InitializeWithPIDs(positions)
+}
+
+// internal returns true if the replica is neither the root nor a leaf of the current tree.
+func (c *contribution) internal() bool {
+	if c.tree == nil {
+		return false
+	}
+	_, hasParent := c.tree.GetParent()
+	return hasParent && len(c.tree.GetChildren()) > 0
+}
+
+// timeoutDelay returns the delay after which a contribution sent to the parent arrives just before the parent's
+// aggregation timeout. The parent waits for the tree delta for each level of its subtree after it sent the proposal,
+// which arrived at this replica one link latency later.
+func (c *contribution) timeoutDelay(parent hotstuff.ID) time.Duration {
+	delta := c.opts.TreeDelta()
+	if delta <= 0 {
+		delta = kauri.DefaultTreeDelta
+	}
+	latency := c.configuration.GetLatency(c.opts.ID(), parent)
+	timeout := c.proposed.Add(-latency).Add(time.Duration(c.tree.GetHeight()+1) * delta)
+	send := timeout.Add(-latency - delta/10)
+	return max(send.Sub(c.eventLoop.Now()), 0)
+}
+
+func (c *contribution) sendContribution(parent hotstuff.ID, contribution *kauripb.Contribution) {
+	if !c.internal() {
+		c.network.SendContribution(parent, contribution)
+		return
+	}
+	if c.drop {
+		return
+	}
+	delay := c.delay
+	if c.untilTimeout {
+		delay = c.timeoutDelay(parent)
+	}
+	c.eventLoop.AfterFunc(delay, func() {
+		c.eventLoop.AddEvent(delayedContribution{parent: parent, contribution: contribution})
+	})
+}
+
+// WrapNetwork returns a network that drops or delays the contributions of the replica when it is an internal node.
+func (c *contribution) WrapNetwork(network kauri.Network) kauri.Network {
+	c.network = network
+	return contributionNetwork{c}
+}
+
+// Wrap returns the rules unaltered, since the contributions are sent by Kauri.
+func (c *contribution) Wrap(rules consensus.Rules) consensus.Rules {
+	return rules
+}
+
+func (c *contribution) Extensions() []any {
+	return []any{c}
+}
+
+// contributionNetwork is the network returned by WrapNetwork. It is a separate type, such that the strategy itself
+// is not mistaken for Kauri's network by the module system.
+type contributionNetwork struct {
+	strategy *contribution
+}
+
+func (n contributionNetwork) SendContribution(id hotstuff.ID, contribution *kauripb.Contribution) {
+	n.strategy.sendContribution(id, contribution)
+}
+
+// delayedContribution is raised when a delayed contribution should be sent to the parent.
+type delayedContribution struct {
+	parent       hotstuff.ID
+	contribution *kauripb.Contribution
+}
@@ -97,7 +97,32 @@ func (hs *ChainedHotStuff) VoteRule(proposal hotstuff.ProposeMsg) bool {
 	return safe
 }
 
+// Recover restores the locked block of a restarted replica from the high QC that it stored.
+// The replica locked the block certified by the QC of the block certified by the high QC
+// when it accepted the proposal that carried the high QC.
+func (hs *ChainedHotStuff) Recover(storage modules.StableStorage) {
+	qc, ok := storage.HighQC()
+	if !ok {
+		return
+	}
+	block1, ok := hs.blockChain.LocalGet(qc.BlockHash())
+	if !ok {
+		return
+	}
+	block2, ok := hs.blockChain.LocalGet(block1.QuorumCert().BlockHash())
+	if !ok {
+		hs.logger.Warnf("Recover: the locked block %.8s was not stored", block1.QuorumCert().BlockHash())
+		return
+	}
+	if block2.View() > hs.bLock.View() {
+		hs.logger.Debug("Recovered lock: ", block2)
+		hs.bLock = block2
+	}
+}
+
 // ChainLength returns the number of blocks that need to be chained together in order to commit.
 func (hs *ChainedHotStuff) ChainLength() int {
 	return 3
 }
+
+var _ modules.Recoverer = (*ChainedHotStuff)(nil)
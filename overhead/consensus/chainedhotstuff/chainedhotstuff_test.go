@@ -0,0 +1,85 @@
+package chainedhotstuff
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain/persistent"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// testQC returns a quorum certificate for the block with made up signatures of replicas 1 to 3.
+func testQC(block *hotstuff.Block) hotstuff.QuorumCert {
+	var sigs []*ecdsa.Signature
+	for id := hotstuff.ID(1); id <= 3; id++ {
+		sigs = append(sigs, ecdsa.RestoreSignature(big.NewInt(int64(id)), big.NewInt(int64(block.View())), id))
+	}
+	return hotstuff.NewQuorumCert(ecdsa.RestoreMultiSignature(sigs), block.View(), block.Hash(), nil)
+}
+
+func openStorage(t *testing.T, path string) modules.BlockChain {
+	t.Helper()
+	chain, err := persistent.New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = chain.(modules.StableStorage).Close() })
+	return chain
+}
+
+// TestRecoverLock tests that a restarted replica is still locked on a block that was not committed,
+// and therefore does not vote for a conflicting proposal.
+func TestRecoverLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replica.log")
+	chain := openStorage(t, path)
+
+	// the replica accepts a proposal in view 4, which locks the block of view 2 and commits the block of view 1
+	var blocks []*hotstuff.Block
+	parent, qc := hotstuff.GetGenesis(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash(), nil)
+	for view := hotstuff.View(1); view <= 4; view++ {
+		block := hotstuff.NewBlock(parent.Hash(), qc, hotstuff.Command("cmd"), view, 1, time.Unix(0, int64(view)))
+		chain.Store(block)
+		blocks = append(blocks, block)
+		parent, qc = block, testQC(block)
+	}
+	hs := New().(*ChainedHotStuff)
+	hs.blockChain, hs.logger = chain, logging.New("test")
+	if committed := hs.CommitRule(blocks[3]); committed != blocks[0] {
+		t.Fatalf("committed %v, want %v", committed, blocks[0])
+	}
+	if hs.bLock != blocks[1] {
+		t.Fatalf("locked %v, want %v", hs.bLock, blocks[1])
+	}
+	storage := chain.(modules.StableStorage)
+	storage.StoreHighQC(blocks[3].QuorumCert())
+	storage.StoreCommit(blocks[0], nil)
+	if err := storage.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	chain = openStorage(t, path)
+	hs = New().(*ChainedHotStuff)
+	hs.blockChain, hs.logger = chain, logging.New("test")
+	hs.Recover(chain.(modules.StableStorage))
+	if hs.bLock.Hash() != blocks[1].Hash() {
+		t.Fatalf("recovered the lock %v, want %v", hs.bLock, blocks[1])
+	}
+
+	// a proposal that extends the committed block, but not the locked block
+	conflicting := hotstuff.NewBlock(blocks[0].Hash(), testQC(blocks[0]), hotstuff.Command("other"), 5, 2, time.Unix(0, 5))
+	chain.Store(conflicting)
+	if hs.VoteRule(hotstuff.ProposeMsg{ID: 2, Block: conflicting}) {
+		t.Error("voted for a proposal that conflicts with the recovered lock")
+	}
+	// a proposal that extends the locked block
+	extending := hotstuff.NewBlock(blocks[2].Hash(), testQC(blocks[2]), hotstuff.Command("next"), 5, 2, time.Unix(0, 5))
+	chain.Store(extending)
+	if !hs.VoteRule(hotstuff.ProposeMsg{ID: 2, Block: extending}) {
+		t.Error("did not vote for a proposal that extends the recovered lock")
+	}
+}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -42,6 +43,7 @@ type consensusBase struct {
 
 	acceptor       modules.Acceptor
 	blockChain     modules.BlockChain
+	checkpointer   modules.Checkpointer
 	commandQueue   modules.CommandQueue
 	configuration  modules.Configuration
 	crypto         modules.Crypto
@@ -52,16 +54,29 @@ type consensusBase struct {
 	logger         logging.Logger
 	ranking        modules.Ranking
 	opts           *modules.Options
+	storage        modules.StableStorage
 	synchronizer   modules.Synchronizer
 
 	handel modules.Handel
+	kauri  modules.Kauri
 
 	lastVote hotstuff.View
 
 	mut        sync.Mutex
 	bExec      *hotstuff.Block
+	height     uint64 // the number of blocks committed after the genesis block
 	committees map[int][]hotstuff.ID
 	index      int
+
+	// reconfiguration state; see reconfiguration.go
+	activeReplicas         []hotstuff.ID    // active replicas as of the last committed reconfiguration
+	pendingReconfiguration hotstuff.Command // requested reconfiguration that has not been committed yet
+	operatorSerial         uint64           // serial number of the last committed operator certificate
+	reconfigurationView    hotstuff.View    // view of the last proposed reconfiguration
+
+	// committed ranking state; see ranking.go
+	rankings     map[hotstuff.Hash]committedRanking // ranking state after each recently committed block
+	rankingOrder []hotstuff.Hash                    // the recently committed blocks in commit order
 }
 
 // New returns a new Consensus instance based on the given Rules implementation.
@@ -72,6 +87,7 @@ func New(impl Rules) modules.Consensus {
 		bExec:      hotstuff.GetGenesis(),
 		committees: make(map[int][]hotstuff.ID),
 		index:      1,
+		rankings:   make(map[hotstuff.Hash]committedRanking),
 	}
 }
 
@@ -93,6 +109,9 @@ func (cs *consensusBase) InitModule(mods *modules.Core) {
 	)
 
 	mods.TryGet(&cs.handel)
+	mods.TryGet(&cs.kauri)
+	mods.TryGet(&cs.storage)
+	mods.TryGet(&cs.checkpointer)
 
 	if mod, ok := cs.impl.(modules.Module); ok {
 		mod.InitModule(mods)
@@ -102,6 +121,9 @@ func (cs *consensusBase) InitModule(mods *modules.Core) {
 		cs.OnPropose(event.(hotstuff.ProposeMsg))
 	})
 	mods.TryGet(&cs.ranking)
+	cs.eventLoop.RegisterHandler(hotstuff.ReconfigurationRequest{}, func(event any) {
+		cs.onReconfigurationRequest(event.(hotstuff.ReconfigurationRequest))
+	})
 }
 
 func (cs *consensusBase) CommittedBlock() *hotstuff.Block {
@@ -114,6 +136,11 @@ func (cs *consensusBase) CommittedBlock() *hotstuff.Block {
 func (cs *consensusBase) StopVoting(view hotstuff.View) {
 	if cs.lastVote < view {
 		cs.lastVote = view
+		if cs.storage != nil {
+			if err := cs.storage.StoreLastVote(view); err != nil {
+				cs.logger.Errorf("StopVoting: failed to store last vote: %v", err)
+			}
+		}
 	}
 }
 
@@ -121,79 +148,27 @@ func (cs *consensusBase) StopVoting(view hotstuff.View) {
 func (cs *consensusBase) Propose(cert hotstuff.SyncInfo) {
 	cs.logger.Debug("Propose")
 
+	var parent *hotstuff.Block
 	qc, ok := cert.QC()
 	if ok {
 		// tell the acceptor that the previous proposal succeeded.
 		if qcBlock, ok := cs.blockChain.Get(qc.BlockHash()); ok {
-			cs.acceptor.Proposed(qcBlock.Command())
+			cs.proposed(qcBlock.Command())
+			parent = qcBlock
 		} else {
 			cs.logger.Errorf("Could not find block for QC: %s", qc)
 		}
 	}
-	// This is the code for reconfiguration of committees
-	// if !cs.isCommitteeComputed {
-	// 	cs.committees = cs.configuration.GetCommittees(20, true)
-	// 	cs.isCommitteeComputed = true
-	// 	cs.logger.Info("committes", cs.committees)
-	// }
-	//cs.index = cs.index % len(cs.committees)
-	//cs.index = 1
-	//count := 2
-	// if cs.synchronizer.View()%100 == 0 && cs.index <= 2 {
-
-	// 	suspicions := cs.ranking.GetSuspectedNodes()
-	// 	cs.logger.Info("suspicions are ", suspicions)
-	// 	ids := make([]hotstuff.ID, 0, len(suspicions))
-	// 	for id := range suspicions {
-	// 		ids = append(ids, id)
-	// 	}
-	// 	sort.SliceStable(ids, func(i, j int) bool {
-	// 		return suspicions[ids[i]] > suspicions[ids[j]]
-	// 	})
-
-	// 	removeNodes := make([]hotstuff.ID, 0)
-	// 	for index, id := range ids {
-	// 		if index <= count {
-	// 			removeNodes = append(removeNodes, id)
-	// 		} else {
-	// 			break
-	// 		}
-	// 	}
-	// 	committee := make([]hotstuff.ID, 0)
-	// 	cs.logger.Info("committee removed nodes are ", removeNodes)
-	// 	for id := range cs.configuration.Replicas() {
-	// 		found := false
-	// 		for _, badNode := range removeNodes {
-	// 			if id == badNode {
-	// 				found = true
-	// 				break
-	// 			}
-	// 		}
-	// 		if !found {
-	// 			committee = append(committee, id)
-	// 		}
-	// 	}
-	// 	reconfigurationRequest := hotstuff.ReconfigurationMsg{
-	// 		QuorumSize:        hotstuff.QuorumSize(len(committee)),
-	// 		ActiveReplicas:    committee,
-	// 		QuorumCertificate: qc,
-	// 		View:              cs.synchronizer.View(),
-	// 	}
-	// 	cs.configuration.Reconfiguration(reconfigurationRequest)
-	// 	cs.eventLoop.AddEvent(reconfigurationRequest)
-	// 	count++
-	// 	cs.index++
-	// 	cs.logger.Info("reconfig request ", reconfigurationRequest)
-	// 	return
-	// }
-
-	ctx, cancel := synchronizer.TimeoutContext(cs.eventLoop.Context(), cs.eventLoop)
-	defer cancel()
-
-	cmd, ok := cs.commandQueue.Get(ctx)
-	if !ok {
-		cs.logger.Debug("Propose: No command")
-		return
+	cmd, isReconfiguration := cs.nextReconfiguration(parent)
+	if !isReconfiguration {
+		ctx, cancel := synchronizer.TimeoutContext(cs.eventLoop.Context(), cs.eventLoop)
+		defer cancel()
+
+		cmd, ok = cs.commandQueue.Get(ctx)
+		if !ok {
+			cs.logger.Debug("Propose: No command")
+			return
+		}
 	}
 
 	var proposal hotstuff.ProposeMsg
@@ -213,15 +188,15 @@ func (cs *consensusBase) Propose(cert hotstuff.SyncInfo) {
 		}
 
 		proposal = hotstuff.ProposeMsg{
-			ID:         cs.opts.ID(),
-			Complaints: complaints,
-			Block: hotstuff.NewBlock(
+			ID: cs.opts.ID(),
+			Block: hotstuff.NewBlockWithComplaints(
 				qc.BlockHash(),
 				qc,
 				cmd,
 				cs.synchronizer.View(),
 				cs.opts.ID(),
-				time.Now(),
+				cs.eventLoop.Now(),
+				complaints,
 			),
 		}
 		//cs.logger.Info("size of the proposal ", size.Of(proposal.Block.Time()))
@@ -232,7 +207,18 @@ func (cs *consensusBase) Propose(cert hotstuff.SyncInfo) {
 
 	cs.blockChain.Store(proposal.Block)
 
-	cs.configuration.Propose(proposal)
+	// a restarted replica must not propose again in this view
+	if cs.storage != nil {
+		if err := cs.storage.StoreLastVote(proposal.Block.View()); err != nil {
+			cs.logger.Errorf("Propose: failed to store the view: %v", err)
+			return
+		}
+	}
+
+	if cs.kauri == nil {
+		// with Kauri, the proposal is disseminated down the tree by Begin.
+		cs.configuration.Propose(proposal)
+	}
 	// self vote
 	cs.OnPropose(proposal)
 	//cs.configuration.Update(*proposal.Block)
@@ -257,36 +243,67 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 			return
 		}
 	}
-	if len(proposal.Complaints) > 0 && cs.ranking != nil {
-		cs.ranking.CommitComplaints(proposal.Complaints)
-		cs.eventLoop.AddEvent(hotstuff.CheckLatencyVector{
-			LatencyVector: block.QuorumCert().LatencyVector(),
-			Proposer:      block.Proposer(),
+	// ensure the block came from the leader.
+	expectedLeader := cs.leaderRotation.GetLeader(block.View())
+	if block.Proposer() != expectedLeader {
+		cs.logger.Infof("OnPropose: block was not proposed by the expected leader %d", expectedLeader)
+		return
+	}
+	// With Kauri, the proposal is relayed by the parent in the leader's tree.
+	expectedSender := expectedLeader
+	if cs.kauri != nil {
+		sender, ok := cs.kauri.ProposalSender(block)
+		if !ok {
+			cs.logger.Infof("OnPropose: the tree of view %d is not rooted at the leader %d", block.View(), expectedLeader)
+			return
+		}
+		expectedSender = sender
+	}
+	if proposal.ID != expectedSender {
+		cs.logger.Infof("OnPropose: proposal was not sent by the expected replica %d", expectedSender)
+		return
+	}
+
+	if proposal.ID != cs.opts.ID() {
+		cs.eventLoop.AddEvent(hotstuff.PhaseEvent{
+			View:  block.View(),
+			Phase: hotstuff.PhaseDissemination,
+			Start: block.Time(),
+			End:   cs.eventLoop.Now(),
 		})
 	}
+
 	if !cs.crypto.VerifyQuorumCert(block.QuorumCert()) {
 		cs.logger.Info("OnPropose: invalid QC")
 		return
 	}
 
-	// ensure the block came from the leader.
-	// if proposal.ID != cs.leaderRotation.GetLeader(block.View()) {
-	// 	cs.logger.Info("OnPropose: block was not proposed by the expected leader")
-	// 	return
-	// }
-
 	if !cs.impl.VoteRule(proposal) {
 		cs.logger.Info("OnPropose: Block not voted for")
 		return
 	}
 
 	if qcBlock, ok := cs.blockChain.Get(block.QuorumCert().BlockHash()); ok {
-		cs.acceptor.Proposed(qcBlock.Command())
+		cs.proposed(qcBlock.Command())
 	} else {
 		cs.logger.Info("OnPropose: Failed to fetch qcBlock")
 	}
 
-	if !cs.acceptor.Accept(block.Command()) {
+	if reconfiguration, ok := hotstuff.ReconfigurationFromCommand(block.Command()); ok {
+		if err := cs.verifyReconfiguration(reconfiguration); err != nil {
+			cs.logger.Infof("OnPropose: invalid reconfiguration: %v", err)
+			return
+		}
+		parent, ok := cs.blockChain.Get(block.Parent())
+		if !ok {
+			cs.logger.Info("OnPropose: failed to fetch the parent of the reconfiguration")
+			return
+		}
+		if err := cs.verifyRemovals(reconfiguration, parent); err != nil {
+			cs.logger.Infof("OnPropose: invalid reconfiguration: %v", err)
+			return
+		}
+	} else if !cs.acceptor.Accept(block.Command()) {
 		cs.logger.Info("OnPropose: command not accepted")
 		return
 	}
@@ -294,6 +311,16 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 	// block is safe and was accepted
 	cs.blockChain.Store(block)
 
+	// the complaints count towards the ranking state of this replica when the block is accepted,
+	// and towards the committed ranking state when the block is committed
+	if len(block.Complaints()) > 0 && cs.ranking != nil {
+		cs.ranking.CommitComplaints(block.Complaints())
+		cs.eventLoop.AddEvent(hotstuff.CheckLatencyVector{
+			LatencyVector: block.QuorumCert().LatencyVector(),
+			Proposer:      block.Proposer(),
+		})
+	}
+
 	if b := cs.impl.CommitRule(block); b != nil {
 		cs.Commit(b)
 	}
@@ -310,6 +337,14 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 		return
 	}
 
+	// a restarted replica must not vote again in this view
+	if cs.storage != nil {
+		if err := cs.storage.StoreLastVote(block.View()); err != nil {
+			cs.logger.Error("OnPropose: failed to store the vote: ", err)
+			return
+		}
+	}
+
 	cs.lastVote = block.View()
 
 	if cs.handel != nil {
@@ -318,6 +353,12 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 		return
 	}
 
+	if cs.kauri != nil {
+		// let Kauri disseminate the proposal and aggregate the votes
+		cs.kauri.Begin(pc, proposal)
+		return
+	}
+
 	leaderID := cs.leaderRotation.GetLeader(cs.lastVote + 1)
 	if leaderID == cs.opts.ID() {
 		cs.eventLoop.AddEvent(hotstuff.VoteMsg{ID: cs.opts.ID(), PartialCert: pc})
@@ -351,7 +392,9 @@ func (cs *consensusBase) Commit(block *hotstuff.Block) {
 	// prune the blockchain and handle forked blocks
 	forkedBlocks := cs.blockChain.PruneToHeight(block.View())
 	for _, block := range forkedBlocks {
-		cs.forkHandler.Fork(block)
+		if !block.Command().IsReconfiguration() {
+			cs.forkHandler.Fork(block)
+		}
 	}
 }
 
@@ -369,11 +412,110 @@ func (cs *consensusBase) commitInner(block *hotstuff.Block) error {
 		return fmt.Errorf("failed to locate block: %s", block.Parent())
 	}
 	cs.logger.Debug("EXEC: ", block)
-	cs.executor.Exec(block)
+	if cs.ranking != nil {
+		cs.ranking.CommitBlock(block)
+	}
+	cs.recordRanking(block)
+	if reconfiguration, ok := hotstuff.ReconfigurationFromCommand(block.Command()); ok {
+		cs.commitReconfiguration(block, reconfiguration)
+	} else {
+		cs.executor.Exec(block)
+	}
+	cs.eventLoop.AddEvent(hotstuff.PhaseEvent{
+		View:  block.View(),
+		Phase: hotstuff.PhaseCommit,
+		Start: block.Time(),
+		End:   cs.eventLoop.Now(),
+	})
+	cs.bExec = block
+	cs.height++
+	if cs.storage != nil {
+		cs.storage.StoreCommit(block, cs.rankingSnapshot())
+	}
+	if cs.checkpointer != nil {
+		cs.checkpointer.Committed(block, cs.height, cs.activeReplicas)
+	}
+	return nil
+}
+
+// InstallCheckpoint makes the block of a stable checkpoint the last committed block, without executing the blocks
+// that lead up to it. The state of the executor must be restored from the checkpoint by the caller.
+//
+// NOTE: the blocks that were skipped are not recorded by the stable storage, so a replica that installed a
+// checkpoint does not recover the state of the checkpoint if it is restarted.
+func (cs *consensusBase) InstallCheckpoint(block *hotstuff.Block, checkpoint hotstuff.Checkpoint) {
+	cs.mut.Lock()
+	if cs.bExec.View() >= block.View() {
+		cs.mut.Unlock()
+		return
+	}
 	cs.bExec = block
+	cs.height = checkpoint.Height
+	cs.activeReplicas = checkpoint.ActiveReplicas
+	// the ranking state of the checkpoint has been restored by the caller
+	cs.recordRanking(block)
+	if pending, ok := hotstuff.ReconfigurationFromCommand(cs.pendingReconfiguration); ok &&
+		slices.Equal(pending.ActiveReplicas, checkpoint.ActiveReplicas) {
+		cs.pendingReconfiguration = ""
+	}
+	cs.mut.Unlock()
+
+	for _, block := range cs.blockChain.PruneToHeight(block.View()) {
+		if !block.Command().IsReconfiguration() {
+			cs.forkHandler.Fork(block)
+		}
+	}
+}
+
+// rankingSnapshot returns a snapshot of the ranking state, or nil if the ranking module cannot be snapshotted.
+func (cs *consensusBase) rankingSnapshot() []byte {
+	if snapshotter, ok := cs.ranking.(modules.Snapshotter); ok {
+		return snapshotter.Snapshot()
+	}
 	return nil
 }
 
+// Recover restores the ranking state and the last vote that were stored by a previous run of the replica,
+// and executes the committed blocks again to restore the state of the executor.
+// Rules that implement modules.Recoverer restore their own state, such as the locked block, afterwards.
+func (cs *consensusBase) Recover(storage modules.StableStorage) {
+	blocks, rankingState := storage.Committed()
+	if snapshotter, ok := cs.ranking.(modules.Snapshotter); ok && rankingState != nil {
+		if err := snapshotter.Restore(rankingState); err != nil {
+			cs.logger.Errorf("Recover: failed to restore the ranking state: %v", err)
+		}
+	}
+	cs.mut.Lock()
+	for _, block := range blocks {
+		if block.View() <= cs.bExec.View() {
+			continue
+		}
+		if reconfiguration, ok := hotstuff.ReconfigurationFromCommand(block.Command()); ok {
+			cs.activeReplicas = reconfiguration.ActiveReplicas
+			cs.operatorSerial = max(cs.operatorSerial, reconfiguration.Certificate.Serial)
+		} else {
+			cs.acceptor.Proposed(block.Command())
+			cs.executor.Exec(block)
+		}
+		cs.bExec = block
+		cs.height++
+	}
+	if len(blocks) > 0 {
+		// the ranking state of the earlier blocks was not stored
+		cs.recordRanking(cs.bExec)
+	}
+	cs.mut.Unlock()
+	if lastVote := storage.LastVote(); lastVote > cs.lastVote {
+		cs.lastVote = lastVote
+	}
+	if len(blocks) > 0 || cs.lastVote > 0 {
+		cs.logger.Infof("Recovered %d committed blocks, last vote in view %d", len(blocks), cs.lastVote)
+	}
+	if recoverer, ok := cs.impl.(modules.Recoverer); ok {
+		recoverer.Recover(storage)
+	}
+}
+
 // ChainLength returns the number of blocks that need to be chained together in order to commit.
 func (cs *consensusBase) ChainLength() int {
 	return cs.impl.ChainLength()
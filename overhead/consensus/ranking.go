@@ -0,0 +1,57 @@
+package consensus
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+// Committed ranking state
+//
+// Each block carries the complaints of its proposer. When the consensus module commits a block, the ranking module
+// applies the complaints of the block to its committed ranking state, and the consensus module records the suspicion
+// matrix and the faulty replicas of that state, such that decisions that the replicas must agree on, such as the
+// choice of a leader, can be based on the ranking state after a given committed block, even after the replicas have
+// committed later blocks. The replicas commit the same blocks in the same order, so they record the same state.
+
+// rankingHistoryLength is the number of recently committed blocks whose ranking state is kept.
+const rankingHistoryLength = 128
+
+// committedRanking is the ranking state after a committed block.
+type committedRanking struct {
+	suspicions map[hotstuff.ID]map[hotstuff.ID]int
+	faulty     []hotstuff.ID
+}
+
+// recordRanking records the committed ranking state as the ranking state after the committed block,
+// and forgets the ranking state of the blocks that were committed rankingHistoryLength blocks earlier.
+// The caller must hold cs.mut.
+func (cs *consensusBase) recordRanking(block *hotstuff.Block) {
+	if cs.ranking == nil {
+		return
+	}
+	matrix, faulty := cs.ranking.CommittedSuspicions()
+	suspicions := make(map[hotstuff.ID]map[hotstuff.ID]int)
+	for complainee, complainants := range matrix {
+		suspicions[complainee] = maps.Clone(complainants)
+	}
+	cs.rankings[block.Hash()] = committedRanking{suspicions: suspicions, faulty: slices.Clone(faulty)}
+	cs.rankingOrder = append(cs.rankingOrder, block.Hash())
+	if len(cs.rankingOrder) > rankingHistoryLength {
+		delete(cs.rankings, cs.rankingOrder[0])
+		cs.rankingOrder = cs.rankingOrder[1:]
+	}
+}
+
+// SuspicionsAt returns the suspicion matrix and the faulty replicas of the ranking state after the block was
+// committed. It returns false if the block is not one of the last rankingHistoryLength committed blocks.
+func (cs *consensusBase) SuspicionsAt(block hotstuff.Hash) (suspicions map[hotstuff.ID]map[hotstuff.ID]int, faulty []hotstuff.ID, ok bool) {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	ranking, ok := cs.rankings[block]
+	return ranking.suspicions, ranking.faulty, ok
+}
+
+var _ modules.CommittedRanking = (*consensusBase)(nil)
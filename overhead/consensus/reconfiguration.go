@@ -0,0 +1,275 @@
+package consensus
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"slices"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+	"optitree/opt"
+)
+
+func init() {
+	modules.RegisterModule("reconfiguration", func() *suspicionReconfiguration { return &suspicionReconfiguration{} })
+}
+
+// suspicionReconfiguration is a module that makes the leader propose reconfigurations
+// that remove the replicas suspected by the ranking module.
+type suspicionReconfiguration struct{}
+
+// InitModule enables the ShouldReconfigureOnSuspicion option.
+func (*suspicionReconfiguration) InitModule(mods *modules.Core) {
+	var opts *modules.Options
+	mods.Get(&opts)
+	opts.SetShouldReconfigureOnSuspicion()
+}
+
+// reconfigurationDelay is the number of chain lengths between the view of a committed
+// reconfiguration block and the view in which the new configuration takes effect.
+// This gives the other replicas time to commit the block before the leader schedule changes.
+const reconfigurationDelay = 2
+
+// reconfigurationQuorum returns the quorum size for m active replicas out of a configuration of n replicas.
+// Since up to f = NumFaulty(n) of the active replicas may be faulty, two quorums must intersect in more than f replicas.
+// This must also hold for a quorum of the new active replicas and a quorum of the configuration or of an earlier
+// reconfiguration, which may be any replicas of the configuration, so two quorums of size q must satisfy 2q - n > f.
+func reconfigurationQuorum(n, m int) int {
+	f := hotstuff.NumFaulty(n)
+	return max(hotstuff.QuorumSize(m), (n+f)/2+1)
+}
+
+// proposed tells the acceptor that a proposal succeeded. Reconfiguration commands are not client commands,
+// so the acceptor does not know them.
+func (cs *consensusBase) proposed(cmd hotstuff.Command) {
+	if !cmd.IsReconfiguration() {
+		cs.acceptor.Proposed(cmd)
+	}
+}
+
+// onReconfigurationRequest stores the requested reconfiguration to be proposed when this replica is the leader.
+func (cs *consensusBase) onReconfigurationRequest(request hotstuff.ReconfigurationRequest) {
+	cmd, err := cs.newReconfiguration(request.ActiveReplicas, request.Certificate)
+	if err != nil {
+		cs.logger.Warnf("ReconfigurationRequest: %v", err)
+		return
+	}
+	cs.pendingReconfiguration = cmd
+}
+
+// newReconfiguration returns a reconfiguration command for the given active replicas.
+// The certificate is empty unless the operator requested the reconfiguration.
+func (cs *consensusBase) newReconfiguration(activeReplicas []hotstuff.ID, certificate hotstuff.OperatorCertificate) (hotstuff.Command, error) {
+	ids := slices.Clone(activeReplicas)
+	slices.Sort(ids)
+	cmd := hotstuff.NewCertifiedReconfigurationCommand(ids, reconfigurationQuorum(cs.configuration.Len(), len(ids)), certificate)
+	reconfiguration, _ := hotstuff.ReconfigurationFromCommand(cmd)
+	if err := cs.verifyReconfiguration(reconfiguration); err != nil {
+		return "", err
+	}
+	return cmd, nil
+}
+
+// verifyReconfiguration checks that the reconfiguration keeps at least n-f replicas of the configuration active,
+// and that its quorum size is the one given by reconfigurationQuorum.
+func (cs *consensusBase) verifyReconfiguration(reconfiguration hotstuff.ReconfigurationMsg) error {
+	n := cs.configuration.Len()
+	ids := reconfiguration.ActiveReplicas
+	if len(ids) < n-hotstuff.NumFaulty(n) {
+		return fmt.Errorf("%d active replicas is less than n-f = %d", len(ids), n-hotstuff.NumFaulty(n))
+	}
+	for i, id := range ids {
+		if _, ok := cs.configuration.Replica(id); !ok {
+			return fmt.Errorf("unknown replica %d", id)
+		}
+		if i > 0 && ids[i-1] >= id {
+			return fmt.Errorf("active replicas must be sorted and unique: %v", ids)
+		}
+	}
+	if want := reconfigurationQuorum(n, len(ids)); reconfiguration.QuorumSize != want {
+		return fmt.Errorf("quorum size %d, want %d", reconfiguration.QuorumSize, want)
+	}
+	return nil
+}
+
+// verifyRemovals checks that the replicas that the reconfiguration removes from the configuration are suspected in
+// the committed ranking state at the parent of the proposed block. A reconfiguration with a valid operator certificate
+// may remove any replicas.
+func (cs *consensusBase) verifyRemovals(reconfiguration hotstuff.ReconfigurationMsg, parent *hotstuff.Block) error {
+	if reconfiguration.Certificate.Signature != nil {
+		return cs.verifyCertificate(reconfiguration)
+	}
+	ids := cs.allReplicas()
+	var suspected []hotstuff.ID
+	if ranking, ok := cs.anchoredRanking(parent); ok {
+		suspected = suspectedReplicas(ids, ranking)
+	}
+	for _, id := range ids {
+		if !slices.Contains(reconfiguration.ActiveReplicas, id) && !slices.Contains(suspected, id) {
+			return fmt.Errorf("replica %d is removed, but not suspected", id)
+		}
+	}
+	return nil
+}
+
+// verifyCertificate checks that the operator signed the reconfiguration,
+// and that the operator requested it after the last committed reconfiguration that the operator requested.
+func (cs *consensusBase) verifyCertificate(reconfiguration hotstuff.ReconfigurationMsg) error {
+	key := cs.opts.OperatorKey()
+	if key == nil {
+		return fmt.Errorf("no operator key to verify the certificate")
+	}
+	certificate := reconfiguration.Certificate
+	cs.mut.Lock()
+	serial := cs.operatorSerial
+	cs.mut.Unlock()
+	if certificate.Serial <= serial {
+		return fmt.Errorf("certificate %d is not newer than the committed certificate %d", certificate.Serial, serial)
+	}
+	hash := hotstuff.OperatorHash(reconfiguration.ActiveReplicas, certificate.Serial)
+	if !ecdsa.VerifyASN1(key, hash[:], certificate.Signature) {
+		return fmt.Errorf("invalid operator certificate")
+	}
+	return nil
+}
+
+// anchoredRanking returns the committed ranking state that decides which replicas a reconfiguration proposed on top
+// of the parent block may remove. It is the state after the newest ancestor of the parent that is at least ChainLength
+// views older than the parent, such that the replicas that have committed that far use the same state.
+func (cs *consensusBase) anchoredRanking(parent *hotstuff.Block) (committedRanking, bool) {
+	if cs.ranking == nil || parent == nil {
+		return committedRanking{}, false
+	}
+	chainLength := hotstuff.View(cs.impl.ChainLength())
+	block, ok := parent, true
+	for ok && block.View() > 0 && block.View()+chainLength > parent.View() {
+		block, ok = cs.blockChain.Get(block.Parent())
+	}
+	if !ok {
+		return committedRanking{}, false
+	}
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	ranking, ok := cs.rankings[block.Hash()]
+	return ranking, ok
+}
+
+// nextReconfiguration returns the reconfiguration command to propose on top of the parent block, if any.
+// Only one reconfiguration can be in progress at a time.
+func (cs *consensusBase) nextReconfiguration(parent *hotstuff.Block) (hotstuff.Command, bool) {
+	if cs.CommittedBlock().View() < cs.reconfigurationView {
+		return "", false
+	}
+	cmd := cs.pendingReconfiguration
+	if cmd == "" && cs.opts.ShouldReconfigureOnSuspicion() {
+		ranking, ok := cs.anchoredRanking(parent)
+		if !ok {
+			return "", false
+		}
+		active := unsuspectedReplicas(cs.allReplicas(), ranking)
+		if slices.Equal(active, cs.committedActiveReplicas()) {
+			return "", false
+		}
+		var err error
+		if cmd, err = cs.newReconfiguration(active, hotstuff.OperatorCertificate{}); err != nil {
+			cs.logger.Warnf("Propose: failed to reconfigure: %v", err)
+			return "", false
+		}
+	}
+	if cmd == "" {
+		return "", false
+	}
+	cs.reconfigurationView = cs.synchronizer.View()
+	return cmd, true
+}
+
+// commitReconfiguration schedules the committed reconfiguration to take effect reconfigurationDelay chain lengths
+// after the block. The proposer also forwards it to all replicas, so that passive replicas learn that they are activated.
+// The caller must hold cs.mut.
+func (cs *consensusBase) commitReconfiguration(block *hotstuff.Block, reconfiguration hotstuff.ReconfigurationMsg) {
+	reconfiguration.View = block.View() + hotstuff.View(reconfigurationDelay*cs.impl.ChainLength())
+	reconfiguration.QuorumCertificate = cs.synchronizer.HighQC()
+	cs.activeReplicas = reconfiguration.ActiveReplicas
+	cs.operatorSerial = max(cs.operatorSerial, reconfiguration.Certificate.Serial)
+	if pending, ok := hotstuff.ReconfigurationFromCommand(cs.pendingReconfiguration); ok &&
+		slices.Equal(pending.ActiveReplicas, reconfiguration.ActiveReplicas) {
+		cs.pendingReconfiguration = ""
+	}
+	cs.logger.Infof("committed reconfiguration to %v, effective from view %d", reconfiguration.ActiveReplicas, reconfiguration.View)
+	cs.eventLoop.AddEvent(reconfiguration)
+	if block.Proposer() == cs.opts.ID() {
+		cs.configuration.Reconfiguration(reconfiguration)
+	}
+}
+
+// committedActiveReplicas returns the active replicas as of the last committed reconfiguration.
+func (cs *consensusBase) committedActiveReplicas() []hotstuff.ID {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	if cs.activeReplicas != nil {
+		return cs.activeReplicas
+	}
+	return cs.allReplicas()
+}
+
+func (cs *consensusBase) allReplicas() []hotstuff.ID {
+	ids := make([]hotstuff.ID, 0, cs.configuration.Len())
+	for id := range cs.configuration.Replicas() {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// unsuspectedReplicas returns the replicas except at most f of the suspected replicas, in the order given by
+// suspectedReplicas.
+func unsuspectedReplicas(ids []hotstuff.ID, ranking committedRanking) []hotstuff.ID {
+	removed := suspectedReplicas(ids, ranking)
+	if f := hotstuff.NumFaulty(len(ids)); len(removed) > f {
+		removed = removed[:f]
+	}
+	return slices.DeleteFunc(slices.Clone(ids), func(id hotstuff.ID) bool {
+		return slices.Contains(removed, id)
+	})
+}
+
+// suspectedReplicas returns the replicas that a reconfiguration may remove according to the committed ranking state.
+// Replicas with committed proofs of misbehavior come first, followed by the replicas that are excluded by the
+// suspicion graph, in decreasing order of suspicions.
+func suspectedReplicas(ids []hotstuff.ID, ranking committedRanking) []hotstuff.ID {
+	index := make(map[hotstuff.ID]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	suspicions := opt.NewSuspicions(len(ids))
+	counts := make(map[hotstuff.ID]int)
+	for a, row := range ranking.suspicions {
+		for b, count := range row {
+			i, okA := index[a]
+			j, okB := index[b]
+			if okA && okB && i != j && count > 0 {
+				suspicions.Suspect(i, j)
+				counts[a] += count
+				counts[b] += count
+			}
+		}
+	}
+	candidates := suspicions.Candidates()
+	suspected := make([]hotstuff.ID, 0)
+	for i, id := range ids {
+		if !slices.Contains(candidates, i) {
+			suspected = append(suspected, id)
+		}
+	}
+	slices.SortStableFunc(suspected, func(a, b hotstuff.ID) int {
+		return counts[b] - counts[a]
+	})
+
+	removed := make([]hotstuff.ID, 0, len(suspected))
+	for _, id := range append(slices.Clone(ranking.faulty), suspected...) {
+		if _, ok := index[id]; ok && !slices.Contains(removed, id) {
+			removed = append(removed, id)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,185 @@
+package consensus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestReconfigurationCommand(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 4, 7}
+	cmd := hotstuff.NewReconfigurationCommand(ids, 3)
+	if !cmd.IsReconfiguration() {
+		t.Fatal("expected a reconfiguration command")
+	}
+	got, ok := hotstuff.ReconfigurationFromCommand(cmd)
+	if !ok {
+		t.Fatal("failed to decode reconfiguration command")
+	}
+	if got.QuorumSize != 3 || !slices.Equal(got.ActiveReplicas, ids) {
+		t.Errorf("got quorum %d and replicas %v, want 3 and %v", got.QuorumSize, got.ActiveReplicas, ids)
+	}
+	if hotstuff.Command("client commands").IsReconfiguration() {
+		t.Error("client command detected as reconfiguration")
+	}
+}
+
+// TestReconfigurationQuorum checks that any two quorums of the active replicas intersect
+// in at least one correct replica when up to f of the n replicas are faulty, also with the quorums of
+// the configuration and of other reconfigurations, and that a quorum of correct replicas remains
+// when the removed replicas are among the faulty ones.
+func TestReconfigurationQuorum(t *testing.T) {
+	for n := 4; n <= 31; n++ {
+		f := hotstuff.NumFaulty(n)
+		for m := n - f; m <= n; m++ {
+			q := reconfigurationQuorum(n, m)
+			if q > m-(f-(n-m)) {
+				t.Errorf("n=%d, m=%d: quorum %d is not live with %d faults", n, m, q, f-(n-m))
+			}
+			if 2*q-m <= f {
+				t.Errorf("n=%d, m=%d: quorums of size %d intersect in only %d replicas", n, m, q, 2*q-m)
+			}
+			if overlap := q + hotstuff.QuorumSize(n) - n; overlap <= f {
+				t.Errorf("n=%d, m=%d: quorum %d intersects a quorum of the configuration in only %d replicas", n, m, q, overlap)
+			}
+			for k := n - f; k <= n; k++ {
+				if overlap := q + reconfigurationQuorum(n, k) - n; overlap <= f {
+					t.Errorf("n=%d, m=%d, k=%d: quorums of the reconfigurations intersect in only %d replicas", n, m, k, overlap)
+				}
+			}
+		}
+	}
+}
+
+// rulesStub is a Rules implementation with a chain length of 3.
+type rulesStub struct{ Rules }
+
+func (rulesStub) ChainLength() int { return 3 }
+
+// configurationStub is a configuration of the replicas 1 to 4.
+type configurationStub struct{ modules.Configuration }
+
+func (configurationStub) Len() int { return 4 }
+
+func (configurationStub) Replicas() map[hotstuff.ID]modules.Replica {
+	return map[hotstuff.ID]modules.Replica{1: nil, 2: nil, 3: nil, 4: nil}
+}
+
+// blockChainStub is a block chain that holds the given blocks.
+type blockChainStub struct {
+	modules.BlockChain
+	blocks map[hotstuff.Hash]*hotstuff.Block
+}
+
+func (bc blockChainStub) Get(hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	block, ok := bc.blocks[hash]
+	return block, ok
+}
+
+// rankingStub is a ranking module. Its current state is not used by the verification.
+type rankingStub struct{ modules.Ranking }
+
+func TestVerifyRemovals(t *testing.T) {
+	// a chain of blocks in the views 1 to 6
+	chain := blockChainStub{blocks: map[hotstuff.Hash]*hotstuff.Block{hotstuff.GetGenesis().Hash(): hotstuff.GetGenesis()}}
+	blocks := []*hotstuff.Block{hotstuff.GetGenesis()}
+	for view := hotstuff.View(1); view <= 6; view++ {
+		parent := blocks[len(blocks)-1]
+		block := hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash(), nil), "", view, 1, time.Time{})
+		chain.blocks[block.Hash()] = block
+		blocks = append(blocks, block)
+	}
+	cs := &consensusBase{
+		impl:          rulesStub{},
+		configuration: configurationStub{},
+		blockChain:    chain,
+		ranking:       rankingStub{},
+		rankings: map[hotstuff.Hash]committedRanking{
+			// replica 2 is suspected by the others in the committed state after the block in view 3
+			blocks[3].Hash(): {suspicions: map[hotstuff.ID]map[hotstuff.ID]int{2: {1: 1, 3: 1, 4: 1}}},
+		},
+	}
+	removeTwo := hotstuff.ReconfigurationMsg{ActiveReplicas: []hotstuff.ID{1, 3, 4}}
+	removeFour := hotstuff.ReconfigurationMsg{ActiveReplicas: []hotstuff.ID{1, 2, 3}}
+
+	// a proposal in view 7 is anchored at the block in view 3
+	if err := cs.verifyRemovals(removeTwo, blocks[6]); err != nil {
+		t.Errorf("expected the suspected replica 2 to be removed: %v", err)
+	}
+	if err := cs.verifyRemovals(removeFour, blocks[6]); err == nil {
+		t.Error("expected the removal of the unsuspected replica 4 to be rejected")
+	}
+	if err := cs.verifyRemovals(hotstuff.ReconfigurationMsg{ActiveReplicas: []hotstuff.ID{1, 2, 3, 4}}, blocks[6]); err != nil {
+		t.Errorf("expected a reconfiguration without removals to be accepted: %v", err)
+	}
+	// a proposal in view 6 is anchored at the block in view 2, whose committed state is not known
+	if err := cs.verifyRemovals(removeTwo, blocks[5]); err == nil {
+		t.Error("expected the removal to be rejected without the committed state")
+	}
+	// a pending request from the operator is not enough to remove a replica
+	cs.pendingReconfiguration = hotstuff.NewReconfigurationCommand(removeFour.ActiveReplicas, 3)
+	if err := cs.verifyRemovals(removeFour, blocks[6]); err == nil {
+		t.Error("expected the requested removal of replica 4 to be rejected without a certificate")
+	}
+}
+
+func TestVerifyOperatorCertificate(t *testing.T) {
+	operatorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certify := func(key *ecdsa.PrivateKey, ids []hotstuff.ID, serial uint64) hotstuff.ReconfigurationMsg {
+		hash := hotstuff.OperatorHash(ids, serial)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the certificate must survive the encoding of the command
+		cmd := hotstuff.NewCertifiedReconfigurationCommand(ids, 3, hotstuff.OperatorCertificate{Serial: serial, Signature: sig})
+		reconfiguration, ok := hotstuff.ReconfigurationFromCommand(cmd)
+		if !ok {
+			t.Fatal("failed to decode reconfiguration command")
+		}
+		return reconfiguration
+	}
+	opts := &modules.Options{}
+	opts.SetOperatorKey(&operatorKey.PublicKey)
+	cs := &consensusBase{impl: rulesStub{}, configuration: configurationStub{}, opts: opts}
+	removeFour := []hotstuff.ID{1, 2, 3}
+
+	// the operator may remove an unsuspected replica, even without the committed state
+	if err := cs.verifyRemovals(certify(operatorKey, removeFour, 1), nil); err != nil {
+		t.Errorf("expected the certified removal of replica 4 to be accepted: %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.verifyRemovals(certify(otherKey, removeFour, 1), nil); err == nil {
+		t.Error("expected a certificate that is not signed by the operator to be rejected")
+	}
+	forged := certify(operatorKey, removeFour, 1)
+	forged.ActiveReplicas = []hotstuff.ID{1, 2, 4}
+	if err := cs.verifyRemovals(forged, nil); err == nil {
+		t.Error("expected a certificate of other active replicas to be rejected")
+	}
+	// a certificate that is not newer than a committed one cannot be replayed
+	cs.operatorSerial = 1
+	if err := cs.verifyRemovals(certify(operatorKey, removeFour, 1), nil); err == nil {
+		t.Error("expected a replayed certificate to be rejected")
+	}
+	if err := cs.verifyRemovals(certify(operatorKey, removeFour, 2), nil); err != nil {
+		t.Errorf("expected a newer certificate to be accepted: %v", err)
+	}
+	// replicas without the operator key do not accept certificates
+	cs.opts = &modules.Options{}
+	if err := cs.verifyRemovals(certify(operatorKey, removeFour, 2), nil); err == nil {
+		t.Error("expected the certificate to be rejected without the operator key")
+	}
+}
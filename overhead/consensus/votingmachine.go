@@ -133,5 +133,12 @@ func (vm *VotingMachine) verifyCert(cert hotstuff.PartialCert, block *hotstuff.B
 	}
 	delete(vm.verifiedVotes, cert.BlockHash())
 
+	vm.eventLoop.AddEvent(hotstuff.PhaseEvent{
+		View:  block.View(),
+		Phase: hotstuff.PhaseQC,
+		Start: block.Time(),
+		End:   vm.eventLoop.Now(),
+	})
+
 	vm.eventLoop.AddEvent(hotstuff.NewViewMsg{ID: vm.opts.ID(), SyncInfo: hotstuff.NewSyncInfo().WithQC(qc)})
 }
@@ -5,12 +5,14 @@ import (
 	"time"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/modules"
 )
 
 type crypto struct {
 	blockChain    modules.BlockChain
 	configuration modules.Configuration
+	eventLoop     *eventloop.EventLoop
 	opts          *modules.Options
 	modules.CryptoBase
 }
@@ -29,6 +31,7 @@ func (c *crypto) InitModule(mods *modules.Core) {
 		&c.configuration,
 		&c.opts,
 	)
+	mods.TryGet(&c.eventLoop)
 
 	if mod, ok := c.CryptoBase.(modules.Module); ok {
 		mod.InitModule(mods)
@@ -54,13 +57,21 @@ func (c crypto) prepareLatencyVector(block *hotstuff.Block, signatures []hotstuf
 	return latencyVector
 }
 
+// now returns the time of the event loop's clock, which the latency vectors are measured with.
+func (c crypto) now() time.Time {
+	if c.eventLoop == nil {
+		return time.Now()
+	}
+	return c.eventLoop.Now()
+}
+
 // CreatePartialCert signs a single block and returns the partial certificate.
 func (c crypto) CreatePartialCert(block *hotstuff.Block) (cert hotstuff.PartialCert, err error) {
 	sig, err := c.Sign(block.ToBytes())
 	if err != nil {
 		return hotstuff.PartialCert{}, err
 	}
-	return hotstuff.NewPartialCert(sig, block.Hash(), time.Now()), nil
+	return hotstuff.NewPartialCert(sig, block.Hash(), c.now()), nil
 }
 
 // CreateQuorumCert creates a quorum certificate from a list of partial certificates.
@@ -133,7 +144,7 @@ func (c crypto) VerifyQuorumCert(qc hotstuff.QuorumCert) bool {
 	if qc.BlockHash() == hotstuff.GetGenesis().Hash() {
 		return true
 	}
-	if qc.Signature().Participants().Len() < c.configuration.QuorumSize(qc.View()) {
+	if qc.Signature() == nil || qc.Signature().Participants().Len() < c.configuration.QuorumSize(qc.View()) {
 		return false
 	}
 	block, ok := c.blockChain.Get(qc.BlockHash())
@@ -158,10 +169,11 @@ func (c crypto) VerifyTimeoutCert(tc hotstuff.TimeoutCert) bool {
 // VerifyAggregateQC verifies the AggregateQC and returns the highQC, if valid.
 func (c crypto) VerifyAggregateQC(aggQC hotstuff.AggregateQC) (highQC hotstuff.QuorumCert, ok bool) {
 	messages := make(map[hotstuff.ID][]byte)
+	found := false
 	for id, qc := range aggQC.QCs() {
-		// /|| highQC == (hotstuff.QuorumCert{}
-		if highQC.View() < qc.View() {
-			highQC = qc
+		// the QCs may all be for the genesis block in view 0
+		if !found || highQC.View() < qc.View() {
+			highQC, found = qc, true
 		}
 		// reconstruct the TimeoutMsg to get the hash
 		messages[id] = hotstuff.TimeoutMsg{
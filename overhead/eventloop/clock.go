@@ -0,0 +1,44 @@
+package eventloop
+
+import "time"
+
+// Clock is the source of time of an event loop and its tickers.
+// The event loop uses the system clock by default,
+// but a simulation can replace it with a virtual clock so that the modules observe simulated time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc calls f after the duration d, unless the returned stop function is called first.
+	// The stop function returns false if f has already been called or the timer was already stopped.
+	AfterFunc(d time.Duration, f func()) (stop func() bool)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// SetClock replaces the clock of the event loop.
+// It must be called before the event loop is started, and before any tickers are added.
+func (el *EventLoop) SetClock(clock Clock) {
+	el.mut.Lock()
+	defer el.mut.Unlock()
+	el.clock = clock
+}
+
+// Now returns the current time according to the clock of the event loop.
+func (el *EventLoop) Now() time.Time {
+	return el.clock.Now()
+}
+
+// AfterFunc calls f after the duration d according to the clock of the event loop.
+// With the system clock, f is called in its own goroutine, so it should usually just add an event to the event loop.
+// The returned function stops the timer.
+func (el *EventLoop) AfterFunc(d time.Duration, f func()) (stop func() bool) {
+	return el.clock.AfterFunc(d, f)
+}
@@ -63,6 +63,8 @@ type EventLoop struct {
 
 	tickers  map[int]*ticker
 	tickerID int
+
+	clock Clock
 }
 
 // New returns a new event loop with the requested buffer size.
@@ -73,6 +75,7 @@ func New(bufferSize uint) *EventLoop {
 		waitingEvents: make(map[reflect.Type][]any),
 		handlers:      make(map[reflect.Type][]handler),
 		tickers:       make(map[int]*ticker),
+		clock:         systemClock{},
 	}
 	return el
 }
@@ -344,33 +347,32 @@ func (el *EventLoop) RemoveTicker(id int) bool {
 func (el *EventLoop) startTicker(id int) {
 	// lock the mutex such that the ticker cannot be removed until we have started it
 	el.mut.Lock()
-	defer el.mut.Unlock()
 	ticker, ok := el.tickers[id]
 	if !ok {
+		el.mut.Unlock()
 		return
 	}
 	ctx := el.ctx
 	ctx, ticker.cancel = context.WithCancel(ctx)
-	go el.runTicker(ctx, ticker)
-}
-
-func (el *EventLoop) runTicker(ctx context.Context, ticker *ticker) {
-	t := time.NewTicker(ticker.interval)
-	defer t.Stop()
+	el.mut.Unlock()
 
 	if ctx.Err() != nil {
 		return
 	}
-
 	// send the first event immediately
-	el.AddEvent(ticker.callback(time.Now()))
+	now := el.clock.Now()
+	el.AddEvent(ticker.callback(now))
+	el.scheduleTick(ctx, ticker, now.Add(ticker.interval))
+}
 
-	for {
-		select {
-		case tick := <-t.C:
-			el.AddEvent(ticker.callback(tick))
-		case <-ctx.Done():
+// scheduleTick sends the next event of the ticker at the given time, and then schedules the following one.
+// The ticks are scheduled at fixed intervals from the first tick, so that they do not drift.
+func (el *EventLoop) scheduleTick(ctx context.Context, ticker *ticker, next time.Time) {
+	el.clock.AfterFunc(next.Sub(el.clock.Now()), func() {
+		if ctx.Err() != nil {
 			return
 		}
-	}
+		el.AddEvent(ticker.callback(next))
+		el.scheduleTick(ctx, ticker, next.Add(ticker.interval))
+	})
 }
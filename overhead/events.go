@@ -3,6 +3,7 @@ package hotstuff
 import (
 	"bytes"
 	"fmt"
+	"time"
 )
 
 // ProposeMsg is broadcast when a leader makes a proposal.
@@ -10,7 +11,6 @@ type ProposeMsg struct {
 	ID          ID           // The ID of the replica who sent the message.
 	Block       *Block       // The block that is proposed.
 	AggregateQC *AggregateQC // Optional AggregateQC
-	Complaints  []*Complaint
 }
 
 func (p ProposeMsg) String() string {
@@ -59,6 +59,13 @@ type NewViewMsg struct {
 	SyncInfo SyncInfo // The highest QC / TC.
 }
 
+// CheckpointMsg is broadcast by a replica when it takes a checkpoint, and carries its share of the checkpoint certificate.
+type CheckpointMsg struct {
+	ID         ID              // The ID of the replica who sent the message.
+	Checkpoint Checkpoint      // The checkpoint.
+	Signature  QuorumSignature // The replica's signature of the checkpoint.
+}
+
 // CommitEvent is raised whenever a block is committed,
 // and includes the number of client commands that were executed.
 type CommitEvent struct {
@@ -78,3 +85,37 @@ type CheckLatencyVector struct {
 	LatencyVector []uint32
 	Proposer      ID
 }
+
+// ReconfigurationRequest asks the replica to propose a reconfiguration
+// to the given active replicas the next time it is the leader.
+type ReconfigurationRequest struct {
+	ActiveReplicas []ID
+	Certificate    OperatorCertificate // set if the operator requested the reconfiguration
+}
+
+// Phase identifies a phase of a view in latency measurements.
+type Phase string
+
+// The phases of a view.
+const (
+	PhaseDissemination Phase = "dissemination" // the proposal was sent to the replica by the leader or its parent
+	PhaseAggregation   Phase = "aggregation"   // the replica collected the votes of its subtree and sent them to its parent
+	PhaseQC            Phase = "qc"            // the leader formed a quorum certificate for its proposal
+	PhaseCommit        Phase = "commit"        // the block was committed
+)
+
+// PhaseEvent is raised when a replica completes a phase of a view.
+type PhaseEvent struct {
+	View      View
+	Phase     Phase
+	Start     time.Time
+	End       time.Time
+	Predicted time.Duration // the predicted duration of the phase, if known
+}
+
+// TreeChangeEvent is raised when a replica changes the tree that is used to disseminate proposals and aggregate votes.
+type TreeChangeEvent struct {
+	View      View
+	Tree      []ID          // the replica IDs in tree position order, with the root first
+	Predicted time.Duration // the QC latency of the tree predicted by the tree optimizer, if known
+}
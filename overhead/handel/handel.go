@@ -9,6 +9,11 @@
 //
 //	./hotstuff run --modules="handel"
 //
+// The OptiLog-aware partitioning, which places nearby, unsuspected replicas in the lower levels of each other,
+// is enabled by loading the `optihandel` module instead, together with a ranking module:
+//
+//	./hotstuff run --modules="optihandel,complaintcache"
+//
 // Initialization.
 //
 // Handel requires an extra initialization step, because it needs to access the `Configuration`
@@ -50,16 +55,19 @@ type Handel struct {
 	server        *backend.Server
 
 	blockChain   modules.BlockChain
+	consensus    modules.Consensus
 	crypto       modules.Crypto
 	eventLoop    *eventloop.EventLoop
 	logger       logging.Logger
 	opts         *modules.Options
+	ranking      modules.Ranking
 	synchronizer modules.Synchronizer
 
 	nodes    map[hotstuff.ID]*handelpb.Node
 	maxLevel int
 	sessions map[hotstuff.Hash]*session
 	initDone bool
+	optiLog  bool // partition the replicas using the ranking state; see NewOptiLog
 }
 
 // New returns a new instance of the Handel module.
@@ -83,6 +91,10 @@ func (h *Handel) InitModule(mods *modules.Core) {
 		&h.opts,
 		&h.synchronizer,
 	)
+	if h.optiLog {
+		mods.Get(&h.consensus)
+		mods.TryGet(&h.ranking)
+	}
 
 	h.opts.SetShouldUseHandel()
 
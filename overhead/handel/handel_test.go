@@ -1,6 +1,7 @@
 package handel
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/relab/hotstuff"
@@ -27,3 +28,53 @@ func TestRangeLevel(t *testing.T) {
 		t.Errorf("expected (min, max) to be (4, 4), but was (%d, %d)", min, max)
 	}
 }
+
+func TestOptiLogOrder(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	// replicas 1, 3, 5 and 7 are close to each other, and so are replicas 2, 4, 6 and 8.
+	latencies := make(latencyMatrix)
+	for _, a := range ids {
+		latencies[a] = make(map[hotstuff.ID]uint32)
+		for _, b := range ids {
+			if a%2 == b%2 {
+				latencies[a][b] = 1000 + uint32(a+b)
+			} else {
+				latencies[a][b] = 50000
+			}
+		}
+	}
+	state := optiLogState{latencies: latencies, suspected: map[hotstuff.ID]bool{3: true}}
+
+	order := state.order(ids)
+	want := []hotstuff.ID{1, 5, 7, 2, 4, 6, 8, 3}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+
+	// the first levels of replica 1 contain the close, unsuspected replicas,
+	// and the suspected replica is only found in the last level.
+	part := newPartitioner(1, order)
+	if got := part.partition(1); !reflect.DeepEqual(got, []hotstuff.ID{5}) {
+		t.Errorf("expected level 1 to be [5], got %v", got)
+	}
+	if got := part.partition(2); !reflect.DeepEqual(got, []hotstuff.ID{7, 2}) {
+		t.Errorf("expected level 2 to be [7 2], got %v", got)
+	}
+	if got := part.partition(3); !reflect.DeepEqual(got, []hotstuff.ID{4, 6, 8, 3}) {
+		t.Errorf("expected level 3 to be [4 6 8 3], got %v", got)
+	}
+
+	cp := state.contributionPriority(order, 1)
+	wantCP := map[hotstuff.ID]int{5: 0, 7: 1, 2: 2, 4: 3, 6: 4, 8: 5, 3: 6}
+	if !reflect.DeepEqual(cp, wantCP) {
+		t.Errorf("expected contribution priority %v, got %v", wantCP, cp)
+	}
+}
+
+func TestOptiLogOrderWithoutLatencies(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4}
+	state := optiLogState{latencies: make(latencyMatrix), suspected: map[hotstuff.ID]bool{1: true}}
+	if order := state.order(ids); !reflect.DeepEqual(order, []hotstuff.ID{2, 3, 4, 1}) {
+		t.Errorf("expected the sorted order with the suspected replica last, got %v", order)
+	}
+}
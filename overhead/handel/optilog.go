@@ -0,0 +1,206 @@
+package handel
+
+import (
+	"slices"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+	"optitree/opt"
+)
+
+func init() {
+	modules.RegisterModule("optihandel", NewOptiLog)
+}
+
+// latencyWindow is the number of committed blocks whose latency vectors are used to partition the replicas.
+const latencyWindow = 100
+
+// NewOptiLog returns a new instance of the Handel module that partitions the replicas using the committed latency
+// vectors and the suspicions of the ranking module, instead of a random permutation of the replicas.
+//
+// The replicas are ordered such that the unsuspected replicas come first, each followed by the closest unsuspected
+// replica that is not yet placed, and the suspected replicas come last. Since the levels of each replica are built from
+// its neighbors in this order, nearby trusted replicas end up in the lower levels of each other, and suspected
+// replicas are only found in the highest levels of the trusted replicas. Within a level, contributions are sent to
+// the closest unsuspected replicas first.
+//
+// The latencies are taken from the latency vectors of the quorum certificates of the last latencyWindow blocks
+// before the committed ancestor of the block being voted on, or from the configuration if they are not found there,
+// and the suspicions from the complaints committed by the ranking module, such that all replicas compute the same
+// partitioning for a block.
+func NewOptiLog() modules.Handel {
+	h := New().(*Handel)
+	h.optiLog = true
+	return h
+}
+
+// latencyMatrix holds one-way latencies in microseconds, indexed [from][to].
+type latencyMatrix map[hotstuff.ID]map[hotstuff.ID]uint32
+
+// lookup returns the latency between a and b, or the latency between b and a if the former is unknown.
+func (m latencyMatrix) lookup(a, b hotstuff.ID) (uint32, bool) {
+	if l, ok := m[a][b]; ok {
+		return l, true
+	}
+	l, ok := m[b][a]
+	return l, ok
+}
+
+// distance returns the latency between a and b, and unknown latencies are longer than any known latency.
+func (m latencyMatrix) distance(a, b hotstuff.ID) uint64 {
+	if l, ok := m.lookup(a, b); ok {
+		return uint64(l)
+	}
+	return 1 << 32
+}
+
+// optiLogState is the committed ranking state that an OptiLog session is partitioned with.
+type optiLogState struct {
+	latencies latencyMatrix
+	suspected map[hotstuff.ID]bool
+}
+
+// optiLogState returns the committed ranking state of the replicas for a session of the block with the given hash.
+// The quorum certificates that are aggregated by Handel have no latency vectors, so the latencies that are not found
+// in the committed blocks are taken from the expected latencies of the configuration.
+func (h *Handel) optiLogState(hash hotstuff.Hash, ids []hotstuff.ID) optiLogState {
+	latencies := h.committedLatencies(hash)
+	for _, a := range ids {
+		for _, b := range ids {
+			if _, ok := latencies.lookup(a, b); ok || a == b {
+				continue
+			}
+			if latency := h.configuration.GetLatency(a, b); latency > 0 {
+				if _, exists := latencies[a]; !exists {
+					latencies[a] = make(map[hotstuff.ID]uint32)
+				}
+				latencies[a][b] = uint32(latency.Microseconds())
+			}
+		}
+	}
+	return optiLogState{
+		latencies: latencies,
+		suspected: h.suspected(ids),
+	}
+}
+
+// committedLatencies collects the latency vectors of the quorum certificates of the last latencyWindow blocks,
+// starting ChainLength blocks before the block with the given hash, which is committed once that block is.
+// Newer measurements take precedence over older ones.
+func (h *Handel) committedLatencies(hash hotstuff.Hash) latencyMatrix {
+	latencies := make(latencyMatrix)
+	block, ok := h.blockChain.LocalGet(hash)
+	for i := 0; ok && i < h.consensus.ChainLength(); i++ {
+		block, ok = h.blockChain.LocalGet(block.Parent())
+	}
+	for i := 0; ok && i < latencyWindow && block != hotstuff.GetGenesis(); i++ {
+		qc := block.QuorumCert()
+		if certified, found := h.blockChain.LocalGet(qc.BlockHash()); found {
+			from := certified.Proposer()
+			if _, exists := latencies[from]; !exists {
+				latencies[from] = make(map[hotstuff.ID]uint32)
+			}
+			for _, v := range qc.LatencyVector() {
+				to := hotstuff.ID(v >> 24)
+				if _, exists := latencies[from][to]; !exists {
+					latencies[from][to] = v & 0x00FFFFFF
+				}
+			}
+		}
+		block, ok = h.blockChain.LocalGet(block.Parent())
+	}
+	return latencies
+}
+
+// suspected returns the replicas that are excluded by the suspicion graph of the ranking module.
+func (h *Handel) suspected(ids []hotstuff.ID) map[hotstuff.ID]bool {
+	suspected := make(map[hotstuff.ID]bool)
+	if h.ranking == nil {
+		return suspected
+	}
+	index := make(map[hotstuff.ID]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	suspicions := opt.NewSuspicions(len(ids))
+	for a, row := range h.ranking.GetSuspicionMatrix() {
+		for b, count := range row {
+			i, okA := index[a]
+			j, okB := index[b]
+			if okA && okB && i != j && count > 0 {
+				suspicions.Suspect(i, j)
+			}
+		}
+	}
+	candidates := suspicions.Candidates()
+	for i, id := range ids {
+		if !slices.Contains(candidates, i) {
+			suspected[id] = true
+		}
+	}
+	return suspected
+}
+
+// order returns the sorted IDs in the order that the partitioner builds the levels from.
+// Starting from the lowest unsuspected ID, each replica is followed by the closest replica that is not yet placed,
+// with ties broken by the lowest ID, first among the unsuspected replicas and then among the suspected replicas.
+func (st optiLogState) order(ids []hotstuff.ID) []hotstuff.ID {
+	var trusted, suspected []hotstuff.ID
+	for _, id := range ids {
+		if st.suspected[id] {
+			suspected = append(suspected, id)
+		} else {
+			trusted = append(trusted, id)
+		}
+	}
+	order := make([]hotstuff.ID, 0, len(ids))
+	for _, group := range [][]hotstuff.ID{trusted, suspected} {
+		remaining := slices.Clone(group)
+		for len(remaining) > 0 {
+			next := 0
+			if len(order) > 0 {
+				last := order[len(order)-1]
+				for i, id := range remaining {
+					if st.latencies.distance(last, id) < st.latencies.distance(last, remaining[next]) {
+						next = i
+					}
+				}
+			}
+			order = append(order, remaining[next])
+			remaining = slices.Delete(remaining, next, next+1)
+		}
+	}
+	return order
+}
+
+// contributionPriority returns the contribution priority of the other replicas for the local node,
+// which prefers unsuspected replicas over suspected replicas, and then the closest replicas.
+func (st optiLogState) contributionPriority(ids []hotstuff.ID, self hotstuff.ID) (cp map[hotstuff.ID]int) {
+	others := make([]hotstuff.ID, 0, len(ids)-1)
+	for _, id := range ids {
+		if id != self {
+			others = append(others, id)
+		}
+	}
+	slices.SortStableFunc(others, func(a, b hotstuff.ID) int {
+		if st.suspected[a] != st.suspected[b] {
+			if st.suspected[a] {
+				return 1
+			}
+			return -1
+		}
+		da, db := st.latencies.distance(self, a), st.latencies.distance(self, b)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		}
+		return int(a) - int(b)
+	})
+	cp = make(map[hotstuff.ID]int, len(others))
+	for i, id := range others {
+		cp[id] = i
+	}
+	return cp
+}
@@ -76,6 +76,9 @@ type session struct {
 	seed int64
 	part partitioner
 
+	// optiLog is the ranking state that the session is partitioned with, if the OptiLog mode is enabled.
+	optiLog *optiLogState
+
 	// levels
 	levels           []level
 	activeLevelIndex int
@@ -113,9 +116,16 @@ func (h *Handel) newSession(hash hotstuff.Hash, in hotstuff.QuorumSignature) *se
 	}
 	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 
-	// Shuffle the list of IDs using the shared random seed + the first 8 bytes of the hash.
-	rnd := rand.New(rand.NewSource(s.seed))
-	rnd.Shuffle(len(ids), reflect.Swapper(ids))
+	if h.optiLog {
+		// Order the list of IDs by the committed latencies and suspicions.
+		state := h.optiLogState(hash, ids)
+		s.optiLog = &state
+		ids = state.order(ids)
+	} else {
+		// Shuffle the list of IDs using the shared random seed + the first 8 bytes of the hash.
+		rnd := rand.New(rand.NewSource(s.seed))
+		rnd.Shuffle(len(ids), reflect.Swapper(ids))
+	}
 
 	h.logger.Debugf("Handel session ids: %v", ids)
 
@@ -156,9 +166,13 @@ type level struct {
 }
 
 func (s *session) newLevel(i int) level {
+	cp := contributionPriority(s.part.ids, s.seed, s.h.opts.ID(), i)
+	if s.optiLog != nil {
+		cp = s.optiLog.contributionPriority(s.part.ids, s.h.opts.ID())
+	}
 	return level{
 		vp:         verificationPriority(s.part.ids, s.seed, s.h.opts.ID(), i),
-		cp:         contributionPriority(s.part.ids, s.seed, s.h.opts.ID(), i),
+		cp:         cp,
 		individual: make(map[hotstuff.ID]hotstuff.QuorumSignature),
 	}
 }
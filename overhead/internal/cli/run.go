@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -39,7 +40,7 @@ Then, you must use the '--ssh-config' parameter to specify the location of your
 (or omit it to use ~/.ssh/config). Then, you must specify the list of remote machines to connect to
 using the '--host' parameter. This should be a comma separated list of hostnames or ip addresses.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runController()
+		checkf("failed to run experiment: %v", runController())
 	},
 }
 
@@ -63,6 +64,9 @@ func init() {
 	runCmd.Flags().String("leader-rotation", "round-robin", "name of the leader rotation algorithm")
 	runCmd.Flags().Int64("shared-seed", 0, "Shared random number generator seed")
 	runCmd.Flags().StringSlice("modules", nil, "Name additional modules to be loaded.")
+	runCmd.Flags().IntSlice("tree-pos", nil, "replica IDs in tree position order, used by kauri (chosen by kauri if empty)")
+	runCmd.Flags().String("leaf-placement", "", "algorithm that places the leaves of the kauri tree: greedy, hungarian, balanced or quorum (chosen by the tree optimizer if empty)")
+	runCmd.Flags().Duration("tree-delta", 30*time.Millisecond, "time that kauri waits for the votes of each level of the tree")
 
 	runCmd.Flags().Bool("worker", false, "run a local worker")
 	runCmd.Flags().StringSlice("hosts", nil, "the remote hosts to run the experiment on via ssh")
@@ -77,10 +81,24 @@ func init() {
 
 	runCmd.Flags().StringSlice("metrics", []string{"client-latency", "throughput"}, "list of metrics to enable")
 	runCmd.Flags().Duration("measurement-interval", 0, "time interval between measurements")
+	runCmd.Flags().Bool("dashboard", false, "show a live summary of the measurements during the experiment")
+	runCmd.Flags().Duration("abort-after", 0, "stop the experiment early if no commands were committed for this duration (disabled by default)")
+	runCmd.Flags().String("metrics-addr", "", "address where each worker serves live metrics in OpenMetrics format, e.g. ':9100' (disabled by default)")
 	runCmd.Flags().Float64("rate-limit", math.Inf(1), "rate limit for clients (in commands/second)")
 	runCmd.Flags().Float64("rate-step", 0, "rate limit step up for clients (in commands/second)")
 	runCmd.Flags().Duration("rate-step-interval", time.Hour, "how often the client rate limit should be increased")
+	runCmd.Flags().String("workload", "rate", "client workload: rate, poisson (arrivals at the rate limit), onoff (bursts at the rate limit), trace or kv (key-value operations for the kvstore module)")
+	runCmd.Flags().Duration("burst-on", time.Second, "duration of the bursts of the onoff workload")
+	runCmd.Flags().Duration("burst-off", time.Second, "duration of the pauses between the bursts of the onoff workload")
+	runCmd.Flags().Float64("rate-skew", 0, "skew of the client rate limits: the rate limit of client i is rate-limit / i^rate-skew")
+	runCmd.Flags().Uint32("kv-keys", 1000, "number of keys that the kv workload reads and writes")
+	runCmd.Flags().Float64("kv-reads", 0.5, "fraction of the operations of the kv workload that are reads")
+	runCmd.Flags().String("workload-trace", "", "CSV file of request times and payload sizes to replay with the trace workload")
 	runCmd.Flags().StringSlice("byzantine", nil, "byzantine strategies to use, as a comma separated list of 'name:count'")
+	runCmd.Flags().String("data-dir", "", "directory on the workers where the replicas store their blocks and safety state, such that they can be restarted (in memory by default)")
+	runCmd.Flags().StringSlice("restart", nil, "replicas to crash and restart during the experiment, as a comma separated list of 'id:at:downtime', e.g. '2:5s:2s' (requires --data-dir)")
+	runCmd.Flags().Uint32("checkpoint-interval", 0, "number of committed blocks between certified checkpoints, below which the replicas discard their blocks (disabled if 0)")
+	runCmd.Flags().StringArray("reconfigure", nil, "reconfigurations of the active replicas during the experiment, as 'at:ids', e.g. '5s:1,2,3' (can be repeated)")
 
 	err := viper.BindPFlags(runCmd.Flags())
 	if err != nil {
@@ -88,7 +106,9 @@ func init() {
 	}
 }
 
-func runController() {
+// runController runs an experiment with the settings in viper.
+// It exits if the experiment cannot be set up, and returns the error of the experiment itself, if any.
+func runController() error {
 	var err error
 	outputDir := ""
 	if output := viper.GetString("output"); output != "" {
@@ -104,19 +124,25 @@ func runController() {
 		NumClients:  viper.GetInt("clients"),
 		Duration:    viper.GetDuration("duration"),
 		Output:      outputDir,
+		AbortAfter:  viper.GetDuration("abort-after"),
 		ReplicaOpts: &orchestrationpb.ReplicaOpts{
-			UseTLS:            true,
-			BatchSize:         viper.GetUint32("batch-size"),
-			TimeoutMultiplier: float32(viper.GetFloat64("timeout-multiplier")),
-			Consensus:         viper.GetString("consensus"),
-			Crypto:            viper.GetString("crypto"),
-			LeaderRotation:    viper.GetString("leader-rotation"),
-			ConnectTimeout:    durationpb.New(viper.GetDuration("connect-timeout")),
-			InitialTimeout:    durationpb.New(viper.GetDuration("view-timeout")),
-			TimeoutSamples:    viper.GetUint32("duration-samples"),
-			MaxTimeout:        durationpb.New(viper.GetDuration("max-timeout")),
-			SharedSeed:        viper.GetInt64("shared-seed"),
-			Modules:           viper.GetStringSlice("modules"),
+			UseTLS:             true,
+			BatchSize:          viper.GetUint32("batch-size"),
+			TimeoutMultiplier:  float32(viper.GetFloat64("timeout-multiplier")),
+			Consensus:          viper.GetString("consensus"),
+			Crypto:             viper.GetString("crypto"),
+			LeaderRotation:     viper.GetString("leader-rotation"),
+			ConnectTimeout:     durationpb.New(viper.GetDuration("connect-timeout")),
+			InitialTimeout:     durationpb.New(viper.GetDuration("view-timeout")),
+			TimeoutSamples:     viper.GetUint32("duration-samples"),
+			MaxTimeout:         durationpb.New(viper.GetDuration("max-timeout")),
+			SharedSeed:         viper.GetInt64("shared-seed"),
+			Modules:            viper.GetStringSlice("modules"),
+			DataDir:            viper.GetString("data-dir"),
+			CheckpointInterval: viper.GetUint32("checkpoint-interval"),
+			TreePositions:      treePositions(viper.GetIntSlice("tree-pos")),
+			TreeDelta:          durationpb.New(viper.GetDuration("tree-delta")),
+			LeafPlacement:      viper.GetString("leaf-placement"),
 		},
 		ClientOpts: &orchestrationpb.ClientOpts{
 			UseTLS:           true,
@@ -127,12 +153,38 @@ func runController() {
 			RateStep:         viper.GetFloat64("rate-step"),
 			RateStepInterval: durationpb.New(viper.GetDuration("rate-step-interval")),
 			Timeout:          durationpb.New(viper.GetDuration("client-timeout")),
+			Workload:         viper.GetString("workload"),
+			BurstOn:          durationpb.New(viper.GetDuration("burst-on")),
+			BurstOff:         durationpb.New(viper.GetDuration("burst-off")),
+			RateSkew:         viper.GetFloat64("rate-skew"),
+			KVKeys:           viper.GetUint32("kv-keys"),
+			KVReads:          viper.GetFloat64("kv-reads"),
 		},
 	}
 
+	if trace := viper.GetString("workload-trace"); trace != "" {
+		experiment.ClientOpts.Trace, err = os.ReadFile(trace)
+		checkf("failed to read trace: %v", err)
+	}
+
 	experiment.Byzantine, err = parseByzantine()
 	checkf("%v", err)
 
+	experiment.Restarts, err = parseRestarts()
+	checkf("%v", err)
+
+	experiment.Reconfigurations, err = parseReconfigurations()
+	checkf("%v", err)
+
+	enabledMetrics := viper.GetStringSlice("metrics")
+	interval := viper.GetDuration("measurement-interval")
+	if viper.GetBool("dashboard") || experiment.AbortAfter > 0 {
+		enabledMetrics, interval = liveMetrics(enabledMetrics, interval)
+	}
+	if viper.GetBool("dashboard") {
+		experiment.Dashboard = os.Stdout
+	}
+
 	worker := viper.GetBool("worker")
 	hosts := viper.GetStringSlice("hosts")
 	exePath := viper.GetString("exe")
@@ -152,8 +204,9 @@ func runController() {
 		MemProfiling:        viper.GetBool("mem-profile"),
 		Tracing:             viper.GetBool("trace"),
 		Fgprof:              viper.GetBool("fgprof-profile"),
-		Metrics:             viper.GetStringSlice("metrics"),
-		MeasurementInterval: viper.GetDuration("measurement-interval"),
+		Metrics:             enabledMetrics,
+		MeasurementInterval: interval,
+		MetricsAddr:         viper.GetString("metrics-addr"),
 	})
 	checkf("failed to deploy workers: %v", err)
 
@@ -169,7 +222,7 @@ func runController() {
 	}
 
 	if worker || len(hosts) == 0 {
-		worker, wait := localWorker(outputDir, viper.GetStringSlice("metrics"), viper.GetDuration("measurement-interval"))
+		worker, wait := localWorker(outputDir, enabledMetrics, interval, viper.GetString("metrics-addr"))
 		defer wait()
 		experiment.Hosts["localhost"] = worker
 	}
@@ -196,8 +249,7 @@ func runController() {
 		checkf("invalid configuration for %s: %v", cfg.Name, err)
 	}
 
-	err = experiment.Run()
-	checkf("failed to run experiment: %v", err)
+	runErr := experiment.Run()
 
 	for _, session := range sessions {
 		err := session.Close()
@@ -214,6 +266,16 @@ func runController() {
 
 	err = g.Close()
 	checkf("failed to close ssh connections: %v", err)
+
+	return runErr
+}
+
+func treePositions(ids []int) []uint32 {
+	positions := make([]uint32, len(ids))
+	for i, id := range ids {
+		positions[i] = uint32(id)
+	}
+	return positions
 }
 
 func checkHostLocation(location string) error {
@@ -256,7 +318,69 @@ func parseByzantine() (map[string]int, error) {
 	return strategies, nil
 }
 
-func localWorker(globalOutput string, enableMetrics []string, interval time.Duration) (worker orchestration.RemoteWorker, wait func()) {
+func parseRestarts() ([]orchestration.Restart, error) {
+	var restarts []orchestration.Restart
+	for _, arg := range viper.GetStringSlice("restart") {
+		parts := strings.Split(arg, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("restart must be specified as a comma separated list of 'id:at:downtime'")
+		}
+		id, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("could not read replica id of restart '%s': %w", arg, err)
+		}
+		at, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not read time of restart '%s': %w", arg, err)
+		}
+		downtime, err := time.ParseDuration(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("could not read downtime of restart '%s': %w", arg, err)
+		}
+		restarts = append(restarts, orchestration.Restart{ID: hotstuff.ID(id), At: at, Downtime: downtime})
+	}
+	return restarts, nil
+}
+
+func parseReconfigurations() ([]orchestration.Reconfiguration, error) {
+	var reconfigurations []orchestration.Reconfiguration
+	for _, arg := range viper.GetStringSlice("reconfigure") {
+		at, ids, ok := strings.Cut(arg, ":")
+		if !ok {
+			return nil, fmt.Errorf("reconfiguration must be specified as 'at:ids'")
+		}
+		var reconfiguration orchestration.Reconfiguration
+		var err error
+		reconfiguration.At, err = time.ParseDuration(at)
+		if err != nil {
+			return nil, fmt.Errorf("could not read time of reconfiguration '%s': %w", arg, err)
+		}
+		for _, id := range strings.Split(ids, ",") {
+			id, err := strconv.ParseUint(id, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("could not read replica id of reconfiguration '%s': %w", arg, err)
+			}
+			reconfiguration.ActiveReplicas = append(reconfiguration.ActiveReplicas, hotstuff.ID(id))
+		}
+		reconfigurations = append(reconfigurations, reconfiguration)
+	}
+	return reconfigurations, nil
+}
+
+// liveMetrics adds the metrics that are shown on the dashboard, and ensures that measurements are taken.
+func liveMetrics(enabled []string, interval time.Duration) ([]string, time.Duration) {
+	for _, metric := range []string{"throughput", "client-latency", "timeouts", "tree-changes"} {
+		if !slices.Contains(enabled, metric) {
+			enabled = append(enabled, metric)
+		}
+	}
+	if interval == 0 {
+		interval = time.Second
+	}
+	return enabled, interval
+}
+
+func localWorker(globalOutput string, enableMetrics []string, interval time.Duration, metricsAddr string) (worker orchestration.RemoteWorker, wait func()) {
 	// set up an output dir
 	output := ""
 	if globalOutput != "" {
@@ -296,6 +420,11 @@ func localWorker(globalOutput string, enableMetrics []string, interval time.Dura
 			enableMetrics,
 			interval,
 		)
+		if metricsAddr != "" {
+			om, stop := serveOpenMetrics(metricsAddr)
+			defer stop()
+			worker.SetOpenMetrics(om)
+		}
 
 		err := worker.Run()
 		if err != nil {
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/metrics"
+	"github.com/relab/hotstuff/twins"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate an experiment in a single process under virtual time.",
+	Long: `The simulate command runs the replicas of an experiment in a single process with a simulated network and clock.
+Messages are delayed by the latencies between the locations of the replicas, plus a random jitter,
+and the replicas' timers expire according to the virtual clock, so a long experiment with many replicas
+finishes in seconds. The same seed gives the same result. There are no clients; each block counts as
+'batch-size' commands in the throughput metric.
+
+Faults can be injected by a 'faults' list in the config file, where each fault has the fields
+replica (all replicas if 0), start, end (the end of the simulation if 0), crash, droprate and delay:
+
+	[[faults]]
+	replica = 3
+	start = "2s"
+	end = "4s"
+	crash = true
+
+The measurements are written to '<output>/simulation/measurements.json', which can be read by the plot and summarize commands.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cmd.SilenceUsage = true
+		for _, key := range []string{"tree-pos", "locations"} {
+			if err := viper.BindPFlag(key, cmd.Flags().Lookup(key)); err != nil {
+				return err
+			}
+		}
+		var faults []twins.Fault
+		if err := viper.UnmarshalKey("faults", &faults); err != nil {
+			return fmt.Errorf("failed to read faults: %w", err)
+		}
+		simulateCfg.Faults = faults
+		simulateCfg.Locations = viper.GetStringSlice("locations")
+		for _, id := range viper.GetIntSlice("tree-pos") {
+			simulateCfg.TreePositions = append(simulateCfg.TreePositions, hotstuff.ID(id))
+		}
+		return runSimulation(simulateCfg)
+	},
+}
+
+var (
+	simulateCfg    twins.SimulationConfig
+	simulateOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.Flags().IntVar(&simulateCfg.Replicas, "replicas", 4, "number of replicas to simulate")
+	simulateCmd.Flags().StringVar(&simulateCfg.Consensus, "consensus", "chainedhotstuff", "name of the consensus implementation")
+	simulateCmd.Flags().StringVar(&simulateCfg.Crypto, "crypto", "ecdsa", "name of the crypto implementation")
+	simulateCmd.Flags().StringVar(&simulateCfg.LeaderRotation, "leader-rotation", "round-robin", "name of the leader rotation algorithm")
+	simulateCmd.Flags().StringSliceVar(&simulateCfg.Modules, "modules", nil, "Name additional modules to be loaded.")
+	simulateCmd.Flags().IntVar(&simulateCfg.BatchSize, "batch-size", 1, "number of commands that each block counts as")
+	simulateCmd.Flags().DurationVar(&simulateCfg.ViewTimeout, "view-timeout", 0, "duration of the first view (derived from the latencies if 0)")
+	simulateCmd.Flags().DurationVar(&simulateCfg.MaxTimeout, "max-timeout", 0, "upper limit on view timeouts")
+	simulateCmd.Flags().Uint64Var(&simulateCfg.TimeoutSamples, "duration-samples", 1000, "number of previous views to consider when predicting view duration")
+	simulateCmd.Flags().Float64Var(&simulateCfg.TimeoutMultiplier, "timeout-multiplier", 1.2, "number to multiply the view duration by in case of a timeout")
+	simulateCmd.Flags().IntSlice("tree-pos", nil, "replica IDs in tree position order, used by kauri (chosen by kauri if empty)")
+	simulateCmd.Flags().StringVar(&simulateCfg.LeafPlacement, "leaf-placement", "", "algorithm that places the leaves of the kauri tree: greedy, hungarian, balanced or quorum (chosen by the tree optimizer if empty)")
+	simulateCmd.Flags().DurationVar(&simulateCfg.TreeDelta, "tree-delta", 0, "time that kauri waits for the votes of each level of the tree (derived from the latencies if 0)")
+	simulateCmd.Flags().Uint64Var(&simulateCfg.CheckpointInterval, "checkpoint-interval", 0, "number of committed blocks between certified checkpoints (disabled if 0)")
+	simulateCmd.Flags().StringSlice("locations", nil, "location of each replica, in ID order (reused in order if there are more replicas)")
+	simulateCmd.Flags().DurationVar(&simulateCfg.Latency, "latency", 10*time.Millisecond, "latency between all replicas if no locations are given")
+	simulateCmd.Flags().DurationVar(&simulateCfg.Jitter, "jitter", 0, "maximum random delay added to each message")
+	simulateCmd.Flags().DurationVar(&simulateCfg.Duration, "duration", 10*time.Second, "virtual duration of the experiment")
+	simulateCmd.Flags().DurationVar(&simulateCfg.Resolution, "resolution", time.Microsecond, "resolution of the virtual clock")
+	simulateCmd.Flags().Int64Var(&simulateCfg.Seed, "seed", 0, "seed of the jitter and the message drops, also used as the shared random seed")
+	simulateCmd.Flags().StringSliceVar(&simulateCfg.Metrics, "metrics", []string{"throughput"}, "list of metrics to enable")
+	simulateCmd.Flags().DurationVar(&simulateCfg.MeasurementInterval, "measurement-interval", time.Second, "time interval between measurements")
+	simulateCmd.Flags().StringVar(&simulateOutput, "output", "", "the directory to save the measurements to (disabled by default)")
+}
+
+func runSimulation(cfg twins.SimulationConfig) (err error) {
+	if simulateOutput != "" {
+		dir := filepath.Join(simulateOutput, "simulation")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(filepath.Join(dir, "measurements.json"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		wr := bufio.NewWriter(f)
+		defer func() {
+			if ferr := wr.Flush(); err == nil {
+				err = ferr
+			}
+		}()
+		logger, err := metrics.NewJSONLogger(wr)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := logger.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		cfg.MetricsLogger = logger
+	}
+
+	start := time.Now()
+	result, err := twins.Simulate(cfg)
+	if err != nil {
+		return err
+	}
+	log.Printf("Simulated %v in %v: %d blocks committed, %d messages sent, %d dropped",
+		cfg.Duration, time.Since(start).Round(time.Millisecond), result.Commits, result.Messages, result.Dropped)
+	if !result.Safe {
+		return fmt.Errorf("the replicas committed different blocks")
+	}
+	return nil
+}
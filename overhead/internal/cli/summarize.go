@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/relab/hotstuff/internal/proto/orchestrationpb" // register message types
+	"github.com/relab/hotstuff/metrics/plotting"
+	_ "github.com/relab/hotstuff/metrics/types" // register message types
+	"github.com/spf13/cobra"
+)
+
+var (
+	summaryFormat   string
+	summaryOutput   string
+	summaryWarmup   time.Duration
+	summaryCooldown time.Duration
+)
+
+var summarizeCmd = &cobra.Command{
+	Use:   "summarize [experiment...]",
+	Short: "Compute summary statistics of experiment measurements.",
+	Long: `The summarize command computes the mean, standard deviation and percentiles of
+client latency (ms), throughput (commands/second), view timeouts, proposal bytes
+and the consensus phases (ms) for each experiment, both per client/replica and for all of them.
+The phases are recorded by the 'consensus-phases' metric. With Kauri, the QC phase is also
+compared with the QC latency predicted by the tree optimizer. The messages and bytes that replicas
+send to each other are recorded by the 'traffic' metric, and are reported in total and for each gRPC method.
+Each argument is either a measurements file, or the output directory of 'hotstuff run',
+in which case all '*/measurements.json' files in the directory are read.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		var rows []plotting.SummaryRow
+		for _, experiment := range args {
+			summary, err := plotting.ReadSummary(experiment)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, summary.Compute(filepath.Base(filepath.Clean(experiment)), summaryWarmup, summaryCooldown)...)
+		}
+
+		out := os.Stdout
+		if summaryOutput != "" {
+			var err error
+			out, err = os.OpenFile(summaryOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			defer func() { checkf("failed to close output file: %v", out.Close()) }()
+		}
+		switch summaryFormat {
+		case "csv":
+			return plotting.WriteSummaryCSV(out, rows)
+		case "json":
+			return plotting.WriteSummaryJSON(out, rows)
+		default:
+			return fmt.Errorf("unknown format '%s'", summaryFormat)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(summarizeCmd)
+
+	summarizeCmd.Flags().StringVar(&summaryFormat, "format", "csv", "output format (csv or json)")
+	summarizeCmd.Flags().StringVar(&summaryOutput, "output", "", "file to write the summary to (defaults to stdout)")
+	summarizeCmd.Flags().DurationVar(&summaryWarmup, "warmup", 0, "ignore measurements taken within this duration after a client/replica started")
+	summarizeCmd.Flags().DurationVar(&summaryCooldown, "cooldown", 0, "ignore measurements taken within this duration before the last measurement of a client/replica")
+}
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep config",
+	Short: "Run an experiment for each combination of a grid of parameters.",
+	Long: `The sweep command runs an experiment for each combination of the parameters in a grid,
+repeating each combination a number of times. The config file uses the same settings as 'hotstuff run',
+which are used by all experiments, and a 'sweep' table with the grid and the number of repetitions:
+
+	replicas = 7
+	duration = "30s"
+	output = "results"
+
+	[sweep]
+	repetitions = 3
+
+	[sweep.grid]
+	modules = [["kauri"], ["kauri", "complaintcache"]]
+	byzantine = [[], ["silence:1"], ["silence:2"]]
+
+The output of each run is saved to '<output>/<combination>/<repetition>', where the combination is a label such as
+'byzantine=silence_1,modules=kauri+complaintcache', and the repetitions are numbered from 1.
+The parameters of each run, the git revision and whether each run finished are written to '<output>/manifest.json'.
+If a run fails, the sweep stops, and running it again with the same output directory skips the finished runs.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		viper.SetConfigFile(args[0])
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read sweep config: %w", err)
+		}
+		return runSweep()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sweepCmd)
+}
+
+// sweepManifest records the parameters and progress of a sweep.
+type sweepManifest struct {
+	Revision    string           `json:"revision"`
+	Params      map[string]any   `json:"params"`
+	Grid        map[string][]any `json:"grid"`
+	Repetitions int              `json:"repetitions"`
+	Runs        []*sweepRun      `json:"runs"`
+}
+
+// sweepRun is one run of a combination of parameters.
+type sweepRun struct {
+	Combination string         `json:"combination"`
+	Repetition  int            `json:"repetition"`
+	Params      map[string]any `json:"params"`
+	Output      string         `json:"output"` // relative to the output directory of the sweep
+	Done        bool           `json:"done"`
+	Error       string         `json:"error,omitempty"`
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+}
+
+func runSweep() error {
+	output := viper.GetString("output")
+	if output == "" {
+		return errors.New("the sweep config must set an output directory")
+	}
+	grid, err := sweepGrid()
+	if err != nil {
+		return err
+	}
+	repetitions := max(viper.GetInt("sweep.repetitions"), 1)
+
+	params := viper.AllSettings()
+	delete(params, "sweep")
+	manifest := &sweepManifest{
+		Revision:    gitRevision(),
+		Params:      params,
+		Grid:        grid,
+		Repetitions: repetitions,
+	}
+	manifestPath := filepath.Join(output, "manifest.json")
+	done, err := finishedRuns(manifestPath, manifest.Revision)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+
+	for _, combination := range combinations(grid) {
+		label := combinationLabel(combination)
+		for rep := 1; rep <= repetitions; rep++ {
+			if run, ok := done[runKey(label, rep)]; ok {
+				manifest.Runs = append(manifest.Runs, run)
+				continue
+			}
+			run := &sweepRun{
+				Combination: label,
+				Repetition:  rep,
+				Params:      combination,
+				Output:      filepath.Join(label, fmt.Sprint(rep)),
+			}
+			manifest.Runs = append(manifest.Runs, run)
+
+			// remove the output of an unfinished attempt
+			runOutput := filepath.Join(output, run.Output)
+			if err := os.RemoveAll(runOutput); err != nil {
+				return err
+			}
+			for key, value := range combination {
+				viper.Set(key, value)
+			}
+			viper.Set("output", runOutput)
+
+			log.Printf("Running %s (%d/%d)", label, rep, repetitions)
+			run.Start = time.Now()
+			err := runController()
+			run.End = time.Now()
+			run.Done = err == nil
+			if err != nil {
+				run.Error = err.Error()
+			}
+			if werr := writeManifest(manifestPath, manifest); werr != nil {
+				return werr
+			}
+			if err != nil {
+				return fmt.Errorf("run %d of %s failed: %w", rep, label, err)
+			}
+		}
+	}
+	return writeManifest(manifestPath, manifest)
+}
+
+// sweepGrid returns the values of each parameter of the grid. The parameters must be settings of 'hotstuff run'.
+func sweepGrid() (map[string][]any, error) {
+	grid := make(map[string][]any)
+	for key, values := range viper.GetStringMap("sweep.grid") {
+		if runCmd.Flags().Lookup(key) == nil && key != "hosts-config" {
+			return nil, fmt.Errorf("unknown parameter '%s' in the sweep grid", key)
+		}
+		list, ok := values.([]any)
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("the sweep grid must have a non-empty list of values for '%s'", key)
+		}
+		grid[key] = list
+	}
+	if len(grid) == 0 {
+		return nil, errors.New("the sweep config has no 'sweep.grid' table")
+	}
+	return grid, nil
+}
+
+// combinations returns every combination of the values of the grid.
+// The combinations vary the last parameter, in sorted order, fastest.
+func combinations(grid map[string][]any) []map[string]any {
+	keys := make([]string, 0, len(grid))
+	for key := range grid {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := []map[string]any{{}}
+	for _, key := range keys {
+		var next []map[string]any
+		for _, partial := range result {
+			for _, value := range grid[key] {
+				combination := make(map[string]any, len(partial)+1)
+				for k, v := range partial {
+					combination[k] = v
+				}
+				combination[key] = value
+				next = append(next, combination)
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// combinationLabel returns a label for the combination that can be used as a directory name,
+// e.g. 'byzantine=silence_1,modules=kauri+complaintcache'.
+func combinationLabel(combination map[string]any) string {
+	keys := make([]string, 0, len(combination))
+	for key := range combination {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		str := fmt.Sprint(combination[key])
+		if list, ok := combination[key].([]any); ok {
+			values := make([]string, len(list))
+			for j, v := range list {
+				values[j] = fmt.Sprint(v)
+			}
+			str = strings.Join(values, "+")
+			if str == "" {
+				str = "none"
+			}
+		}
+		parts[i] = key + "=" + strings.Map(func(r rune) rune {
+			if r == '/' || r == '\\' || r == ':' || r == ',' || r == '=' || r == ' ' {
+				return '_'
+			}
+			return r
+		}, str)
+	}
+	return strings.Join(parts, ",")
+}
+
+func runKey(label string, rep int) string {
+	return fmt.Sprintf("%s/%d", label, rep)
+}
+
+// finishedRuns reads the runs that have finished from an existing manifest, if any.
+func finishedRuns(manifestPath, revision string) (map[string]*sweepRun, error) {
+	done := make(map[string]*sweepRun)
+	b, err := os.ReadFile(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var previous sweepManifest
+	if err := json.Unmarshal(b, &previous); err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", manifestPath, err)
+	}
+	if previous.Revision != revision {
+		log.Printf("Resuming a sweep started at revision %s with revision %s", previous.Revision, revision)
+	}
+	for _, run := range previous.Runs {
+		if run.Done {
+			done[runKey(run.Combination, run.Repetition)] = run
+		}
+	}
+	if len(done) > 0 {
+		log.Printf("Skipping %d finished runs", len(done))
+	}
+	return done, nil
+}
+
+func writeManifest(manifestPath string, manifest *sweepManifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, append(b, '\n'), 0o644)
+}
+
+// gitRevision returns the git revision that the executable was built from,
+// or the revision of the working directory if the build has no version control information.
+func gitRevision() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		var revision, modified string
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				revision = setting.Value
+			case "vcs.modified":
+				modified = setting.Value
+			}
+		}
+		if revision != "" {
+			if modified == "true" {
+				revision += "-dirty"
+			}
+			return revision
+		}
+	}
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
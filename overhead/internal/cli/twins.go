@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -31,14 +32,25 @@ var (
 	twinsDest           string
 	twinsSrc            string
 	twinsConsensus      string
+	twinsModules        []string
+	twinsTree           bool
 	logAll              bool
 	concurrency         uint
+	livenessViews       int
+	minimize            bool
+	coverageDest        string
 )
 
 var twinsCmd = &cobra.Command{
 	Use:   "twins [run|generate]",
 	Short: "Generate and execute Twins scenarios.",
-	Long:  `The twins command allows for generating and executing twins scenarios.`,
+	Long: `The twins command allows for generating and executing twins scenarios.
+
+When running scenarios, each scenario is followed by 'liveness-views' views without partitions,
+and every correct replica must commit a block within those views. Scenarios that are unsafe or not live
+are minimized to the shortest scenario that still fails before they are written to the output.
+The leaders, partitions and consensus code paths exercised by the scenarios are logged at the end,
+and written as JSON to the 'coverage' file, if given.`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
@@ -76,8 +88,13 @@ func init() {
 	twinsCmd.Flags().StringVar(&twinsDest, "output", "", "If scenarios-per-file is 0, this specifies the file to write to.\nOtherwise this specifies the directory to write files to.")
 	twinsCmd.Flags().StringVar(&twinsSrc, "input", "", "File to read scenarios from.")
 	twinsCmd.Flags().StringVar(&twinsConsensus, "consensus", "chainedhotstuff", "The name of the consensus implementation to use.")
+	twinsCmd.Flags().StringSliceVar(&twinsModules, "modules", nil, "Additional modules to enable on all nodes, such as kauri and complaintcache.")
+	twinsCmd.Flags().BoolVar(&twinsTree, "tree", false, "Generate partitions that cut off internal nodes of a Kauri tree, with a fixed leader at the root.")
 	twinsCmd.Flags().BoolVar(&logAll, "log-all", false, "If true, all scenarios will be written to the output file when in \"run\" mode.")
 	twinsCmd.Flags().UintVar(&concurrency, "concurrency", 1, "Number of goroutines to use. If set to 0, the number of CPUs will be used.")
+	twinsCmd.Flags().IntVar(&livenessViews, "liveness-views", 4, "Number of views after the partitions heal in which every correct replica must commit a block.\nIf set to 0, liveness is not checked.")
+	twinsCmd.Flags().BoolVar(&minimize, "minimize", true, "Minimize failing scenarios before writing them to the output.")
+	twinsCmd.Flags().StringVar(&coverageDest, "coverage", "", "File to write the coverage of the scenarios to, as JSON.")
 }
 
 func twinsRun() {
@@ -127,6 +144,11 @@ func twinsRun() {
 
 	wg.Wait()
 
+	log.Printf("coverage:\n%v", t.coverage)
+	if coverageDest != "" {
+		checkf("failed to write coverage: %v", t.coverage.writeFile(coverageDest))
+	}
+
 	log.Println("done")
 }
 
@@ -156,6 +178,35 @@ type twinsInstance struct {
 	outputStream scenarioWriter
 	logger       logging.Logger
 	closeOutput  func() error
+	coverage     *coverage
+}
+
+// coverage collects the coverage of the scenarios executed by concurrent workers.
+type coverage struct {
+	mut      sync.Mutex
+	coverage *twins.Coverage
+}
+
+func (c *coverage) add(scenario twins.Scenario, result twins.ScenarioResult) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.coverage.Add(scenario, result)
+}
+
+func (c *coverage) String() string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.coverage.String()
+}
+
+func (c *coverage) writeFile(name string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	b, err := json.MarshalIndent(c.coverage, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0644)
 }
 
 func newGen(logger logging.Logger) *twins.Generator {
@@ -165,6 +216,7 @@ func newGen(logger logging.Logger) *twins.Generator {
 		Partitions: numPartitions,
 		Views:      numViews,
 		Ticks:      numTicks,
+		Tree:       twinsTree,
 	})
 
 	if shuffle {
@@ -227,6 +279,7 @@ func newInstance(scenarioSource twins.ScenarioSource) (twinsInstance, error) {
 		source:       scenarioSource,
 		outputStream: output,
 		logger:       logging.New("twins"),
+		coverage:     &coverage{coverage: twins.NewCoverage()},
 		closeOutput: func() error {
 			if cerr := closeOutput(); err == nil {
 				err = cerr
@@ -260,13 +313,21 @@ func (ti twinsInstance) generateAndExecuteScenario() (bool, error) {
 
 	t := time.Now()
 
-	result, err := twins.ExecuteScenario(scenario, settings.NumNodes, settings.NumTwins, settings.Ticks, twinsConsensus)
+	scenario = twins.Heal(scenario, settings.NumNodes, settings.NumTwins, livenessViews)
+	result, err := twins.ExecuteScenario(scenario, settings.NumNodes, settings.NumTwins, settings.Ticks, twinsConsensus, twinsModules...)
 	if err != nil {
 		return false, err
 	}
 
 	ti.logger.Debugf("%d commits, duration: %s", result.Commits, time.Since(t).String())
 
+	ti.coverage.add(scenario, result)
+	stalled := twins.CheckLiveness(scenario, result, livenessViews)
+
+	if !result.SuspicionsAgree {
+		ti.logger.Infof("Found scenario where the replicas disagree on the suspicion state: %v", scenario)
+	}
+
 	if !result.Safe {
 		ti.logger.Info("Found unsafe scenario: %v", scenario)
 		fmt.Fprintln(os.Stderr, "================ Network Logs ================")
@@ -279,7 +340,16 @@ func (ti twinsInstance) generateAndExecuteScenario() (bool, error) {
 		}
 	}
 
-	if !result.Safe || logAll {
+	if len(stalled) > 0 {
+		ti.logger.Infof("Found scenario where %v did not commit within %d views after the partitions healed: %v", stalled, livenessViews, scenario)
+	}
+
+	if (!result.Safe || len(stalled) > 0) && minimize {
+		scenario = ti.minimize(scenario, !result.Safe)
+		ti.logger.Infof("Minimized the scenario to %d views: %v", len(scenario), scenario)
+	}
+
+	if !result.Safe || len(stalled) > 0 || !result.SuspicionsAgree || logAll {
 		err := ti.outputStream.WriteScenario(scenario)
 		if err != nil {
 			return false, err
@@ -289,6 +359,21 @@ func (ti twinsInstance) generateAndExecuteScenario() (bool, error) {
 	return true, nil
 }
 
+// minimize returns the shortest scenario that is still unsafe, if unsafe is true, or not live otherwise.
+func (ti twinsInstance) minimize(scenario twins.Scenario, unsafe bool) twins.Scenario {
+	settings := ti.source.Settings()
+	return twins.Minimize(scenario, func(s twins.Scenario) bool {
+		result, err := twins.ExecuteScenario(s, settings.NumNodes, settings.NumTwins, settings.Ticks, twinsConsensus, twinsModules...)
+		if err != nil {
+			return false
+		}
+		if unsafe {
+			return !result.Safe
+		}
+		return len(twins.CheckLiveness(s, result, livenessViews)) > 0
+	})
+}
+
 type scenarioWriter interface {
 	WriteScenario(scenario twins.Scenario) error
 	Close() error
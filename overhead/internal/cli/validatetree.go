@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/kauri"
+	"github.com/relab/hotstuff/metrics/plotting"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"optitree/opt"
+)
+
+var (
+	validateDuration    time.Duration
+	validateTreeDelta   time.Duration
+	validateViewTimeout time.Duration
+	validateWarmup      time.Duration
+	validateOutput      string
+	validateFormat      string
+)
+
+var validateTreeCmd = &cobra.Command{
+	Use:   "validate-tree",
+	Short: "Compare the QC latency predicted by the tree optimizer with a Kauri run.",
+	Long: `The validate-tree command runs Kauri locally with a fixed tree, emulating the latencies
+between the given locations, and compares the QC latency of each view with the QC latency
+that the tree optimizer predicts for the same tree and latency matrix.
+The tree is given by 'tree-pos', the replica IDs in tree position order with the root first,
+and 'locations', the location of each replica in ID order. Both can be read from the config file.
+The output has a row for each view, with latencies in milliseconds, followed by the mean of all views.
+The 'replica' column is the prediction made by the leader itself; it should equal the 'predicted' column.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		for _, key := range []string{"tree-pos", "locations", "branch-factor"} {
+			if err := viper.BindPFlag(key, cmd.Flags().Lookup(key)); err != nil {
+				return err
+			}
+		}
+		return validateTree(viper.GetIntSlice("tree-pos"), viper.GetStringSlice("locations"), viper.GetInt("branch-factor"))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateTreeCmd)
+
+	validateTreeCmd.Flags().IntSlice("tree-pos", nil, "replica IDs in tree position order")
+	validateTreeCmd.Flags().StringSlice("locations", nil, "location of each replica, in ID order")
+	validateTreeCmd.Flags().Int("branch-factor", kauri.MaxChild, "branch factor of the tree")
+	validateTreeCmd.Flags().DurationVar(&validateDuration, "duration", 10*time.Second, "duration of the experiment")
+	validateTreeCmd.Flags().DurationVar(&validateTreeDelta, "tree-delta", 0, "time that kauri waits for the votes of each level of the tree (derived from the latencies if 0)")
+	validateTreeCmd.Flags().DurationVar(&validateViewTimeout, "view-timeout", 0, "duration of the first view (derived from the latencies if 0)")
+	validateTreeCmd.Flags().DurationVar(&validateWarmup, "warmup", 0, "ignore views that ended within this duration after the leader started")
+	validateTreeCmd.Flags().StringVar(&validateOutput, "output", "", "the directory to save the measurements to (a temporary directory by default)")
+	validateTreeCmd.Flags().StringVar(&validateFormat, "format", "csv", "output format (csv or json)")
+}
+
+func validateTree(treePos []int, locations []string, branchFactor int) error {
+	n := len(treePos)
+	if branchFactor != kauri.MaxChild {
+		return fmt.Errorf("kauri only supports branch factor %d", kauri.MaxChild)
+	}
+	if n < branchFactor+1 || n > opt.TreeSize(branchFactor) {
+		return fmt.Errorf("%d replicas do not fit in a tree of height two with branch factor %d", n, branchFactor)
+	}
+	if len(locations) != n {
+		return fmt.Errorf("got %d locations for %d replicas", len(locations), n)
+	}
+
+	// Kauri puts the leader at the root, so the replicas are renumbered
+	// such that the replica at tree position i has ID i+1, and replica 1 is the fixed leader.
+	positionLocations := make([]string, n)
+	seen := make(map[int]bool, n)
+	for pos, id := range treePos {
+		if id < 1 || id > n || seen[id] {
+			return fmt.Errorf("tree positions must be a permutation of the replica IDs 1 to %d: %v", n, treePos)
+		}
+		seen[id] = true
+		positionLocations[pos] = locations[id-1]
+	}
+	latencies := opt.NewLatencies(n)
+	var maxLatency time.Duration
+	for i, a := range positionLocations {
+		for j, b := range positionLocations {
+			latency, ok := backend.LocationLatency(a, b)
+			if !ok {
+				return fmt.Errorf("unknown latency between '%s' and '%s'", a, b)
+			}
+			latencies[i][j] = opt.Latency(latency.Microseconds())
+			maxLatency = max(maxLatency, latency)
+		}
+	}
+	tree := make([]int, n)
+	identity := make([]int, n)
+	for i := range tree {
+		tree[i] = i
+		identity[i] = i + 1
+	}
+	predicted := time.Duration(latencies.TreeQCLatency(hotstuff.QuorumSize(n), branchFactor, tree)) * time.Microsecond
+
+	// an internal node must wait for the votes of its children, which take a round trip
+	treeDelta := validateTreeDelta
+	if treeDelta == 0 {
+		treeDelta = 2*maxLatency + 10*time.Millisecond
+	}
+	viewTimeout := validateViewTimeout
+	if viewTimeout == 0 {
+		viewTimeout = 4*max(predicted, 2*maxLatency) + 100*time.Millisecond
+	}
+
+	output := validateOutput
+	if output == "" {
+		var err error
+		if output, err = os.MkdirTemp("", "validate-tree"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(output)
+	}
+	viper.Set("replicas", n)
+	viper.Set("clients", 1)
+	viper.Set("duration", validateDuration)
+	viper.Set("view-timeout", viewTimeout)
+	viper.Set("client-timeout", 20*viewTimeout)
+	viper.Set("leader-rotation", "fixed")
+	viper.Set("modules", []string{"kauri"})
+	viper.Set("tree-pos", identity)
+	viper.Set("tree-delta", treeDelta)
+	viper.Set("metrics", []string{"consensus-phases"})
+	viper.Set("measurement-interval", time.Second)
+	viper.Set("output", output)
+	viper.Set("hosts-config", []map[string]any{{
+		"name":      "localhost",
+		"replicas":  n,
+		"clients":   1,
+		"locations": positionLocations,
+	}})
+	runController()
+
+	files, err := filepath.Glob(filepath.Join(output, "*", "measurements.json"))
+	if err != nil {
+		return err
+	}
+	prediction := plotting.NewQCPrediction()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		err = plotting.NewReader(f, &prediction).ReadAll()
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read '%s': %w", file, err)
+		}
+	}
+	report := prediction.Compute(predicted, validateWarmup)
+	if len(report.Views) == 0 {
+		return fmt.Errorf("no QC latencies were measured")
+	}
+	for _, row := range report.Views {
+		if row.Replica != 0 && row.Replica != row.Predicted {
+			log.Printf("the leader predicted %vms in view %d, but the tree optimizer predicts %vms", row.Replica, row.View, row.Predicted)
+			break
+		}
+	}
+	log.Printf("predicted QC latency %v; mean error %.3fms, mean absolute error %.3fms, mean relative error %.3f",
+		predicted, report.Error.Mean, report.MeanAbsoluteError, report.RelativeError.Mean)
+
+	switch validateFormat {
+	case "csv":
+		return plotting.WritePredictionCSV(os.Stdout, report)
+	case "json":
+		return plotting.WritePredictionJSON(os.Stdout, report)
+	default:
+		return fmt.Errorf("unknown format '%s'", validateFormat)
+	}
+}
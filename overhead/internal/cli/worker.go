@@ -2,7 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"time"
 
@@ -22,6 +25,7 @@ var (
 
 	enableMetrics       []string
 	measurementInterval time.Duration
+	metricsAddr         string
 )
 
 // workerCmd represents the worker command
@@ -56,6 +60,7 @@ func init() {
 
 	workerCmd.Flags().StringSliceVar(&enableMetrics, "metrics", nil, "the metrics to enable")
 	workerCmd.Flags().DurationVar(&measurementInterval, "measurement-interval", 0, "the interval between measurements")
+	workerCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "the address to serve live metrics on in OpenMetrics format")
 }
 
 func runWorker() {
@@ -83,8 +88,32 @@ func runWorker() {
 	}
 
 	worker := orchestration.NewWorker(protostream.NewWriter(os.Stdout), protostream.NewReader(os.Stdin), metricsLogger, enableMetrics, measurementInterval)
+	if metricsAddr != "" {
+		om, stop := serveOpenMetrics(metricsAddr)
+		defer stop()
+		worker.SetOpenMetrics(om)
+	}
 	err = worker.Run()
 	if err != nil {
 		log.Println(err)
 	}
 }
+
+// serveOpenMetrics serves live metrics in OpenMetrics format at /metrics on the given address.
+// The returned function stops the server.
+func serveOpenMetrics(addr string) (om *metrics.OpenMetrics, stop func()) {
+	lis, err := net.Listen("tcp", addr)
+	checkf("failed to listen on metrics address: %v", err)
+	log.Printf("serving metrics on http://%s/metrics", lis.Addr())
+
+	om = metrics.NewOpenMetrics()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", om.Handler())
+	srv := &http.Server{Handler: mux}
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics server failed: %v", err)
+		}
+	}()
+	return om, func() { checkf("failed to close metrics server: %v", srv.Close()) }
+}
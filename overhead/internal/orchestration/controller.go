@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -44,6 +45,16 @@ type Experiment struct {
 	Byzantine   map[string]int // number of replicas to assign to each byzantine strategy
 	Output      string         // path to output folder
 
+	// Dashboard, if set, shows a live summary of the measurements that the workers stream during the experiment.
+	// The workers must be started with the throughput, client-latency, timeouts and tree-changes metrics.
+	Dashboard io.Writer
+	// AbortAfter stops the experiment early if no commands were committed for this duration (disabled if 0).
+	AbortAfter time.Duration
+	// Restarts are the crashes and restarts of replicas during the experiment.
+	Restarts []Restart
+	// Reconfigurations are the reconfigurations of the active replicas during the experiment.
+	Reconfigurations []Reconfiguration
+
 	// the host associated with each replica.
 	hostsToReplicas map[string][]hotstuff.ID
 	// the host associated with each client.
@@ -62,6 +73,11 @@ func (e *Experiment) Run() (err error) {
 		}
 	}()
 
+	events, err := e.schedule()
+	if err != nil {
+		return err
+	}
+
 	err = e.assignReplicasAndClients()
 	if err != nil {
 		return err
@@ -80,6 +96,16 @@ func (e *Experiment) Run() (err error) {
 		return fmt.Errorf("failed to create replicas: %w", err)
 	}
 
+	d := newDashboard(e.Dashboard)
+	if e.Dashboard != nil || e.AbortAfter > 0 {
+		for host, worker := range e.Hosts {
+			err = worker.StreamMeasurements(d.add)
+			if err != nil {
+				return fmt.Errorf("failed to stream measurements from %s: %w", host, err)
+			}
+		}
+	}
+
 	e.Logger.Info("Starting replicas...")
 	err = e.startReplicas(cfg)
 	if err != nil {
@@ -92,7 +118,10 @@ func (e *Experiment) Run() (err error) {
 		return fmt.Errorf("failed to start clients: %w", err)
 	}
 
-	time.Sleep(e.Duration)
+	abortErr := e.watch(d, events, cfg)
+	if abortErr != nil {
+		e.Logger.Errorf("Aborting experiment: %v", abortErr)
+	}
 
 	e.Logger.Info("Stopping clients...")
 	err = e.stopClients()
@@ -111,6 +140,9 @@ func (e *Experiment) Run() (err error) {
 		return fmt.Errorf("failed to stop replicas: %w", err)
 	}
 
+	if abortErr != nil {
+		return fmt.Errorf("experiment aborted: %w", abortErr)
+	}
 	return nil
 }
 
@@ -351,6 +383,7 @@ func (e *Experiment) stopReplicas() error {
 
 func verifyStopResponses(responses []*orchestrationpb.StopReplicaResponse) error {
 	results := make(map[uint32][][]byte)
+	digests := make(map[uint32][][]byte)
 	for _, response := range responses {
 		commandCount := response.GetCounts()
 		hashes := response.GetHashes()
@@ -359,6 +392,7 @@ func verifyStopResponses(responses []*orchestrationpb.StopReplicaResponse) error
 				results[count] = make([][]byte, 0)
 			}
 			results[count] = append(results[count], hashes[id])
+			digests[count] = append(digests[count], response.GetDigests()[id])
 		}
 	}
 	for cmdCount, hashes := range results {
@@ -369,6 +403,15 @@ func verifyStopResponses(responses []*orchestrationpb.StopReplicaResponse) error
 			}
 		}
 	}
+	// replicas that executed the same commands must have reached the same state
+	for cmdCount, stateDigests := range digests {
+		firstDigest := stateDigests[0]
+		for _, digest := range stateDigests {
+			if !bytes.Equal(firstDigest, digest) {
+				return fmt.Errorf("state digest mismatch at command: %d", cmdCount)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,195 @@
+package orchestration
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
+	"github.com/relab/hotstuff/metrics/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// dashboardInterval is the time between updates of the dashboard.
+const dashboardInterval = time.Second
+
+// replicaStatus is the latest state of a replica, as reported by its measurements.
+type replicaStatus struct {
+	view       uint64
+	throughput float64 // commands per second
+	timeouts   uint64  // total number of view timeouts
+	tree       []uint32
+}
+
+// dashboard collects the measurements that the workers stream during an experiment,
+// and shows a live summary of them.
+type dashboard struct {
+	mut          sync.Mutex
+	replicas     map[uint32]*replicaStatus
+	latency      map[uint32]*types.LatencyMeasurement // latest latency measurement of each client
+	lastProgress time.Time                            // when a replica last reported committed commands
+
+	out   io.Writer
+	lines int // the number of lines written in the last update, to be erased on a terminal
+	tty   bool
+}
+
+func newDashboard(out io.Writer) *dashboard {
+	d := &dashboard{
+		replicas:     make(map[uint32]*replicaStatus),
+		latency:      make(map[uint32]*types.LatencyMeasurement),
+		lastProgress: time.Now(),
+		out:          out,
+	}
+	if f, ok := out.(*os.File); ok {
+		d.tty = isatty.IsTerminal(f.Fd())
+	}
+	return d
+}
+
+func (d *dashboard) replica(id uint32) *replicaStatus {
+	r, ok := d.replicas[id]
+	if !ok {
+		r = &replicaStatus{}
+		d.replicas[id] = r
+	}
+	return r
+}
+
+// add records a measurement streamed by a worker.
+func (d *dashboard) add(measurement proto.Message) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	switch m := measurement.(type) {
+	case *types.ThroughputMeasurement:
+		if duration := m.GetDuration().AsDuration(); duration > 0 {
+			d.replica(m.GetEvent().GetID()).throughput = float64(m.GetCommands()) / duration.Seconds()
+		}
+		if m.GetCommands() > 0 {
+			d.lastProgress = time.Now()
+		}
+	case *types.LatencyMeasurement:
+		if m.GetEvent().GetClient() && m.GetCount() > 0 {
+			d.latency[m.GetEvent().GetID()] = m
+		}
+	case *types.ViewTimeouts:
+		r := d.replica(m.GetEvent().GetID())
+		r.view = m.GetView()
+		r.timeouts += m.GetTimeouts()
+	case *types.TreeChange:
+		d.replica(m.GetEvent().GetID()).tree = m.GetTree()
+	}
+}
+
+// stalled returns the time since a replica last reported committed commands.
+func (d *dashboard) stalled() time.Duration {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return time.Since(d.lastProgress)
+}
+
+// update writes the current summary, replacing the previous one on a terminal.
+func (d *dashboard) update(elapsed, duration time.Duration) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	var sb strings.Builder
+	var throughput float64
+	for _, r := range d.replicas {
+		throughput += r.throughput
+	}
+	if len(d.replicas) > 0 {
+		throughput /= float64(len(d.replicas))
+	}
+	var latency, count float64
+	for _, m := range d.latency {
+		latency += m.GetLatency() * float64(m.GetCount())
+		count += float64(m.GetCount())
+	}
+	if count > 0 {
+		latency /= count
+	}
+	fmt.Fprintf(&sb, "[%v/%v] throughput: %.1f commands/s, latency: %.2f ms\n", elapsed, duration, throughput, latency)
+
+	ids := make([]uint32, 0, len(d.replicas))
+	for id := range d.replicas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "replica\tview\tthroughput\ttimeouts\ttree")
+	for _, id := range ids {
+		r := d.replicas[id]
+		tree := "-"
+		if len(r.tree) > 0 {
+			tree = fmt.Sprint(r.tree)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%.1f\t%d\t%s\n", id, r.view, r.throughput, r.timeouts, tree)
+	}
+	_ = tw.Flush()
+
+	frame := sb.String()
+	if d.tty && d.lines > 0 {
+		// move the cursor to the start of the previous summary and erase it
+		frame = fmt.Sprintf("\033[%dF\033[J", d.lines) + frame
+	}
+	d.lines = strings.Count(sb.String(), "\n")
+	_, _ = io.WriteString(d.out, frame)
+}
+
+// watch waits for the duration of the experiment, while showing the streamed measurements on the dashboard, if any,
+// and crashing, restarting and reconfiguring replicas according to the schedule.
+// It returns an error if no commands were committed for AbortAfter, or if a scheduled event failed.
+func (e *Experiment) watch(d *dashboard, events []scheduledEvent, cfg *orchestrationpb.ReplicaConfiguration) error {
+	start := time.Now()
+	// the replicas cannot commit commands before the clients are started
+	d.mut.Lock()
+	d.lastProgress = start
+	d.mut.Unlock()
+
+	shown := time.Duration(-1)
+	show := func() {
+		// the final update often coincides with the last tick
+		if elapsed := time.Since(start).Truncate(time.Second); e.Dashboard != nil && elapsed != shown {
+			d.update(elapsed, e.Duration)
+			shown = elapsed
+		}
+	}
+
+	ticker := time.NewTicker(dashboardInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(e.Duration)
+	defer timer.Stop()
+	var eventTimer <-chan time.Time
+	if len(events) > 0 {
+		eventTimer = time.After(events[0].at)
+	}
+	for {
+		select {
+		case <-eventTimer:
+			if err := e.runScheduled(events[0], cfg); err != nil {
+				return err
+			}
+			events = events[1:]
+			eventTimer = nil
+			if len(events) > 0 {
+				eventTimer = time.After(events[0].at - time.Since(start))
+			}
+		case <-timer.C:
+			show()
+			return nil
+		case <-ticker.C:
+			show()
+			if stalled := d.stalled(); e.AbortAfter > 0 && stalled > e.AbortAfter {
+				return fmt.Errorf("no commands were committed for %v", stalled.Truncate(time.Second))
+			}
+		}
+	}
+}
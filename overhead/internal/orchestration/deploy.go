@@ -32,6 +32,7 @@ type DeployConfig struct {
 	Fgprof              bool
 	Metrics             []string
 	MeasurementInterval time.Duration
+	MetricsAddr         string
 }
 
 // Deploy deploys the hotstuff binary to a group of servers and starts a worker on the given port.
@@ -220,6 +221,12 @@ func (w *workerSetup) Apply(_ context.Context, host iago.Host) (err error) {
 	}
 	sb.WriteString("\" ")
 
+	if w.cfg.MetricsAddr != "" {
+		sb.WriteString("--metrics-addr ")
+		sb.WriteString(w.cfg.MetricsAddr)
+		sb.WriteString(" ")
+	}
+
 	if w.cfg.CPUProfiling {
 		sb.WriteString("--cpu-profile ")
 		sb.WriteString(path.Join(dir, "cpuprofile"))
@@ -1,23 +1,31 @@
 package orchestration_test
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 	"math"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain/persistent"
 	"github.com/relab/hotstuff/internal/orchestration"
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"github.com/relab/hotstuff/internal/protostream"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/metrics"
+	"github.com/relab/hotstuff/modules"
 	"github.com/relab/iago/iagotest"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
@@ -88,6 +96,256 @@ func TestOrchestration(t *testing.T) {
 	t.Run("Simple-HotStuff+BLS12+Handel", func(t *testing.T) { run("simplehotstuff", "bls12", mods) })
 }
 
+func TestOpenMetrics(t *testing.T) {
+	controllerStream, workerStream := net.Pipe()
+
+	workerProxy := orchestration.NewRemoteWorker(protostream.NewWriter(controllerStream), protostream.NewReader(controllerStream))
+	worker := orchestration.NewWorker(protostream.NewWriter(workerStream), protostream.NewReader(workerStream), metrics.NopLogger(), nil, 0)
+	om := metrics.NewOpenMetrics()
+	worker.SetOpenMetrics(om)
+	srv := httptest.NewServer(om.Handler())
+	defer srv.Close()
+
+	experiment := &orchestration.Experiment{
+		Logger:      logging.New("ctrl"),
+		NumReplicas: 4,
+		NumClients:  1,
+		ClientOpts: &orchestrationpb.ClientOpts{
+			ConnectTimeout: durationpb.New(time.Second),
+			MaxConcurrent:  250,
+			PayloadSize:    100,
+			RateLimit:      math.Inf(1),
+			Timeout:        durationpb.New(500 * time.Millisecond),
+		},
+		ReplicaOpts: &orchestrationpb.ReplicaOpts{
+			BatchSize:         100,
+			ConnectTimeout:    durationpb.New(time.Second),
+			InitialTimeout:    durationpb.New(100 * time.Millisecond),
+			TimeoutSamples:    1000,
+			TimeoutMultiplier: 1.2,
+			Consensus:         "chainedhotstuff",
+			Crypto:            "ecdsa",
+			LeaderRotation:    "round-robin",
+		},
+		Duration: 2 * time.Second,
+		Hosts:    map[string]orchestration.RemoteWorker{"127.0.0.1": workerProxy},
+	}
+
+	c := make(chan error)
+	go func() {
+		c <- worker.Run()
+	}()
+	if err := experiment.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-c; err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/openmetrics-text")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Errorf("got content type %q, want OpenMetrics", ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`hotstuff_commits_total{replica="1"}`,
+		`hotstuff_commands_total{replica="4"}`,
+		`hotstuff_view{replica="2"}`,
+		`hotstuff_client_latency_seconds_bucket{client="1",le="+Inf"}`,
+		"# EOF",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("scrape does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	controllerStream, workerStream := net.Pipe()
+
+	workerProxy := orchestration.NewRemoteWorker(protostream.NewWriter(controllerStream), protostream.NewReader(controllerStream))
+	worker := orchestration.NewWorker(protostream.NewWriter(workerStream), protostream.NewReader(workerStream), metrics.NopLogger(),
+		[]string{"throughput", "client-latency", "timeouts", "tree-changes"}, 500*time.Millisecond)
+
+	var dashboard bytes.Buffer
+	experiment := &orchestration.Experiment{
+		Logger:      logging.New("ctrl"),
+		NumReplicas: 4,
+		NumClients:  1,
+		ClientOpts: &orchestrationpb.ClientOpts{
+			ConnectTimeout: durationpb.New(time.Second),
+			MaxConcurrent:  250,
+			PayloadSize:    100,
+			RateLimit:      math.Inf(1),
+			Timeout:        durationpb.New(500 * time.Millisecond),
+		},
+		ReplicaOpts: &orchestrationpb.ReplicaOpts{
+			BatchSize:         100,
+			ConnectTimeout:    durationpb.New(time.Second),
+			InitialTimeout:    durationpb.New(100 * time.Millisecond),
+			TimeoutSamples:    1000,
+			TimeoutMultiplier: 1.2,
+			Consensus:         "chainedhotstuff",
+			Crypto:            "ecdsa",
+			LeaderRotation:    "round-robin",
+		},
+		Duration:   3 * time.Second,
+		Hosts:      map[string]orchestration.RemoteWorker{"127.0.0.1": workerProxy},
+		Dashboard:  &dashboard,
+		AbortAfter: 2 * time.Second,
+	}
+
+	c := make(chan error)
+	go func() {
+		c <- worker.Run()
+	}()
+	if err := experiment.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-c; err != nil {
+		t.Fatal(err)
+	}
+
+	// the last summary should have a row for each replica
+	out := dashboard.String()
+	last := out[strings.LastIndex(out, "replica"):]
+	if rows := strings.Count(last, "\n") - 1; rows != 4 {
+		t.Errorf("got %d replicas in the last summary, want 4:\n%s", rows, out)
+	}
+	if !strings.HasPrefix(out, "[1s/3s] throughput:") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+}
+
+// newStatefulExperiment returns a local experiment with the kv workload, where the replicas store their state in dataDir.
+func newStatefulExperiment(dataDir string, workerProxy orchestration.RemoteWorker) *orchestration.Experiment {
+	return &orchestration.Experiment{
+		Logger:      logging.New("ctrl"),
+		NumReplicas: 4,
+		NumClients:  1,
+		ClientOpts: &orchestrationpb.ClientOpts{
+			ConnectTimeout: durationpb.New(time.Second),
+			MaxConcurrent:  250,
+			PayloadSize:    16,
+			RateLimit:      1000,
+			Timeout:        durationpb.New(500 * time.Millisecond),
+			Workload:       "kv",
+			KVKeys:         100,
+			KVReads:        0.5,
+		},
+		ReplicaOpts: &orchestrationpb.ReplicaOpts{
+			BatchSize:         100,
+			ConnectTimeout:    durationpb.New(time.Second),
+			InitialTimeout:    durationpb.New(100 * time.Millisecond),
+			TimeoutSamples:    1000,
+			TimeoutMultiplier: 1.2,
+			Consensus:         "chainedhotstuff",
+			Crypto:            "ecdsa",
+			LeaderRotation:    "round-robin",
+			Modules:           []string{"kvstore"},
+			DataDir:           dataDir,
+		},
+		Duration: 5 * time.Second,
+		Hosts:    map[string]orchestration.RemoteWorker{"127.0.0.1": workerProxy},
+	}
+}
+
+// runLocal runs the experiment with a worker that is connected to the given proxy streams.
+func runLocal(t *testing.T, experiment *orchestration.Experiment, workerStream net.Conn) {
+	t.Helper()
+	worker := orchestration.NewWorker(protostream.NewWriter(workerStream), protostream.NewReader(workerStream), metrics.NopLogger(), nil, 0)
+	c := make(chan error)
+	go func() {
+		c <- worker.Run()
+	}()
+	// the replicas that executed the same commands must have the same state digest
+	if err := experiment.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-c; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// checkCommittedChains checks that the replicas stored their committed chains in dataDir without gaps,
+// and that all of them committed up to about the same view.
+func checkCommittedChains(t *testing.T, dataDir string, numReplicas int) {
+	t.Helper()
+	var lastViews []hotstuff.View
+	for id := 1; id <= numReplicas; id++ {
+		chain, err := persistent.New(filepath.Join(dataDir, fmt.Sprintf("replica-%d.log", id)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		storage := chain.(modules.StableStorage)
+		committed, _ := storage.Committed()
+		if len(committed) == 0 {
+			t.Fatalf("replica %d did not commit any blocks", id)
+		}
+		parent := hotstuff.GetGenesis().Hash()
+		for _, block := range committed {
+			if block.Parent() != parent {
+				t.Fatalf("replica %d committed block %v after a gap", id, block)
+			}
+			parent = block.Hash()
+		}
+		if storage.LastVote() < committed[len(committed)-1].View() {
+			t.Errorf("replica %d: last vote in view %d before its last commit", id, storage.LastVote())
+		}
+		lastViews = append(lastViews, committed[len(committed)-1].View())
+		_ = storage.Close()
+	}
+	for id, view := range lastViews {
+		if view+5 < slices.Max(lastViews) {
+			t.Errorf("replica %d committed up to view %d, others up to %d", id+1, view, slices.Max(lastViews))
+		}
+	}
+}
+
+func TestRestart(t *testing.T) {
+	controllerStream, workerStream := net.Pipe()
+	workerProxy := orchestration.NewRemoteWorker(protostream.NewWriter(controllerStream), protostream.NewReader(controllerStream))
+
+	dataDir := t.TempDir()
+	experiment := newStatefulExperiment(dataDir, workerProxy)
+	experiment.Restarts = []orchestration.Restart{
+		{ID: 2, At: time.Second, Downtime: time.Second},
+		{ID: 3, At: 2500 * time.Millisecond, Downtime: 500 * time.Millisecond},
+	}
+	runLocal(t, experiment, workerStream)
+
+	// the restarted replicas must have caught up without gaps in their committed chains
+	checkCommittedChains(t, dataDir, 4)
+}
+
+func TestReconfigure(t *testing.T) {
+	controllerStream, workerStream := net.Pipe()
+	workerProxy := orchestration.NewRemoteWorker(protostream.NewWriter(controllerStream), protostream.NewReader(controllerStream))
+
+	dataDir := t.TempDir()
+	experiment := newStatefulExperiment(dataDir, workerProxy)
+	experiment.Reconfigurations = []orchestration.Reconfiguration{
+		{At: time.Second, ActiveReplicas: []hotstuff.ID{1, 2, 3}},
+		{At: 2500 * time.Millisecond, ActiveReplicas: []hotstuff.ID{1, 2, 3, 4}},
+	}
+	runLocal(t, experiment, workerStream)
+
+	// the paused replica must have caught up with the blocks committed while it was paused
+	checkCommittedChains(t, dataDir, 4)
+}
+
 func TestDeployment(t *testing.T) {
 	if os.Getenv("GITHUB_ACTIONS") != "" && runtime.GOOS != "linux" {
 		t.Skip("GitHub Actions only supports linux containers on linux runners.")
@@ -2,9 +2,11 @@ package orchestration
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"github.com/relab/hotstuff/internal/protostream"
+	"github.com/relab/hotstuff/metrics/types"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -12,16 +14,41 @@ import (
 
 // RemoteWorker is a proxy for a remote worker.
 type RemoteWorker struct {
-	send *protostream.Writer
-	recv *protostream.Reader
+	send   *protostream.Writer
+	recv   *protostream.Reader
+	stream *measurementStream
+}
+
+// measurementStream holds the responses of a worker that streams measurements.
+type measurementStream struct {
+	// responses is set when the worker starts streaming measurements.
+	// From then on, a goroutine reads all messages from the worker and forwards the responses.
+	responses chan response
+}
+
+type response struct {
+	msg proto.Message
+	err error
 }
 
 // NewRemoteWorker returns a new remote worker proxy.
 func NewRemoteWorker(send *protostream.Writer, recv *protostream.Reader) RemoteWorker {
 	return RemoteWorker{
-		send: send,
-		recv: recv,
+		send:   send,
+		recv:   recv,
+		stream: &measurementStream{},
+	}
+}
+
+func (w RemoteWorker) read() (proto.Message, error) {
+	if w.stream.responses == nil {
+		return w.recv.ReadAny()
+	}
+	res, ok := <-w.stream.responses
+	if !ok {
+		return nil, io.EOF
 	}
+	return res.msg, res.err
 }
 
 func (w RemoteWorker) rpc(req proto.Message) (res proto.Message, err error) {
@@ -29,7 +56,7 @@ func (w RemoteWorker) rpc(req proto.Message) (res proto.Message, err error) {
 	if err != nil {
 		return nil, err
 	}
-	res, err = w.recv.ReadAny()
+	res, err = w.read()
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +107,32 @@ func (w RemoteWorker) StopReplica(req *orchestrationpb.StopReplicaRequest) (res
 	return res, nil
 }
 
+// CrashReplica requests that the remote worker stops the specified replicas without reporting their state.
+func (w RemoteWorker) CrashReplica(req *orchestrationpb.CrashReplicaRequest) (res *orchestrationpb.CrashReplicaResponse, err error) {
+	msg, err := w.rpc(req)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := msg.(*orchestrationpb.CrashReplicaResponse)
+	if !ok {
+		return nil, fmt.Errorf("wrong type for response message: got %T, wanted: %T", msg, res)
+	}
+	return res, nil
+}
+
+// RestartReplica requests that the remote worker restarts the specified crashed replicas.
+func (w RemoteWorker) RestartReplica(req *orchestrationpb.RestartReplicaRequest) (res *orchestrationpb.RestartReplicaResponse, err error) {
+	msg, err := w.rpc(req)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := msg.(*orchestrationpb.RestartReplicaResponse)
+	if !ok {
+		return nil, fmt.Errorf("wrong type for response message: got %T, wanted: %T", msg, res)
+	}
+	return res, nil
+}
+
 // StartClient requests that the remote worker starts the specified clients.
 func (w RemoteWorker) StartClient(req *orchestrationpb.StartClientRequest) (res *orchestrationpb.StartClientResponse, err error) {
 	msg, err := w.rpc(req)
@@ -106,7 +159,54 @@ func (w RemoteWorker) StopClient(req *orchestrationpb.StopClientRequest) (res *o
 	return res, nil
 }
 
+// StreamMeasurements requests that the remote worker sends the measurements of its replicas and clients
+// as they are logged. The handler is called with each measurement on a separate goroutine.
+func (w RemoteWorker) StreamMeasurements(handler func(measurement proto.Message)) error {
+	msg, err := w.rpc(&orchestrationpb.StreamMeasurementsRequest{})
+	if err != nil {
+		return err
+	}
+	if _, ok := msg.(*orchestrationpb.StreamMeasurementsResponse); !ok {
+		return fmt.Errorf("wrong type for response message: got %T, wanted: %T", msg, &orchestrationpb.StreamMeasurementsResponse{})
+	}
+	if w.stream.responses != nil {
+		return nil
+	}
+	// there is at most one outstanding request, so the reader never blocks on the last response
+	responses := make(chan response, 1)
+	w.stream.responses = responses
+	go func() {
+		defer close(responses)
+		for {
+			msg, err := w.recv.ReadAny()
+			if err != nil {
+				responses <- response{err: err}
+				return
+			}
+			if _, ok := msg.(interface{ GetEvent() *types.Event }); ok {
+				handler(msg)
+				continue
+			}
+			responses <- response{msg: msg}
+		}
+	}()
+	return nil
+}
+
 // Quit requests that the remote worker exits.
 func (w RemoteWorker) Quit() (err error) {
 	return w.send.WriteAny(&orchestrationpb.QuitRequest{})
 }
+
+// ReconfigureReplica requests that the remote worker's replicas propose a reconfiguration when they are the leader.
+func (w RemoteWorker) ReconfigureReplica(req *orchestrationpb.ReconfigureReplicaRequest) (res *orchestrationpb.ReconfigureReplicaResponse, err error) {
+	msg, err := w.rpc(req)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := msg.(*orchestrationpb.ReconfigureReplicaResponse)
+	if !ok {
+		return nil, fmt.Errorf("wrong type for response message: got %T, wanted: %T", msg, res)
+	}
+	return res, nil
+}
@@ -135,6 +135,66 @@ var testData = []testResponsesData{
 		},
 		expectedError: true,
 	},
+	{
+		responses: []*orchestrationpb.StopReplicaResponse{
+			{
+				Hashes: map[uint32][]byte{
+					1: []byte("execution_hash"),
+					2: []byte("execution_hash"),
+				},
+				Counts: map[uint32]uint32{
+					1: 100,
+					2: 100,
+				},
+				Digests: map[uint32][]byte{
+					1: []byte("state_digest"),
+					2: []byte("state_digest"),
+				},
+			},
+			{
+				Hashes: map[uint32][]byte{
+					3: []byte("execution_hash1"),
+				},
+				Counts: map[uint32]uint32{
+					3: 200,
+				},
+				Digests: map[uint32][]byte{
+					3: []byte("state_digest1"),
+				},
+			},
+		},
+		expectedError: false,
+	},
+	{
+		responses: []*orchestrationpb.StopReplicaResponse{
+			{
+				Hashes: map[uint32][]byte{
+					1: []byte("execution_hash"),
+					2: []byte("execution_hash"),
+				},
+				Counts: map[uint32]uint32{
+					1: 100,
+					2: 100,
+				},
+				Digests: map[uint32][]byte{
+					1: []byte("state_digest"),
+					2: []byte("state_digest"),
+				},
+			},
+			{
+				Hashes: map[uint32][]byte{
+					3: []byte("execution_hash"),
+				},
+				Counts: map[uint32]uint32{
+					3: 100,
+				},
+				Digests: map[uint32][]byte{
+					3: []byte("state_digest1"),
+				},
+			},
+		},
+		expectedError: true,
+	},
 }
 
 func TestCorrectStopReplicaResponses(t *testing.T) {
@@ -0,0 +1,145 @@
+package orchestration
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
+)
+
+// Restart crashes a replica at the given time into the experiment, and restarts it after the downtime.
+// The restarted replica recovers the state stored in its data directory, so ReplicaOpts.DataDir must be set.
+type Restart struct {
+	ID       hotstuff.ID
+	At       time.Duration
+	Downtime time.Duration
+}
+
+// Reconfiguration asks the replicas at the given time into the experiment to propose a reconfiguration
+// to the active replicas. The replicas that are left out are paused, and the replicas that are added back
+// are resumed after catching up with the others.
+type Reconfiguration struct {
+	At             time.Duration
+	ActiveReplicas []hotstuff.ID
+}
+
+// The kinds of scheduled events.
+const (
+	eventCrash = iota
+	eventRestart
+	eventReconfigure
+)
+
+// scheduledEvent is a crash, restart or reconfiguration at a time into the experiment.
+type scheduledEvent struct {
+	at             time.Duration
+	kind           int
+	id             hotstuff.ID   // the crashed or restarted replica
+	activeReplicas []hotstuff.ID // the active replicas of a reconfiguration
+	serial         uint64        // the serial number of a reconfiguration
+}
+
+// schedule returns the crashes, restarts and reconfigurations of the experiment in time order.
+func (e *Experiment) schedule() ([]scheduledEvent, error) {
+	if len(e.Restarts) > 0 && e.ReplicaOpts.GetDataDir() == "" {
+		return nil, fmt.Errorf("restarting replicas requires a data directory")
+	}
+	var events []scheduledEvent
+	downtimes := make(map[hotstuff.ID][]Restart)
+	for _, r := range e.Restarts {
+		switch {
+		case r.ID < 1 || int(r.ID) > e.NumReplicas:
+			return nil, fmt.Errorf("cannot restart unknown replica %d", r.ID)
+		case r.At < 0 || r.Downtime <= 0:
+			return nil, fmt.Errorf("invalid restart of replica %d at %v after %v", r.ID, r.At, r.Downtime)
+		case r.At+r.Downtime >= e.Duration:
+			return nil, fmt.Errorf("replica %d must be restarted before the end of the experiment", r.ID)
+		}
+		for _, other := range downtimes[r.ID] {
+			if r.At < other.At+other.Downtime && other.At < r.At+r.Downtime {
+				return nil, fmt.Errorf("overlapping restarts of replica %d", r.ID)
+			}
+		}
+		downtimes[r.ID] = append(downtimes[r.ID], r)
+		events = append(events,
+			scheduledEvent{at: r.At, kind: eventCrash, id: r.ID},
+			scheduledEvent{at: r.At + r.Downtime, kind: eventRestart, id: r.ID},
+		)
+	}
+	for _, r := range e.Reconfigurations {
+		if r.At < 0 || r.At >= e.Duration {
+			return nil, fmt.Errorf("reconfiguration at %v is outside the experiment", r.At)
+		}
+		for _, id := range r.ActiveReplicas {
+			if id < 1 || int(id) > e.NumReplicas {
+				return nil, fmt.Errorf("cannot activate unknown replica %d", id)
+			}
+		}
+		events = append(events, scheduledEvent{at: r.At, kind: eventReconfigure, activeReplicas: r.ActiveReplicas})
+	}
+	slices.SortStableFunc(events, func(a, b scheduledEvent) int {
+		return int(a.at - b.at)
+	})
+	// the replicas only accept reconfigurations with increasing serial numbers
+	var serial uint64
+	for i := range events {
+		if events[i].kind == eventReconfigure {
+			serial++
+			events[i].serial = serial
+		}
+	}
+	return events, nil
+}
+
+// runScheduled sends the request of the event to the workers that host the affected replicas.
+func (e *Experiment) runScheduled(event scheduledEvent, cfg *orchestrationpb.ReplicaConfiguration) error {
+	if event.kind == eventReconfigure {
+		e.Logger.Infof("Reconfiguring to replicas %v", event.activeReplicas)
+		activeReplicas := make([]uint32, 0, len(event.activeReplicas))
+		for _, id := range event.activeReplicas {
+			activeReplicas = append(activeReplicas, uint32(id))
+		}
+		// the signature certifies to the replicas that the operator requested the reconfiguration
+		hash := hotstuff.OperatorHash(event.activeReplicas, event.serial)
+		signature, err := ecdsa.SignASN1(rand.Reader, e.caKey, hash[:])
+		if err != nil {
+			return fmt.Errorf("failed to sign the reconfiguration: %w", err)
+		}
+		for host, ids := range e.hostsToReplicas {
+			req := &orchestrationpb.ReconfigureReplicaRequest{
+				ActiveReplicas: activeReplicas,
+				Serial:         event.serial,
+				Signature:      signature,
+			}
+			for _, id := range ids {
+				req.IDs = append(req.IDs, uint32(id))
+			}
+			if _, err := e.Hosts[host].ReconfigureReplica(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for host, ids := range e.hostsToReplicas {
+		if !slices.Contains(ids, event.id) {
+			continue
+		}
+		worker := e.Hosts[host]
+		if event.kind == eventRestart {
+			e.Logger.Infof("Restarting replica %d", event.id)
+			_, err := worker.RestartReplica(&orchestrationpb.RestartReplicaRequest{
+				IDs:           []uint32{uint32(event.id)},
+				Configuration: cfg.GetReplicas(),
+			})
+			return err
+		}
+		e.Logger.Infof("Crashing replica %d", event.id)
+		_, err := worker.CrashReplica(&orchestrationpb.CrashReplicaRequest{IDs: []uint32{uint32(event.id)}})
+		return err
+	}
+	return fmt.Errorf("replica %d is not assigned to a host", event.id)
+}
@@ -1,19 +1,26 @@
 package orchestration
 
 import (
+	"bytes"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/backend"
 	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/blockchain/persistent"
 	"github.com/relab/hotstuff/client"
 	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/consensus/byzantine"
@@ -22,6 +29,7 @@ import (
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"github.com/relab/hotstuff/internal/protostream"
+	"github.com/relab/hotstuff/kauri"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/metrics"
 	"github.com/relab/hotstuff/metrics/types"
@@ -40,7 +48,7 @@ import (
 	_ "github.com/relab/hotstuff/crypto/bls12"
 	_ "github.com/relab/hotstuff/crypto/ecdsa"
 	_ "github.com/relab/hotstuff/handel"
-	_ "github.com/relab/hotstuff/kauri"
+	_ "github.com/relab/hotstuff/kvstore"
 	_ "github.com/relab/hotstuff/leaderrotation"
 	_ "github.com/relab/hotstuff/ranking"
 )
@@ -51,11 +59,21 @@ type Worker struct {
 	recv *protostream.Reader
 
 	metricsLogger       metrics.Logger
+	streaming           *atomic.Bool // whether measurements are sent to the controller
 	metrics             []string
 	measurementInterval time.Duration
+	openMetrics         *metrics.OpenMetrics
 
 	replicas map[hotstuff.ID]*replica.Replica
 	clients  map[hotstuff.ID]*client.Client
+	// the options and ports of the created replicas, used to restart them after a crash.
+	created map[hotstuff.ID]createdReplica
+}
+
+type createdReplica struct {
+	opts        *orchestrationpb.ReplicaOpts
+	replicaPort uint32
+	clientPort  uint32
 }
 
 // Run runs the worker until it receives a command to quit.
@@ -74,10 +92,19 @@ func (w *Worker) Run() error {
 			res, err = w.startReplicas(req)
 		case *orchestrationpb.StopReplicaRequest:
 			res, err = w.stopReplicas(req)
+		case *orchestrationpb.CrashReplicaRequest:
+			res, err = w.crashReplicas(req)
+		case *orchestrationpb.RestartReplicaRequest:
+			res, err = w.restartReplicas(req)
+		case *orchestrationpb.ReconfigureReplicaRequest:
+			res, err = w.reconfigureReplicas(req)
 		case *orchestrationpb.StartClientRequest:
 			res, err = w.startClients(req)
 		case *orchestrationpb.StopClientRequest:
 			res, err = w.stopClients(req)
+		case *orchestrationpb.StreamMeasurementsRequest:
+			w.streaming.Store(true)
+			res = &orchestrationpb.StreamMeasurementsResponse{}
 		case *orchestrationpb.QuitRequest:
 			return nil
 		}
@@ -96,56 +123,74 @@ func (w *Worker) Run() error {
 
 // NewWorker returns a new worker.
 func NewWorker(send *protostream.Writer, recv *protostream.Reader, dl metrics.Logger, metrics []string, measurementInterval time.Duration) Worker {
+	streaming := new(atomic.Bool)
 	return Worker{
 		send:                send,
 		recv:                recv,
-		metricsLogger:       dl,
+		metricsLogger:       &streamingLogger{Logger: dl, send: send, streaming: streaming},
+		streaming:           streaming,
 		metrics:             metrics,
 		measurementInterval: measurementInterval,
 		replicas:            make(map[hotstuff.ID]*replica.Replica),
 		clients:             make(map[hotstuff.ID]*client.Client),
+		created:             make(map[hotstuff.ID]createdReplica),
 	}
 }
 
+// SetOpenMetrics makes the replicas and clients that the worker creates record their metrics in the given exporter.
+func (w *Worker) SetOpenMetrics(om *metrics.OpenMetrics) {
+	w.openMetrics = om
+}
+
 func (w *Worker) createReplicas(req *orchestrationpb.CreateReplicaRequest) (*orchestrationpb.CreateReplicaResponse, error) {
 	resp := &orchestrationpb.CreateReplicaResponse{Replicas: make(map[uint32]*orchestrationpb.ReplicaInfo)}
 	for _, cfg := range req.GetReplicas() {
-		r, err := w.createReplica(cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create replica: %w", err)
-		}
-
-		// set up listeners and get the ports
-		replicaListener, err := net.Listen("tcp", ":0")
-		if err != nil {
-			return nil, fmt.Errorf("failed to create listener: %w", err)
-		}
-		replicaPort, err := getPort(replicaListener)
-		if err != nil {
-			return nil, err
-		}
-		clientListener, err := net.Listen("tcp", ":0")
-		if err != nil {
-			return nil, fmt.Errorf("failed to create listener: %w", err)
-		}
-		clientPort, err := getPort(clientListener)
+		created, err := w.startReplicaServers(createdReplica{opts: cfg})
 		if err != nil {
 			return nil, err
 		}
-
-		r.StartServers(replicaListener, clientListener)
-		w.replicas[hotstuff.ID(cfg.GetID())] = r
-
 		resp.Replicas[cfg.GetID()] = &orchestrationpb.ReplicaInfo{
 			ID:          cfg.GetID(),
 			PublicKey:   cfg.GetPublicKey(),
-			ReplicaPort: replicaPort,
-			ClientPort:  clientPort,
+			ReplicaPort: created.replicaPort,
+			ClientPort:  created.clientPort,
 		}
 	}
 	return resp, nil
 }
 
+// startReplicaServers creates the replica and starts its servers on the given ports, or on any free ports if zero.
+func (w *Worker) startReplicaServers(created createdReplica) (createdReplica, error) {
+	r, err := w.createReplica(created.opts)
+	if err != nil {
+		return created, fmt.Errorf("failed to create replica: %w", err)
+	}
+
+	// set up listeners and get the ports
+	replicaListener, err := net.Listen("tcp", fmt.Sprintf(":%d", created.replicaPort))
+	if err != nil {
+		return created, fmt.Errorf("failed to create listener: %w", err)
+	}
+	created.replicaPort, err = getPort(replicaListener)
+	if err != nil {
+		return created, err
+	}
+	clientListener, err := net.Listen("tcp", fmt.Sprintf(":%d", created.clientPort))
+	if err != nil {
+		return created, fmt.Errorf("failed to create listener: %w", err)
+	}
+	created.clientPort, err = getPort(clientListener)
+	if err != nil {
+		return created, err
+	}
+
+	r.StartServers(replicaListener, clientListener)
+	id := hotstuff.ID(created.opts.GetID())
+	w.replicas[id] = r
+	w.created[id] = created
+	return created, nil
+}
+
 func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Replica, error) {
 	w.metricsLogger.Log(opts)
 
@@ -172,9 +217,13 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 		return nil, fmt.Errorf("invalid consensus name: '%s'", opts.GetConsensus())
 	}
 
+	var byzantineModules []any
 	if opts.GetByzantineStrategy() != "" {
 		if byz, ok := modules.GetModule[byzantine.Byzantine](opts.GetByzantineStrategy()); ok {
 			consensusRules = byz.Wrap(consensusRules)
+			if ext, ok := byz.(byzantine.Extension); ok {
+				byzantineModules = ext.Extensions()
+			}
 		} else {
 			return nil, fmt.Errorf("invalid byzantine strategy: '%s'", opts.GetByzantineStrategy())
 		}
@@ -204,15 +253,53 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 		leaderRotation,
 		sync,
 		w.metricsLogger,
-		blockchain.New(),
 		logging.New("hs"+strconv.Itoa(int(opts.GetID()))),
 	)
+	if dir := opts.GetDataDir(); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		chain, err := persistent.New(filepath.Join(dir, fmt.Sprintf("replica-%d.log", opts.GetID())))
+		if err != nil {
+			return nil, err
+		}
+		builder.Add(chain)
+	} else {
+		builder.Add(blockchain.New())
+	}
+	builder.Add(byzantineModules...)
 	builder.Options().SetSharedRandomSeed(opts.GetSharedSeed())
+	if len(opts.GetTreePositions()) > 0 {
+		treePositions := make([]hotstuff.ID, len(opts.GetTreePositions()))
+		for i, id := range opts.GetTreePositions() {
+			treePositions[i] = hotstuff.ID(id)
+		}
+		builder.Options().SetTreePositions(treePositions)
+	}
+	builder.Options().SetTreeDelta(opts.GetTreeDelta().AsDuration())
+	builder.Options().SetCheckpointInterval(uint64(opts.GetCheckpointInterval()))
+	if caPEM := opts.GetCertificateAuthority(); len(caPEM) > 0 {
+		// the controller signs the reconfigurations that it requests with the certificate authority's key
+		operatorKey, err := parseOperatorKey(caPEM)
+		if err != nil {
+			return nil, err
+		}
+		builder.Options().SetOperatorKey(operatorKey)
+	}
+	if name := opts.GetLeafPlacement(); name != "" {
+		if !kauri.IsLeafPlacement(name) {
+			return nil, fmt.Errorf("no leaf placement named '%s'", name)
+		}
+		builder.Options().SetLeafPlacement(name)
+	}
 	if w.measurementInterval > 0 {
 		replicaMetrics := metrics.GetReplicaMetrics(w.metrics...)
 		builder.Add(replicaMetrics...)
 		builder.Add(metrics.NewTicker(w.measurementInterval))
 	}
+	if w.openMetrics != nil {
+		builder.Add(w.openMetrics.ReplicaModule())
+	}
 
 	for _, n := range opts.GetModules() {
 		m, ok := modules.GetModuleUntyped(n)
@@ -265,10 +352,88 @@ func (w *Worker) startReplicas(req *orchestrationpb.StartReplicaRequest) (*orche
 	return &orchestrationpb.StartReplicaResponse{}, nil
 }
 
+// crashReplicas stops the replicas without reporting their state. They can be restarted by restartReplicas.
+func (w *Worker) crashReplicas(req *orchestrationpb.CrashReplicaRequest) (*orchestrationpb.CrashReplicaResponse, error) {
+	for _, id := range req.GetIDs() {
+		r, ok := w.replicas[hotstuff.ID(id)]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "The replica with id %d was not found.", id)
+		}
+		r.Stop()
+		delete(w.replicas, hotstuff.ID(id))
+	}
+	return &orchestrationpb.CrashReplicaResponse{}, nil
+}
+
+// restartReplicas creates the crashed replicas again with the same options and ports, and starts them.
+// The replicas recover the state in their data directories.
+func (w *Worker) restartReplicas(req *orchestrationpb.RestartReplicaRequest) (*orchestrationpb.RestartReplicaResponse, error) {
+	cfg, err := getConfiguration(req.GetConfiguration(), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range req.GetIDs() {
+		created, ok := w.created[hotstuff.ID(id)]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "The replica with id %d was not found.", id)
+		}
+		if _, running := w.replicas[hotstuff.ID(id)]; running {
+			return nil, status.Errorf(codes.FailedPrecondition, "The replica with id %d has not crashed.", id)
+		}
+		if _, err := w.startReplicaServers(created); err != nil {
+			return nil, err
+		}
+		r := w.replicas[hotstuff.ID(id)]
+		if err := r.Connect(cfg); err != nil {
+			return nil, err
+		}
+		w.metricsLogger.Log(&types.StartEvent{Event: types.NewReplicaEvent(id, time.Now())})
+		r.Start()
+	}
+	return &orchestrationpb.RestartReplicaResponse{}, nil
+}
+
+// parseOperatorKey returns the ECDSA public key of the PEM encoded certificate authority.
+func parseOperatorKey(caPEM []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(caPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the certificate authority")
+	}
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate authority: %w", err)
+	}
+	key, ok := ca.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("the certificate authority does not have an ECDSA key")
+	}
+	return key, nil
+}
+
+// reconfigureReplicas asks the running replicas to propose the reconfiguration when they are the leader.
+// Crashed replicas are skipped.
+func (w *Worker) reconfigureReplicas(req *orchestrationpb.ReconfigureReplicaRequest) (*orchestrationpb.ReconfigureReplicaResponse, error) {
+	request := hotstuff.ReconfigurationRequest{
+		Certificate: hotstuff.OperatorCertificate{Serial: req.GetSerial(), Signature: req.GetSignature()},
+	}
+	for _, id := range req.GetActiveReplicas() {
+		request.ActiveReplicas = append(request.ActiveReplicas, hotstuff.ID(id))
+	}
+	for _, id := range req.GetIDs() {
+		if r, ok := w.replicas[hotstuff.ID(id)]; ok {
+			var eventLoop *eventloop.EventLoop
+			r.Modules().Get(&eventLoop)
+			eventLoop.AddEvent(request)
+		}
+	}
+	return &orchestrationpb.ReconfigureReplicaResponse{}, nil
+}
+
 func (w *Worker) stopReplicas(req *orchestrationpb.StopReplicaRequest) (*orchestrationpb.StopReplicaResponse, error) {
 	res := &orchestrationpb.StopReplicaResponse{
-		Hashes: make(map[uint32][]byte),
-		Counts: make(map[uint32]uint32),
+		Hashes:  make(map[uint32][]byte),
+		Counts:  make(map[uint32]uint32),
+		Digests: make(map[uint32][]byte),
 	}
 	for _, id := range req.GetIDs() {
 		r, ok := w.replicas[hotstuff.ID(id)]
@@ -278,6 +443,7 @@ func (w *Worker) stopReplicas(req *orchestrationpb.StopReplicaRequest) (*orchest
 		r.Stop()
 		res.Hashes[id] = r.GetHash()
 		res.Counts[id] = r.GetCmdCount()
+		res.Digests[id] = r.GetStateDigest()
 		// TODO: return test results
 	}
 	return res, nil
@@ -288,7 +454,19 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 	cp := x509.NewCertPool()
 	cp.AppendCertsFromPEM(ca)
 	for _, opts := range req.GetClients() {
-		w.metricsLogger.Log(opts)
+		if len(opts.GetTrace()) > 0 {
+			// the trace can be large, and is the same for all clients
+			logged := proto.Clone(opts).(*orchestrationpb.ClientOpts)
+			logged.Trace = nil
+			w.metricsLogger.Log(logged)
+		} else {
+			w.metricsLogger.Log(opts)
+		}
+
+		workload, err := newWorkload(opts)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "client %d: %v", opts.GetID(), err)
+		}
 
 		c := client.Config{
 			TLS:           opts.GetUseTLS(),
@@ -304,6 +482,7 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 			RateStep:         opts.GetRateStep(),
 			RateStepInterval: opts.GetRateStepInterval().AsDuration(),
 			Timeout:          opts.GetTimeout().AsDuration(),
+			Workload:         workload,
 		}
 		mods := modules.NewBuilder(hotstuff.ID(opts.GetID()), nil)
 		mods.Add(eventloop.New(1000))
@@ -313,6 +492,9 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 			mods.Add(clientMetrics...)
 			mods.Add(metrics.NewTicker(w.measurementInterval))
 		}
+		if w.openMetrics != nil {
+			mods.Add(w.openMetrics.ClientModule())
+		}
 
 		mods.Add(w.metricsLogger)
 		mods.Add(logging.New("cli" + strconv.Itoa(int(opts.GetID()))))
@@ -332,6 +514,32 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 	return &orchestrationpb.StartClientResponse{}, nil
 }
 
+func newWorkload(opts *orchestrationpb.ClientOpts) (client.Workload, error) {
+	var trace []client.TraceEntry
+	if opts.GetWorkload() == "trace" {
+		var err error
+		trace, err = client.ParseTrace(bytes.NewReader(opts.GetTrace()), opts.GetID())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client.NewWorkload(client.WorkloadConfig{
+		Name:             opts.GetWorkload(),
+		ClientID:         opts.GetID(),
+		PayloadSize:      opts.GetPayloadSize(),
+		RateLimit:        opts.GetRateLimit(),
+		RateStep:         opts.GetRateStep(),
+		RateStepInterval: opts.GetRateStepInterval().AsDuration(),
+		RateSkew:         opts.GetRateSkew(),
+		BurstOn:          opts.GetBurstOn().AsDuration(),
+		BurstOff:         opts.GetBurstOff().AsDuration(),
+		Trace:            trace,
+		Seed:             time.Now().UnixNano() + int64(opts.GetID()),
+		KVKeys:           opts.GetKVKeys(),
+		KVReads:          opts.GetKVReads(),
+	})
+}
+
 func (w *Worker) stopClients(req *orchestrationpb.StopClientRequest) (*orchestrationpb.StopClientResponse, error) {
 	for _, id := range req.GetIDs() {
 		cli, ok := w.clients[hotstuff.ID(id)]
@@ -376,3 +584,27 @@ func getPort(lis net.Listener) (uint32, error) {
 	}
 	return uint32(port), nil
 }
+
+// streamingLogger is a metrics logger that also sends the measurements to the controller,
+// once the controller has requested them.
+type streamingLogger struct {
+	metrics.Logger
+	send      *protostream.Writer
+	streaming *atomic.Bool
+}
+
+// InitModule initializes the underlying logger, if it is a module.
+func (l *streamingLogger) InitModule(mods *modules.Core) {
+	if m, ok := l.Logger.(modules.Module); ok {
+		m.InitModule(mods)
+	}
+}
+
+func (l *streamingLogger) Log(msg proto.Message) {
+	l.Logger.Log(msg)
+	if _, ok := msg.(interface{ GetEvent() *types.Event }); !ok || !l.streaming.Load() {
+		return
+	}
+	// if the stream is broken, the worker fails when it sends its next response
+	_ = l.send.WriteAny(msg)
+}
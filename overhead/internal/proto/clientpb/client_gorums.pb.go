@@ -12,7 +12,6 @@ import (
 	gorums "github.com/relab/gorums"
 	encoding "google.golang.org/grpc/encoding"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
@@ -146,21 +145,21 @@ type Node struct {
 
 // ExecCommand sends a command to all replicas and waits for valid signatures
 // from f+1 replicas
-func (c *Configuration) ExecCommand(ctx context.Context, in *Command) *AsyncEmpty {
+func (c *Configuration) ExecCommand(ctx context.Context, in *Command) *AsyncResult {
 	cd := gorums.QuorumCallData{
 		Message: in,
 		Method:  "clientpb.Client.ExecCommand",
 	}
 	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
-		r := make(map[uint32]*emptypb.Empty, len(replies))
+		r := make(map[uint32]*Result, len(replies))
 		for k, v := range replies {
-			r[k] = v.(*emptypb.Empty)
+			r[k] = v.(*Result)
 		}
 		return c.qspec.ExecCommandQF(req.(*Command), r)
 	}
 
 	fut := c.RawConfiguration.AsyncCall(ctx, cd)
-	return &AsyncEmpty{fut}
+	return &AsyncResult{fut}
 }
 
 // QuorumSpec is the interface of quorum functions for Client.
@@ -172,12 +171,12 @@ type QuorumSpec interface {
 	// supplied to the ExecCommand method at call time, and may or may not
 	// be used by the quorum function. If the in parameter is not needed
 	// you should implement your quorum function with '_ *Command'.
-	ExecCommandQF(in *Command, replies map[uint32]*emptypb.Empty) (*emptypb.Empty, bool)
+	ExecCommandQF(in *Command, replies map[uint32]*Result) (*Result, bool)
 }
 
 // Client is the server-side API for the Client Service
 type Client interface {
-	ExecCommand(ctx gorums.ServerCtx, request *Command) (response *emptypb.Empty, err error)
+	ExecCommand(ctx gorums.ServerCtx, request *Command) (response *Result, err error)
 }
 
 func RegisterClientServer(srv *gorums.Server, impl Client) {
@@ -189,23 +188,23 @@ func RegisterClientServer(srv *gorums.Server, impl Client) {
 	})
 }
 
-type internalEmpty struct {
+type internalResult struct {
 	nid   uint32
-	reply *emptypb.Empty
+	reply *Result
 	err   error
 }
 
-// AsyncEmpty is a async object for processing replies.
-type AsyncEmpty struct {
+// AsyncResult is a async object for processing replies.
+type AsyncResult struct {
 	*gorums.Async
 }
 
 // Get returns the reply and any error associated with the called method.
 // The method blocks until a reply or error is available.
-func (f *AsyncEmpty) Get() (*emptypb.Empty, error) {
+func (f *AsyncResult) Get() (*Result, error) {
 	resp, err := f.Async.Get()
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*emptypb.Empty), err
+	return resp.(*Result), err
 }
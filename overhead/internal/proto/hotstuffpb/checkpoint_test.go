@@ -0,0 +1,46 @@
+package hotstuffpb
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+)
+
+func TestConvertCheckpointSnapshot(t *testing.T) {
+	block := hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash(), make([]uint32, 0)), "", 1, 1, time.Now())
+	checkpoint := hotstuff.Checkpoint{
+		Height:         10,
+		View:           block.View(),
+		Block:          block.Hash(),
+		ActiveReplicas: []hotstuff.ID{1, 2, 3},
+		StateDigest:    []byte("state"),
+	}
+	var sigs []*ecdsa.Signature
+	for id := hotstuff.ID(1); id <= 3; id++ {
+		sigs = append(sigs, ecdsa.RestoreSignature(big.NewInt(int64(id)), big.NewInt(int64(10+id)), id))
+	}
+	want := hotstuff.CheckpointSnapshot{
+		Cert:           hotstuff.CheckpointCert{Checkpoint: checkpoint, Signature: ecdsa.RestoreMultiSignature(sigs)},
+		Block:          block,
+		ExecutionState: []byte("execution state"),
+	}
+
+	got := CheckpointSnapshotFromProto(CheckpointSnapshotToProto(want))
+
+	if !got.Cert.Checkpoint.Equals(want.Cert.Checkpoint) {
+		t.Errorf("got checkpoint %v, want %v", got.Cert.Checkpoint, want.Cert.Checkpoint)
+	}
+	if !bytes.Equal(got.Cert.Signature.ToBytes(), want.Cert.Signature.ToBytes()) {
+		t.Error("Signatures don't match.")
+	}
+	if got.Block.Hash() != want.Block.Hash() {
+		t.Error("Hashes don't match.")
+	}
+	if !bytes.Equal(got.ExecutionState, want.ExecutionState) || got.RankingState != nil {
+		t.Error("States don't match.")
+	}
+}
@@ -0,0 +1,35 @@
+package hotstuffpb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+)
+
+func TestConvertBlockComplaints(t *testing.T) {
+	qc := hotstuff.NewQuorumCert(nil, 0, hotstuff.Hash{}, make([]uint32, 0))
+	complaints := []*hotstuff.Complaint{
+		{ID: 1, Complainant: 2, Complainee: 3, ComplaintType: hotstuff.Suspicion},
+		{ID: 2, Complainant: 2, Complainee: 4, ComplaintType: hotstuff.Suspicion},
+	}
+	want := hotstuff.NewBlockWithComplaints(hotstuff.GetGenesis().Hash(), qc, "", 1, 2, time.Now(), complaints)
+	got := BlockFromProto(BlockToProto(want))
+
+	if want.Hash() != got.Hash() {
+		t.Error("Hashes don't match.")
+	}
+	if len(got.Complaints()) != len(complaints) {
+		t.Fatalf("got %d complaints, want %d", len(got.Complaints()), len(complaints))
+	}
+	for i, c := range got.Complaints() {
+		if c.ID != complaints[i].ID || c.Complainee != complaints[i].Complainee || c.ComplaintType != complaints[i].ComplaintType {
+			t.Errorf("complaint %d = %+v, want %+v", i, c, complaints[i])
+		}
+	}
+	// the complaints are part of the hash, so a block without them is a different block
+	without := hotstuff.NewBlock(want.Parent(), qc, "", 1, 2, want.Time())
+	if without.Hash() == want.Hash() {
+		t.Error("the hash of the block does not cover its complaints")
+	}
+}
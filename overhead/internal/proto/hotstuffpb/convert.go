@@ -3,6 +3,7 @@ package hotstuffpb
 
 import (
 	"math/big"
+	"slices"
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/crypto"
@@ -16,8 +17,15 @@ func QuorumSignatureToProto(sig hotstuff.QuorumSignature) *QuorumSignature {
 	signature := &QuorumSignature{}
 	switch s := sig.(type) {
 	case ecdsa.MultiSignature:
+		// sort by signer to make the encoding deterministic
+		order := make([]hotstuff.ID, 0, len(s))
+		for id := range s {
+			order = append(order, id)
+		}
+		slices.Sort(order)
 		sigs := make([]*ECDSASignature, 0, len(s))
-		for _, p := range s {
+		for _, id := range order {
+			p := s[id]
 			sigs = append(sigs, &ECDSASignature{
 				Signer: uint32(p.Signer()),
 				R:      p.R().Bytes(),
@@ -102,22 +110,12 @@ func ProposalToProto(proposal hotstuff.ProposeMsg) *Proposal {
 	if proposal.AggregateQC != nil {
 		p.AggQC = AggregateQCToProto(*proposal.AggregateQC)
 	}
-	complaints := make([]*Complaint, 0)
-	for _, complaint := range proposal.Complaints {
-		complaints = append(complaints, ComplaintToProto(*complaint))
-	}
-	p.Complaints = complaints
 	return p
 }
 
 // ProposalFromProto converts a protobuf message to a ProposeMsg.
 func ProposalFromProto(p *Proposal) (proposal hotstuff.ProposeMsg) {
 	proposal.Block = BlockFromProto(p.GetBlock())
-	complaints := make([]*hotstuff.Complaint, 0)
-	for _, complaint := range p.Complaints {
-		complaints = append(complaints, ComplaintFromProto(complaint))
-	}
-	proposal.Complaints = complaints
 	if p.GetAggQC() != nil {
 		aggQC := AggregateQCFromProto(p.GetAggQC())
 		proposal.AggregateQC = &aggQC
@@ -128,13 +126,18 @@ func ProposalFromProto(p *Proposal) (proposal hotstuff.ProposeMsg) {
 // BlockToProto converts a consensus.Block to a hotstuffpb.Block.
 func BlockToProto(block *hotstuff.Block) *Block {
 	parentHash := block.Parent()
+	var complaints []*Complaint
+	for _, complaint := range block.Complaints() {
+		complaints = append(complaints, ComplaintToProto(*complaint))
+	}
 	return &Block{
-		Parent:    parentHash[:],
-		Command:   []byte(block.Command()),
-		QC:        QuorumCertToProto(block.QuorumCert()),
-		View:      uint64(block.View()),
-		Proposer:  uint32(block.Proposer()),
-		Timestamp: timestamppb.New(block.Time()),
+		Parent:     parentHash[:],
+		Command:    []byte(block.Command()),
+		QC:         QuorumCertToProto(block.QuorumCert()),
+		View:       uint64(block.View()),
+		Proposer:   uint32(block.Proposer()),
+		Timestamp:  timestamppb.New(block.Time()),
+		Complaints: complaints,
 	}
 }
 
@@ -142,13 +145,18 @@ func BlockToProto(block *hotstuff.Block) *Block {
 func BlockFromProto(block *Block) *hotstuff.Block {
 	var p hotstuff.Hash
 	copy(p[:], block.GetParent())
-	return hotstuff.NewBlock(
+	var complaints []*hotstuff.Complaint
+	for _, complaint := range block.GetComplaints() {
+		complaints = append(complaints, ComplaintFromProto(complaint))
+	}
+	return hotstuff.NewBlockWithComplaints(
 		p,
 		QuorumCertFromProto(block.GetQC()),
 		hotstuff.Command(block.GetCommand()),
 		hotstuff.View(block.GetView()),
 		hotstuff.ID(block.GetProposer()),
 		block.Timestamp.AsTime(),
+		complaints,
 	)
 }
 
@@ -281,8 +289,10 @@ func ReconfigurationToProto(reconfiguration hotstuff.ReconfigurationMsg) *Reconf
 
 func ComplaintToProto(complaint hotstuff.Complaint) *Complaint {
 	m := &Complaint{}
+	m.ID = complaint.ID
 	m.Complainant = uint32(complaint.Complainant)
 	m.Complainee = uint32(complaint.Complainee)
+	m.Type = ComplaintType(complaint.ComplaintType)
 	if m.GetType() == ComplaintType_InvalidProposal {
 		m.Proof = &Complaint_Proposal{Proposal: ProposalToProto(complaint.Proof.(hotstuff.ProposeMsg))}
 	} else if m.GetType() == ComplaintType_InvalidQuorumCert {
@@ -306,6 +316,7 @@ func ComplaintFromProto(complaint *Complaint) *hotstuff.Complaint {
 	}
 
 	ret := hotstuff.Complaint{
+		ID:            complaint.GetID(),
 		Complainee:    hotstuff.ID(complaint.Complainee),
 		Complainant:   hotstuff.ID(complaint.Complainant),
 		ComplaintType: int(complaint.Type),
@@ -313,3 +324,84 @@ func ComplaintFromProto(complaint *Complaint) *hotstuff.Complaint {
 	}
 	return &ret
 }
+
+// CheckpointToProto converts a hotstuff.Checkpoint to a hotstuffpb.Checkpoint.
+func CheckpointToProto(checkpoint hotstuff.Checkpoint) *Checkpoint {
+	var activeReplicas []uint32
+	for _, id := range checkpoint.ActiveReplicas {
+		activeReplicas = append(activeReplicas, uint32(id))
+	}
+	return &Checkpoint{
+		Height:         checkpoint.Height,
+		View:           uint64(checkpoint.View),
+		Block:          checkpoint.Block[:],
+		ActiveReplicas: activeReplicas,
+		StateDigest:    checkpoint.StateDigest,
+		RankingDigest:  checkpoint.RankingDigest,
+	}
+}
+
+// CheckpointFromProto converts a hotstuffpb.Checkpoint to a hotstuff.Checkpoint.
+func CheckpointFromProto(checkpoint *Checkpoint) hotstuff.Checkpoint {
+	var activeReplicas []hotstuff.ID
+	for _, id := range checkpoint.GetActiveReplicas() {
+		activeReplicas = append(activeReplicas, hotstuff.ID(id))
+	}
+	var block hotstuff.Hash
+	copy(block[:], checkpoint.GetBlock())
+	return hotstuff.Checkpoint{
+		Height:         checkpoint.GetHeight(),
+		View:           hotstuff.View(checkpoint.GetView()),
+		Block:          block,
+		ActiveReplicas: activeReplicas,
+		StateDigest:    checkpoint.GetStateDigest(),
+		RankingDigest:  checkpoint.GetRankingDigest(),
+	}
+}
+
+// CheckpointMsgToProto converts a replica's signature of a checkpoint to a hotstuffpb.SignedCheckpoint.
+func CheckpointMsgToProto(msg hotstuff.CheckpointMsg) *SignedCheckpoint {
+	return &SignedCheckpoint{
+		Checkpoint: CheckpointToProto(msg.Checkpoint),
+		Sig:        QuorumSignatureToProto(msg.Signature),
+	}
+}
+
+// CheckpointMsgFromProto converts a hotstuffpb.SignedCheckpoint to a replica's signature of a checkpoint.
+// The ID of the sender is not set.
+func CheckpointMsgFromProto(msg *SignedCheckpoint) hotstuff.CheckpointMsg {
+	return hotstuff.CheckpointMsg{
+		Checkpoint: CheckpointFromProto(msg.GetCheckpoint()),
+		Signature:  QuorumSignatureFromProto(msg.GetSig()),
+	}
+}
+
+// CheckpointSnapshotToProto converts a hotstuff.CheckpointSnapshot to a hotstuffpb.CheckpointSnapshot.
+func CheckpointSnapshotToProto(snapshot hotstuff.CheckpointSnapshot) *CheckpointSnapshot {
+	return &CheckpointSnapshot{
+		Cert: &SignedCheckpoint{
+			Checkpoint: CheckpointToProto(snapshot.Cert.Checkpoint),
+			Sig:        QuorumSignatureToProto(snapshot.Cert.Signature),
+		},
+		Block:          BlockToProto(snapshot.Block),
+		ExecutionState: snapshot.ExecutionState,
+		RankingState:   snapshot.RankingState,
+	}
+}
+
+// CheckpointSnapshotFromProto converts a hotstuffpb.CheckpointSnapshot to a hotstuff.CheckpointSnapshot.
+func CheckpointSnapshotFromProto(snapshot *CheckpointSnapshot) hotstuff.CheckpointSnapshot {
+	var block *hotstuff.Block
+	if snapshot.GetBlock() != nil {
+		block = BlockFromProto(snapshot.GetBlock())
+	}
+	return hotstuff.CheckpointSnapshot{
+		Cert: hotstuff.CheckpointCert{
+			Checkpoint: CheckpointFromProto(snapshot.GetCert().GetCheckpoint()),
+			Signature:  QuorumSignatureFromProto(snapshot.GetCert().GetSig()),
+		},
+		Block:          block,
+		ExecutionState: snapshot.GetExecutionState(),
+		RankingState:   snapshot.GetRankingState(),
+	}
+}
@@ -201,6 +201,20 @@ func (c *Configuration) ReconfigurationRequest(ctx context.Context, in *Reconfig
 	c.RawConfiguration.Multicast(ctx, cd, opts...)
 }
 
+// Reference imports to suppress errors if they are not otherwise used.
+var _ empty.Empty
+
+// Checkpoint is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (c *Configuration) Checkpoint(ctx context.Context, in *SignedCheckpoint, opts ...gorums.CallOption) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.Checkpoint",
+	}
+
+	c.RawConfiguration.Multicast(ctx, cd, opts...)
+}
+
 // QuorumSpec is the interface of quorum functions for Hotstuff.
 type QuorumSpec interface {
 	gorums.ConfigOption
@@ -211,6 +225,20 @@ type QuorumSpec interface {
 	// be used by the quorum function. If the in parameter is not needed
 	// you should implement your quorum function with '_ *BlockHash'.
 	FetchQF(in *BlockHash, replies map[uint32]*Block) (*Block, bool)
+
+	// FetchRangeQF is the quorum function for the FetchRange
+	// quorum call method. The in parameter is the request object
+	// supplied to the FetchRange method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *BlockRange'.
+	FetchRangeQF(in *BlockRange, replies map[uint32]*Blocks) (*Blocks, bool)
+
+	// FetchCheckpointQF is the quorum function for the FetchCheckpoint
+	// quorum call method. The in parameter is the request object
+	// supplied to the FetchCheckpoint method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *CheckpointRequest'.
+	FetchCheckpointQF(in *CheckpointRequest, replies map[uint32]*CheckpointSnapshot) (*CheckpointSnapshot, bool)
 }
 
 // Fetch is a quorum call invoked on all nodes in configuration c,
@@ -235,6 +263,50 @@ func (c *Configuration) Fetch(ctx context.Context, in *BlockHash) (resp *Block,
 	return res.(*Block), err
 }
 
+// FetchRange is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (c *Configuration) FetchRange(ctx context.Context, in *BlockRange) (resp *Blocks, err error) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.FetchRange",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*Blocks, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*Blocks)
+		}
+		return c.qspec.FetchRangeQF(req.(*BlockRange), r)
+	}
+
+	res, err := c.RawConfiguration.QuorumCall(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Blocks), err
+}
+
+// FetchCheckpoint is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (c *Configuration) FetchCheckpoint(ctx context.Context, in *CheckpointRequest) (resp *CheckpointSnapshot, err error) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.FetchCheckpoint",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*CheckpointSnapshot, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*CheckpointSnapshot)
+		}
+		return c.qspec.FetchCheckpointQF(req.(*CheckpointRequest), r)
+	}
+
+	res, err := c.RawConfiguration.QuorumCall(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*CheckpointSnapshot), err
+}
+
 // Hotstuff is the server-side API for the Hotstuff Service
 type Hotstuff interface {
 	Propose(ctx gorums.ServerCtx, request *Proposal)
@@ -244,6 +316,9 @@ type Hotstuff interface {
 	Update(ctx gorums.ServerCtx, request *UpdateMsg)
 	ReconfigurationRequest(ctx gorums.ServerCtx, request *ReconfigurationMsg)
 	Fetch(ctx gorums.ServerCtx, request *BlockHash) (response *Block, err error)
+	FetchRange(ctx gorums.ServerCtx, request *BlockRange) (response *Blocks, err error)
+	Checkpoint(ctx gorums.ServerCtx, request *SignedCheckpoint)
+	FetchCheckpoint(ctx gorums.ServerCtx, request *CheckpointRequest) (response *CheckpointSnapshot, err error)
 }
 
 func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
@@ -283,6 +358,23 @@ func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
 		resp, err := impl.Fetch(ctx, req)
 		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
 	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.FetchRange", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*BlockRange)
+		defer ctx.Release()
+		resp, err := impl.FetchRange(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.Checkpoint", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*SignedCheckpoint)
+		defer ctx.Release()
+		impl.Checkpoint(ctx, req)
+	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.FetchCheckpoint", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*CheckpointRequest)
+		defer ctx.Release()
+		resp, err := impl.FetchCheckpoint(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
 }
 
 type internalBlock struct {
@@ -291,6 +383,18 @@ type internalBlock struct {
 	err   error
 }
 
+type internalBlocks struct {
+	nid   uint32
+	reply *Blocks
+	err   error
+}
+
+type internalCheckpointSnapshot struct {
+	nid   uint32
+	reply *CheckpointSnapshot
+	err   error
+}
+
 // Reference imports to suppress errors if they are not otherwise used.
 var _ empty.Empty
 
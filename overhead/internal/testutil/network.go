@@ -0,0 +1,221 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/modules"
+)
+
+// network is an in-memory network that delivers the messages of the replicas to the event loops of the receivers.
+// It does not depend on the backend, such that the tests of the backend and the protobuf conversions can use it.
+type network struct {
+	nodes map[hotstuff.ID]*node
+}
+
+func newNetwork() *network {
+	return &network{nodes: make(map[hotstuff.ID]*node)}
+}
+
+// nodeBuilder returns a builder for the replica with the given ID, which is connected to the network.
+func (n *network) nodeBuilder(id hotstuff.ID, pk hotstuff.PrivateKey) modules.Builder {
+	node := &node{}
+	n.nodes[id] = node
+	builder := modules.NewBuilder(id, pk)
+	// register node as an anonymous module because that allows configuration to obtain it.
+	builder.Add(node)
+	return builder
+}
+
+func (n *network) newConfiguration() modules.Configuration {
+	return &configuration{network: n}
+}
+
+// ids returns the IDs of the replicas in the network in order.
+func (n *network) ids() []hotstuff.ID {
+	ids := make([]hotstuff.ID, 0, len(n.nodes))
+	for id := range n.nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type node struct {
+	blockChain modules.BlockChain
+	eventLoop  *eventloop.EventLoop
+	opts       *modules.Options
+}
+
+func (n *node) InitModule(mods *modules.Core) {
+	mods.Get(
+		&n.blockChain,
+		&n.eventLoop,
+		&n.opts,
+	)
+}
+
+type configuration struct {
+	node      *node
+	network   *network
+	subConfig hotstuff.IDSet
+}
+
+// alternative way to get a pointer to the node.
+func (c *configuration) InitModule(mods *modules.Core) {
+	if c.node == nil {
+		mods.TryGet(&c.node)
+	}
+}
+
+func (c *configuration) broadcastMessage(message any) {
+	for id := range c.network.nodes {
+		if id == c.node.opts.ID() {
+			continue
+		} else if c.subConfig == nil || c.subConfig.Contains(id) {
+			c.sendMessage(id, message)
+		}
+	}
+}
+
+func (c *configuration) sendMessage(id hotstuff.ID, message any) {
+	if node, ok := c.network.nodes[id]; ok {
+		node.eventLoop.AddEvent(message)
+	}
+}
+
+// Replicas returns all of the replicas in the configuration.
+func (c *configuration) Replicas() map[hotstuff.ID]modules.Replica {
+	m := make(map[hotstuff.ID]modules.Replica)
+	for id := range c.network.nodes {
+		m[id] = &replica{config: c, id: id}
+	}
+	return m
+}
+
+// Replica returns a replica if present in the configuration.
+func (c *configuration) Replica(id hotstuff.ID) (r modules.Replica, ok bool) {
+	if _, ok = c.network.nodes[id]; ok {
+		return &replica{config: c, id: id}, true
+	}
+	return nil, false
+}
+
+// GetCommittees returns committees of the given size, numbered from 1, with the replicas in order of their IDs.
+func (c *configuration) GetCommittees(size int, _ bool) map[int][]hotstuff.ID {
+	ids := c.network.ids()
+	committees := make(map[int][]hotstuff.ID)
+	for i := 0; size > 0 && (i+1)*size <= len(ids); i++ {
+		committees[i+1] = ids[i*size : (i+1)*size]
+	}
+	return committees
+}
+
+// SubConfig returns a subconfiguration containing the replicas specified in the ids slice.
+func (c *configuration) SubConfig(ids []hotstuff.ID) (sub modules.Configuration, err error) {
+	subConfig := hotstuff.NewIDSet()
+	for _, id := range ids {
+		subConfig.Add(id)
+	}
+	return &configuration{
+		node:      c.node,
+		network:   c.network,
+		subConfig: subConfig,
+	}, nil
+}
+
+func (c *configuration) Reconfiguration(hotstuff.ReconfigurationMsg) {}
+
+func (c *configuration) Update(hotstuff.Block) {}
+
+// GetLatency returns 0, as the messages are delivered immediately.
+func (c *configuration) GetLatency(hotstuff.ID, hotstuff.ID) time.Duration {
+	return 0
+}
+
+// Len returns the number of replicas in the configuration.
+func (c *configuration) Len() int {
+	return len(c.network.nodes)
+}
+
+// QuorumSize returns the size of a quorum.
+func (c *configuration) QuorumSize(hotstuff.View) int {
+	return hotstuff.QuorumSize(c.Len())
+}
+
+// Propose sends the block to all replicas in the configuration.
+func (c *configuration) Propose(proposal hotstuff.ProposeMsg) {
+	c.broadcastMessage(proposal)
+}
+
+// Timeout sends the timeout message to all replicas.
+func (c *configuration) Timeout(msg hotstuff.TimeoutMsg) {
+	c.broadcastMessage(msg)
+}
+
+// Fetch requests a block from all the replicas in the configuration.
+func (c *configuration) Fetch(_ context.Context, hash hotstuff.Hash) (block *hotstuff.Block, ok bool) {
+	for _, id := range c.network.ids() {
+		block, ok = c.network.nodes[id].blockChain.LocalGet(hash)
+		if ok {
+			return block, true
+		}
+	}
+	return nil, false
+}
+
+type replica struct {
+	// pointer to the configuration of the node that wants to contact this replica.
+	config *configuration
+	// id of the replica.
+	id hotstuff.ID
+}
+
+// ID returns the replica's id.
+func (r *replica) ID() hotstuff.ID {
+	return r.id
+}
+
+// PublicKey returns the replica's public key.
+func (r *replica) PublicKey() hotstuff.PublicKey {
+	return r.config.network.nodes[r.id].opts.PrivateKey().Public()
+}
+
+// Vote sends the partial certificate to the other replica.
+func (r *replica) Vote(cert hotstuff.PartialCert) {
+	r.config.sendMessage(r.id, hotstuff.VoteMsg{
+		ID:          r.config.node.opts.ID(),
+		PartialCert: cert,
+	})
+}
+
+// NewView sends the quorum certificate to the other replica.
+func (r *replica) NewView(si hotstuff.SyncInfo) {
+	r.config.sendMessage(r.id, hotstuff.NewViewMsg{
+		ID:       r.config.node.opts.ID(),
+		SyncInfo: si,
+	})
+}
+
+func (r *replica) Metadata() map[string]string {
+	return r.config.network.nodes[r.id].opts.ConnectionMetadata()
+}
+
+func (r *replica) Active() bool {
+	return true
+}
+
+func (r *replica) SetActive(bool) {}
+
+type fixedDuration struct {
+	timeout time.Duration
+}
+
+func (d fixedDuration) Duration() time.Duration     { return d.timeout }
+func (d fixedDuration) ViewStarted()                {}
+func (d fixedDuration) ViewSucceeded()              {}
+func (d fixedDuration) ViewTimeout()                {}
+func (d fixedDuration) StartTimeout() time.Duration { return d.timeout }
@@ -23,7 +23,6 @@ import (
 	"github.com/relab/hotstuff/leaderrotation"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/synchronizer"
-	"github.com/relab/hotstuff/twins"
 )
 
 // TestModules registers default modules for testing to the given builder.
@@ -116,7 +115,7 @@ func (hl HotStuffList) Keys() (keys []hotstuff.PrivateKey) {
 // CreateBuilders creates n builders with default consensus. Configurations are initialized with replicas.
 func CreateBuilders(t *testing.T, ctrl *gomock.Controller, n int, keys ...hotstuff.PrivateKey) (builders BuilderList) {
 	t.Helper()
-	network := twins.NewSimpleNetwork()
+	network := newNetwork()
 	builders = make([]*modules.Builder, n)
 	for i := 0; i < n; i++ {
 		id := hotstuff.ID(i + 1)
@@ -127,10 +126,10 @@ func CreateBuilders(t *testing.T, ctrl *gomock.Controller, n int, keys ...hotstu
 			key = GenerateECDSAKey(t)
 		}
 
-		builder := network.GetNodeBuilder(twins.NodeID{ReplicaID: id, NetworkID: uint32(id)}, key)
-		builder.Add(network.NewConfiguration())
+		builder := network.nodeBuilder(id, key)
+		builder.Add(network.newConfiguration())
 		TestModules(t, ctrl, id, key, &builder)
-		builder.Add(network.NewConfiguration())
+		builder.Add(network.newConfiguration())
 		builders[i] = &builder
 	}
 	return builders
@@ -299,5 +298,5 @@ func NewLeaderRotation(t *testing.T, order ...hotstuff.ID) modules.LeaderRotatio
 
 // FixedTimeout returns an ExponentialTimeout with a max exponent of 0.
 func FixedTimeout(timeout time.Duration) synchronizer.ViewDuration {
-	return twins.FixedTimeout(timeout)
+	return fixedDuration{timeout}
 }
@@ -2,7 +2,6 @@
 package kauri
 
 import (
-	"context"
 	"encoding/binary"
 	"errors"
 	"math/rand"
@@ -10,7 +9,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/backend"
 	"github.com/relab/hotstuff/eventloop"
@@ -36,8 +34,8 @@ const (
 
 // Kauri structure contains the modules for kauri protocol implementation.
 type Kauri struct {
-	configuration          *backend.Config
-	server                 *backend.Server
+	configuration          modules.Configuration
+	network                Network
 	blockChain             modules.BlockChain
 	crypto                 modules.Crypto
 	eventLoop              *eventloop.EventLoop
@@ -51,27 +49,28 @@ type Kauri struct {
 	blockHash              hotstuff.Hash
 	currentView            hotstuff.View
 	senders                []hotstuff.ID
-	nodes                  map[hotstuff.ID]*kauripb.Node
 	isAggregationSent      bool
 	partitionNumber        int
 	ranking                modules.Ranking
 	partitions             map[int][]hotstuff.ID
 	faultNumber            int
 	changeTree             bool
+	treeView               hotstuff.View // the view in which the tree was last built
 	tickerId               int
 	isOptiLog              bool
+	beginTime              time.Time     // when the replica started the current view
+	predictedQCLatency     time.Duration // QC latency of the current tree predicted by the tree optimizer
 }
 
 // New initializes the kauri structure
 func New() modules.Kauri {
-	return &Kauri{nodes: make(map[hotstuff.ID]*kauripb.Node), partitions: make(map[int][]hotstuff.ID), faultNumber: -1}
+	return &Kauri{partitions: make(map[int][]hotstuff.ID), faultNumber: -1}
 }
 
 // InitModule initializes the Handel module.
 func (k *Kauri) InitModule(mods *modules.Core) {
 	mods.Get(
 		&k.configuration,
-		&k.server,
 		&k.blockChain,
 		&k.crypto,
 		&k.eventLoop,
@@ -82,6 +81,13 @@ func (k *Kauri) InitModule(mods *modules.Core) {
 	)
 
 	mods.TryGet(&k.ranking)
+	if !mods.TryGet(&k.network) {
+		k.network = newGorumsNetwork(mods)
+	}
+	var wrapper NetworkWrapper
+	if mods.TryGet(&wrapper) {
+		k.network = wrapper.WrapNetwork(k.network)
+	}
 	k.opts.SetShouldUseKauri()
 	k.eventLoop.RegisterObserver(backend.ConnectedEvent{}, func(_ any) {
 		k.postInit()
@@ -89,6 +95,16 @@ func (k *Kauri) InitModule(mods *modules.Core) {
 	k.eventLoop.RegisterHandler(ContributionRecvEvent{}, func(event any) {
 		k.OnContributionRecv(event.(ContributionRecvEvent))
 	})
+	k.eventLoop.RegisterHandler(beginEvent{}, func(event any) {
+		begin := event.(beginEvent)
+		k.Begin(begin.pc, begin.proposal)
+	})
+	k.eventLoop.RegisterHandler(aggregationTimeoutEvent{}, func(event any) {
+		k.onAggregationTimeout(event.(aggregationTimeoutEvent).view)
+	})
+	k.eventLoop.RegisterHandler(pendingContribution{}, func(event any) {
+		k.sendContribution(event.(pendingContribution))
+	})
 	k.isOptiLog = true //toggle this for kauri
 	// Uncomment this block to enable the tree change event
 	// k.tickerId = k.eventLoop.AddTicker(time.Duration(10*time.Second), k.tick)
@@ -111,16 +127,11 @@ func (k *Kauri) tick(tickTime time.Time) any {
 }
 
 func (k *Kauri) postInit() {
-	k.logger.Info("Handel: Initializing")
-	kauripb.RegisterKauriServer(k.server.GetGorumsServer(), serviceImpl{k})
+	k.logger.Info("Kauri: Initializing")
 	k.initializeConfiguration()
 }
 
 func (k *Kauri) initializeConfiguration() {
-	kauriCfg := kauripb.ConfigurationFromRaw(k.configuration.GetRawConfiguration(), nil)
-	for _, n := range kauriCfg.Nodes() {
-		k.nodes[hotstuff.ID(n.ID())] = n
-	}
 	// count :=1 // Number of fault nodes
 	// suspicions := k.ranking.GetSuspectedNodes()
 	// k.logger.Info("suspicions are ", suspicions)
@@ -190,46 +201,97 @@ func (k *Kauri) makePartitions() map[int][]hotstuff.ID {
 // Begin starts dissemination of proposal and aggregation of votes.
 func (k *Kauri) Begin(pc hotstuff.PartialCert, p hotstuff.ProposeMsg) {
 	if !k.initDone {
-		k.eventLoop.DelayUntil(backend.ConnectedEvent{}, func() { k.Begin(pc, p) })
+		k.eventLoop.DelayUntil(backend.ConnectedEvent{}, beginEvent{pc: pc, proposal: p})
 		return
 	}
 	k.reset()
 	k.blockHash = pc.BlockHash()
 	k.currentView = p.Block.View()
+	k.beginTime = k.eventLoop.Now()
 	k.aggregatedContribution = pc.Signature()
 	if k.currentView == 0 {
 		ids := k.randomizeIDS(k.blockHash, k.leaderRotation.GetLeader(k.currentView))
 		k.tree.InitializeWithPIDs(ids)
 	}
-	if k.currentView == 1 || k.changeTree {
-		ids := k.randomizeIDS(k.blockHash, k.leaderRotation.GetLeader(k.currentView))
-		if k.isOptiLog {
-			ids = k.assignLeafNodes(k.partitions[k.partitionNumber], k.leaderRotation.GetLeader(k.currentView))
-			//ids = k.moveFaultsToLeaf(ids)
-		} else {
-			ids = k.makeArrayWithPartitions(k.partitions[k.partitionNumber],
-				k.leaderRotation.GetLeader(k.currentView))
-		}
-		k.partitionNumber++
-		k.tree.InitializeWithPIDs(ids)
-		k.changeTree = false
-		k.logger.Info("******************Tree changed***********")
-	}
+	k.updateTree(p.Block)
 	isFaulty := false
 	for _, id := range hotstuff.FaultyNodes {
 		if id == k.opts.ID() {
 			isFaulty = true
 		}
 	}
-	if isFaulty && k.opts.ID() <= hotstuff.ID(k.faultNumber) {
+	if isFaulty && int(k.opts.ID()) <= k.faultNumber {
 		return
 		// ticker := time.NewTicker(time.Duration(100 * time.Millisecond))
 		// <-ticker.C
 		// ticker.Stop()
 	}
 	k.SendProposalToChildren(p)
-	waitTime := time.Duration(uint64(k.tree.GetHeight() * 30 * int(time.Millisecond)))
-	go k.aggregateAndSend(waitTime, k.currentView)
+	waitTime := time.Duration(k.tree.GetHeight()) * k.treeDelta()
+	view := k.currentView
+	k.eventLoop.AfterFunc(waitTime, func() {
+		k.eventLoop.AddEvent(aggregationTimeoutEvent{view: view})
+	})
+}
+
+// updateTree builds the tree of the block's view if the tree changes in that view. The tree is built when the proposal
+// is received, to check its sender, or when the dissemination begins, whichever comes first.
+func (k *Kauri) updateTree(block *hotstuff.Block) {
+	view := block.View()
+	if k.treeView == view || (view != 1 && !k.changeTree) {
+		return
+	}
+	k.treeView = view
+	leaderID := k.leaderRotation.GetLeader(view)
+	var ids map[hotstuff.ID]int
+	if treePositions := k.opts.TreePositions(); len(treePositions) > 0 {
+		ids = correctLeaderPos(leaderID, fixedTree(treePositions))
+	} else if k.isOptiLog {
+		// the tree optimizer is used unless a leaf placement algorithm is selected
+		if k.opts.LeafPlacement() != "" {
+			ids = k.assignLeafNodes(k.partitions[k.partitionNumber], leaderID)
+		} else if tree, ok := k.optiTree(leaderID); ok {
+			ids = tree
+		} else {
+			ids = k.assignLeafNodes(k.partitions[k.partitionNumber], leaderID)
+		}
+		//ids = k.moveFaultsToLeaf(ids)
+	} else {
+		ids = k.makeArrayWithPartitions(k.partitions[k.partitionNumber], leaderID)
+	}
+	k.partitionNumber++
+	k.tree.InitializeWithPIDs(ids)
+	k.predictedQCLatency = k.predictQCLatency(ids)
+	k.changeTree = false
+	k.eventLoop.AddEvent(hotstuff.TreeChangeEvent{
+		View:      view,
+		Tree:      treeOrder(ids),
+		Predicted: k.predictedQCLatency,
+	})
+	k.logger.Info("******************Tree changed***********")
+}
+
+// ProposalSender returns the replica that must send the proposal of the block to this replica, which is its parent
+// in the tree of the block's view, or the leader itself if this replica is the root. It returns false if the tree
+// is not known, or if its root is not the proposer of the block.
+func (k *Kauri) ProposalSender(block *hotstuff.Block) (hotstuff.ID, bool) {
+	if !k.initDone {
+		return 0, false
+	}
+	k.updateTree(block)
+	// the parent of the root is the root itself
+	parent, _ := k.tree.GetParent()
+	return parent, k.tree.GetRoot() == block.Proposer()
+}
+
+// DefaultTreeDelta is the time to wait for the votes of each level of the tree, unless set in the options.
+const DefaultTreeDelta = 30 * time.Millisecond
+
+func (k *Kauri) treeDelta() time.Duration {
+	if delta := k.opts.TreeDelta(); delta > 0 {
+		return delta
+	}
+	return DefaultTreeDelta
 }
 
 func (k *Kauri) reset() {
@@ -238,10 +300,13 @@ func (k *Kauri) reset() {
 	k.isAggregationSent = false
 }
 
-func (k *Kauri) aggregateAndSend(t time.Duration, view hotstuff.View) {
-	ticker := time.NewTicker(t)
-	<-ticker.C
-	ticker.Stop()
+// aggregationTimeoutEvent is raised when the replica has waited for the contributions of its subtree in the view.
+type aggregationTimeoutEvent struct {
+	view hotstuff.View
+}
+
+// onAggregationTimeout sends the contributions received so far to the parent, if not already sent.
+func (k *Kauri) onAggregationTimeout(view hotstuff.View) {
 	if k.currentView != view {
 		return
 	}
@@ -260,7 +325,8 @@ func (k *Kauri) SendProposalToChildren(p hotstuff.ProposeMsg) {
 			return
 		}
 		k.logger.Debug("sending proposal to children ", k.tree.GetChildren())
-		p.Block.SetTime(time.Now())
+		p.Block.SetTime(k.eventLoop.Now())
+		p.ID = k.opts.ID()
 		config.Propose(p)
 	} else {
 		k.SendContributionToParent()
@@ -289,6 +355,9 @@ func (k *Kauri) OnContributionRecv(event ContributionRecvEvent) {
 	}
 }
 
+// faultyContributionDelay is the time that the faulty nodes wait before sending their contributions.
+const faultyContributionDelay = 100 * time.Millisecond
+
 // SendContributionToParent sends contribution to the parent node.
 func (k *Kauri) SendContributionToParent() {
 	isFaulty := false
@@ -297,16 +366,11 @@ func (k *Kauri) SendContributionToParent() {
 			isFaulty = true
 		}
 	}
-	if isFaulty {
-		ticker := time.NewTicker(time.Duration(100 * time.Millisecond))
-		<-ticker.C
-		ticker.Stop()
-	}
 	parent, ok := k.tree.GetParent()
 	children := k.tree.GetChildren()
 	if len(children) != 0 {
 		remaining, ok := isSubSet(children, k.senders)
-		if !ok {
+		if !ok && k.ranking != nil {
 			for _, id := range remaining {
 				k.ranking.AddComplaint(&hotstuff.Complaint{
 					Complainee:       k.opts.ID(),
@@ -317,24 +381,43 @@ func (k *Kauri) SendContributionToParent() {
 			}
 		}
 	}
-	if ok {
-		node, isPresent := k.nodes[parent]
-		if isPresent {
-			node.SendContribution(context.Background(), &kauripb.Contribution{
-				ID:        uint32(k.opts.ID()),
-				Signature: hotstuffpb.QuorumSignatureToProto(k.aggregatedContribution),
-				View:      uint64(k.currentView),
-			})
-		}
+	if !ok {
+		return
+	}
+	contribution := pendingContribution{
+		parent: parent,
+		start:  k.beginTime,
+		contribution: &kauripb.Contribution{
+			ID:        uint32(k.opts.ID()),
+			Signature: hotstuffpb.QuorumSignatureToProto(k.aggregatedContribution),
+			View:      uint64(k.currentView),
+		},
+	}
+	if isFaulty {
+		k.eventLoop.AfterFunc(faultyContributionDelay, func() {
+			k.eventLoop.AddEvent(contribution)
+		})
+		return
 	}
+	k.sendContribution(contribution)
 }
 
-type serviceImpl struct {
-	k *Kauri
+// pendingContribution is a contribution to send to the parent node. Faulty nodes send it as an event after a delay.
+type pendingContribution struct {
+	parent       hotstuff.ID
+	start        time.Time
+	contribution *kauripb.Contribution
 }
 
-func (i serviceImpl) SendContribution(ctx gorums.ServerCtx, request *kauripb.Contribution) {
-	i.k.eventLoop.AddEvent(ContributionRecvEvent{Contribution: request})
+// sendContribution sends the contribution to the parent node.
+func (k *Kauri) sendContribution(event pendingContribution) {
+	k.eventLoop.AddEvent(hotstuff.PhaseEvent{
+		View:  hotstuff.View(event.contribution.View),
+		Phase: hotstuff.PhaseAggregation,
+		Start: event.start,
+		End:   k.eventLoop.Now(),
+	})
+	k.network.SendContribution(event.parent, event.contribution)
 }
 
 // ContributionRecvEvent is raised when a contribution is received.
@@ -390,6 +473,13 @@ func (k *Kauri) mergeWithContribution(currentSignature hotstuff.QuorumSignature)
 			k.aggregatedContribution = new
 			if new.Participants().Len() >= k.configuration.QuorumSize(k.currentView) {
 				k.logger.Debug("Aggregated Complete QC and sending the event")
+				k.eventLoop.AddEvent(hotstuff.PhaseEvent{
+					View:      k.currentView,
+					Phase:     hotstuff.PhaseQC,
+					Start:     k.beginTime,
+					End:       k.eventLoop.Now(),
+					Predicted: k.predictedQCLatency,
+				})
 				k.eventLoop.AddEvent(hotstuff.NewViewMsg{
 					SyncInfo: hotstuff.NewSyncInfo().WithQC(hotstuff.NewQuorumCert(
 						k.aggregatedContribution,
@@ -457,31 +547,31 @@ func (k *Kauri) randomizeIDS(hash hotstuff.Hash, leaderID hotstuff.ID) map[hotst
 	return posMapping
 }
 
+// assignLeafNodes returns the tree positions of a tree with the leader at the root, the other replicas of the partition
+// as internal nodes, and the remaining replicas as leaves, placed by the leaf placement algorithm in the options.
+// The greedy algorithm is used if none is selected.
 func (k *Kauri) assignLeafNodes(ids []hotstuff.ID, leaderID hotstuff.ID) map[hotstuff.ID]int {
-	treePos := make(map[hotstuff.ID]int)
-	pos := 0
-	for _, id := range ids {
-		treePos[id] = pos
-		pos++
+	internal := internalNodes(ids, leaderID)
+	nodes := append(internal, k.leafNodes(internal)...)
+	name := k.opts.LeafPlacement()
+	if name == "" {
+		name = LeafPlacementGreedy
 	}
-	leafNodes := k.leafNodes(ids)
-	for index, id := range ids {
-		if index == 0 {
-			continue
-		}
-		for i := 0; i < MaxChild; i++ {
-			leafNode := k.configuration.FindNearestReplica(id, leafNodes)
-			treePos[leafNode] = pos
-			pos++
-			for j, temp := range leafNodes {
-				if temp == leafNode {
-					leafNodes = append(leafNodes[:j], leafNodes[j+1:]...)
-					break
-				}
-			}
-		}
+	place, ok := leafPlacements[name]
+	if !ok {
+		k.logger.Warnf("Unknown leaf placement '%s', using '%s'", name, LeafPlacementGreedy)
+		place = placeGreedy
+	}
+	p := placement{
+		latencies:  k.latencies(nodes),
+		internal:   len(internal) - 1,
+		quorumSize: k.configuration.QuorumSize(k.currentView),
+	}
+	treePos := make(map[hotstuff.ID]int, len(nodes))
+	for pos, node := range p.tree(place(p)) {
+		treePos[nodes[node]] = pos
 	}
-	return correctLeaderPos(leaderID, treePos)
+	return treePos
 }
 
 func (k *Kauri) moveFaultsToLeaf(posMappings map[hotstuff.ID]int) map[hotstuff.ID]int {
@@ -541,6 +631,25 @@ func (k *Kauri) makeArrayWithPartitions(ids []hotstuff.ID, leaderId hotstuff.ID)
 	return correctLeaderPos(leaderId, ret)
 }
 
+// treeOrder returns the IDs of the given tree positions in position order.
+func treeOrder(treePos map[hotstuff.ID]int) []hotstuff.ID {
+	ids := make([]hotstuff.ID, 0, len(treePos))
+	for id := range treePos {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return treePos[ids[i]] < treePos[ids[j]] })
+	return ids
+}
+
+// fixedTree returns the tree positions of the given IDs, which are in position order.
+func fixedTree(ids []hotstuff.ID) map[hotstuff.ID]int {
+	treePos := make(map[hotstuff.ID]int, len(ids))
+	for pos, id := range ids {
+		treePos[id] = pos
+	}
+	return treePos
+}
+
 func correctLeaderPos(leaderID hotstuff.ID, ids map[hotstuff.ID]int) map[hotstuff.ID]int {
 	lIndex := ids[leaderID]
 	currentRoot := hotstuff.ID(0)
@@ -557,3 +666,9 @@ func correctLeaderPos(leaderID hotstuff.ID, ids map[hotstuff.ID]int) map[hotstuf
 
 type ChangeTreeEvent struct {
 }
+
+// beginEvent is used to begin a view that was proposed before Kauri was initialized.
+type beginEvent struct {
+	pc       hotstuff.PartialCert
+	proposal hotstuff.ProposeMsg
+}
@@ -0,0 +1,39 @@
+package kauri
+
+import (
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+)
+
+func TestProposalSender(t *testing.T) {
+	// the tree does not change in view 5, so the fixed tree below is used
+	block := func(proposer hotstuff.ID) *hotstuff.Block {
+		return hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash(), nil), "", 5, proposer, time.Time{})
+	}
+	tests := []struct {
+		id       hotstuff.ID
+		proposer hotstuff.ID
+		want     hotstuff.ID
+		wantOK   bool
+	}{
+		{id: 1, proposer: 1, want: 1, wantOK: true},
+		{id: 2, proposer: 1, want: 1, wantOK: true},
+		{id: 4, proposer: 1, want: 2, wantOK: true},
+		{id: 7, proposer: 1, want: 3, wantOK: true},
+		{id: 4, proposer: 2, want: 2, wantOK: false},
+	}
+	for _, tt := range tests {
+		k := &Kauri{initDone: true, tree: CreateTree(7, tt.id)}
+		k.tree.InitializeWithPIDs(fixedTree([]hotstuff.ID{1, 2, 3, 4, 5, 6, 7}))
+		if got, ok := k.ProposalSender(block(tt.proposer)); got != tt.want || ok != tt.wantOK {
+			t.Errorf("replica %d: ProposalSender() = (%d, %t) for a proposal by %d, want (%d, %t)",
+				tt.id, got, ok, tt.proposer, tt.want, tt.wantOK)
+		}
+	}
+	k := &Kauri{}
+	if _, ok := k.ProposalSender(block(1)); ok {
+		t.Error("expected no sender before the tree is built")
+	}
+}
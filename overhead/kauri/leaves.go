@@ -0,0 +1,318 @@
+package kauri
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"optitree/opt"
+)
+
+// Leaf placement
+//
+// When the tree is built from a partition of the replicas, the leader is the root, the other replicas of the
+// partition are the internal nodes, and the remaining replicas are the leaves. Each internal node has room for
+// MaxChild leaves. Since the tree positions are contiguous, the internal nodes are filled in order if there are
+// fewer leaves than that, and the leaves that do not fit follow in ID order in the lower levels of the tree.
+// A leaf placement algorithm decides which leaves fill the room of each internal node. The algorithms only depend
+// on the latencies in the configuration, and break ties in favor of the replica with the lowest ID, such that all
+// replicas build the same tree.
+
+// The leaf placement algorithms, which are selected with Options.SetLeafPlacement.
+const (
+	// LeafPlacementGreedy lets each internal node in turn take its nearest remaining leaves.
+	LeafPlacementGreedy = "greedy"
+	// LeafPlacementHungarian minimizes the sum of the round-trip times between the internal nodes and their leaves.
+	LeafPlacementHungarian = "hungarian"
+	// LeafPlacementBalanced minimizes the latest arrival of the votes of a subtree at the root.
+	LeafPlacementBalanced = "balanced"
+	// LeafPlacementQuorum minimizes the arrival of the votes of the last subtree that is needed for a quorum.
+	LeafPlacementQuorum = "quorum"
+)
+
+var leafPlacements = map[string]func(placement) []int{
+	LeafPlacementGreedy:    placeGreedy,
+	LeafPlacementHungarian: placeHungarian,
+	LeafPlacementBalanced:  placeBalanced,
+	LeafPlacementQuorum:    placeQuorum,
+}
+
+// IsLeafPlacement returns true if name is the name of a leaf placement algorithm.
+func IsLeafPlacement(name string) bool {
+	_, ok := leafPlacements[name]
+	return ok
+}
+
+// forbidden is the cost of a leaf slot assignment that must not be made. It is larger than the sum of
+// the round-trip times of any placement, but small enough that a sum of forbidden costs does not overflow.
+const forbidden = int64(1) << 40
+
+// placement is a leaf placement problem. The nodes are numbered by their index in the latency matrix:
+// the root is 0, the internal nodes are 1 to internal, and the leaves follow in ID order.
+type placement struct {
+	latencies  opt.Latencies
+	internal   int
+	quorumSize int
+}
+
+// internalNodes returns the partition with the leader first. If the leader is not in the partition,
+// it takes the place of the first replica of the partition, which becomes a leaf.
+func internalNodes(ids []hotstuff.ID, leaderID hotstuff.ID) []hotstuff.ID {
+	if len(ids) == 0 {
+		return []hotstuff.ID{leaderID}
+	}
+	internal := slices.Clone(ids)
+	if i := slices.Index(internal, leaderID); i >= 0 {
+		internal[0], internal[i] = internal[i], internal[0]
+	} else {
+		internal[0] = leaderID
+	}
+	return internal
+}
+
+// leaves returns the nodes that are leaves.
+func (p placement) leaves() []int {
+	leaves := make([]int, 0, len(p.latencies)-1-p.internal)
+	for leaf := p.internal + 1; leaf < len(p.latencies); leaf++ {
+		leaves = append(leaves, leaf)
+	}
+	return leaves
+}
+
+// slots returns the number of leaves that are placed below the internal nodes.
+func (p placement) slots() int {
+	return min(len(p.latencies)-1-p.internal, p.internal*MaxChild)
+}
+
+// parent returns the internal node of a leaf slot.
+func (p placement) parent(slot int) int {
+	return 1 + slot/MaxChild
+}
+
+// tree returns the nodes in tree position order, given the leaf of each slot.
+func (p placement) tree(assignment []int) []int {
+	tree := make([]int, 0, len(p.latencies))
+	for node := 0; node <= p.internal; node++ {
+		tree = append(tree, node)
+	}
+	tree = append(tree, assignment...)
+	for _, leaf := range p.leaves() {
+		if !slices.Contains(assignment, leaf) {
+			tree = append(tree, leaf)
+		}
+	}
+	return tree
+}
+
+// roundTrip returns the round-trip time between an internal node and a leaf.
+func (p placement) roundTrip(node, leaf int) int64 {
+	return int64(p.latencies[node][leaf]) + int64(p.latencies[leaf][node])
+}
+
+// arrival returns when the votes of the subtree of an internal node arrive at the root if the leaf is one of its
+// leaves, in the QC latency model of the tree optimizer: the proposal is sent from the root to the internal node and
+// on to the leaf, and the votes are sent back the same way.
+func (p placement) arrival(node, leaf int) int64 {
+	return int64(p.latencies[0][node]) + p.roundTrip(node, leaf) + int64(p.latencies[node][0])
+}
+
+// qcLatency returns the QC latency of the placement in the model of the tree optimizer.
+// It returns 0 if the tree does not have a full level of internal nodes.
+func (p placement) qcLatency(assignment []int) opt.Latency {
+	if p.internal != MaxChild {
+		return 0
+	}
+	tree := p.tree(assignment)
+	tree = tree[:min(len(tree), opt.TreeSize(MaxChild))]
+	return p.latencies.TreeQCLatency(p.quorumSize, MaxChild, tree)
+}
+
+// placeGreedy lets each internal node in turn take the remaining leaf with the lowest latency from it.
+func placeGreedy(p placement) []int {
+	remaining := p.leaves()
+	assignment := make([]int, p.slots())
+	for slot := range assignment {
+		node := p.parent(slot)
+		nearest := 0
+		for i, leaf := range remaining {
+			if p.latencies[node][leaf] < p.latencies[node][remaining[nearest]] {
+				nearest = i
+			}
+		}
+		assignment[slot] = remaining[nearest]
+		remaining = slices.Delete(remaining, nearest, nearest+1)
+	}
+	return assignment
+}
+
+// placeHungarian minimizes the sum of the round-trip times between the internal nodes and their leaves.
+func placeHungarian(p placement) []int {
+	assignment, _ := p.minCost(func(slot, leaf int) int64 {
+		return p.roundTrip(p.parent(slot), leaf)
+	})
+	return assignment
+}
+
+// placeBalanced minimizes the latest arrival of the votes of a subtree at the root,
+// and among the placements that achieve it, the sum of the round-trip times.
+func placeBalanced(p placement) []int {
+	return p.bottleneck(func(int) bool { return true })
+}
+
+// placeQuorum minimizes the QC latency, which is the arrival of the votes of the slowest of the fastest subtrees
+// that make up a quorum together with the root. For each set of internal nodes whose subtrees make up a quorum,
+// it minimizes the latest arrival of the votes of these subtrees, and it keeps the placement with the lowest QC
+// latency in the model of the tree optimizer. The sets are enumerated, which is cheap since there are only
+// MaxChild internal nodes.
+func placeQuorum(p placement) []int {
+	if p.internal != MaxChild {
+		return placeBalanced(p)
+	}
+	votes := make([]int, p.internal+1)
+	for node := 1; node <= p.internal; node++ {
+		votes[node] = 1
+	}
+	for slot := 0; slot < p.slots(); slot++ {
+		votes[p.parent(slot)]++
+	}
+	var (
+		best        []int
+		bestLatency opt.Latency
+	)
+	for set := 1; set < 1<<p.internal; set++ {
+		inSet := func(node int) bool { return set&(1<<(node-1)) != 0 }
+		count := 1 // the root's own vote
+		for node := 1; node <= p.internal; node++ {
+			if inSet(node) {
+				count += votes[node]
+			}
+		}
+		if count < p.quorumSize {
+			continue
+		}
+		assignment := p.bottleneck(inSet)
+		if latency := p.qcLatency(assignment); best == nil || latency < bestLatency {
+			best, bestLatency = assignment, latency
+		}
+	}
+	if best == nil {
+		return placeBalanced(p)
+	}
+	return best
+}
+
+// bottleneck minimizes the latest arrival at the root of the votes of the subtrees of the internal nodes
+// for which constrained returns true, and among the placements that achieve it, the sum of the round-trip times.
+func (p placement) bottleneck(constrained func(node int) bool) []int {
+	var thresholds []int64
+	for slot := 0; slot < p.slots(); slot++ {
+		if node := p.parent(slot); constrained(node) {
+			for _, leaf := range p.leaves() {
+				thresholds = append(thresholds, p.arrival(node, leaf))
+			}
+		}
+	}
+	slices.Sort(thresholds)
+	thresholds = slices.Compact(thresholds)
+	cost := func(threshold int64) func(slot, leaf int) int64 {
+		return func(slot, leaf int) int64 {
+			node := p.parent(slot)
+			if constrained(node) && p.arrival(node, leaf) > threshold {
+				return forbidden
+			}
+			return p.roundTrip(node, leaf)
+		}
+	}
+	if len(thresholds) == 0 {
+		assignment, _ := p.minCost(cost(math.MaxInt64))
+		return assignment
+	}
+	// the largest threshold allows every assignment, so the search finds a threshold
+	i := sort.Search(len(thresholds)-1, func(i int) bool {
+		_, total := p.minCost(cost(thresholds[i]))
+		return total < forbidden
+	})
+	assignment, _ := p.minCost(cost(thresholds[i]))
+	return assignment
+}
+
+// minCost returns the assignment of leaves to slots with the lowest total cost.
+func (p placement) minCost(cost func(slot, leaf int) int64) (assignment []int, total int64) {
+	leaves := p.leaves()
+	matrix := make([][]int64, p.slots())
+	for slot := range matrix {
+		matrix[slot] = make([]int64, len(leaves))
+		for i, leaf := range leaves {
+			matrix[slot][i] = cost(slot, leaf)
+		}
+	}
+	columns, total := hungarian(matrix)
+	assignment = make([]int, len(columns))
+	for slot, column := range columns {
+		assignment[slot] = leaves[column]
+	}
+	return assignment, total
+}
+
+// hungarian returns the column of each row in an assignment of rows to distinct columns with the lowest total cost,
+// and the total cost. There must be at least as many columns as rows. It is the shortest augmenting path variant of
+// the Hungarian algorithm, which takes O(n²m) time for n rows and m columns.
+func hungarian(cost [][]int64) (assignment []int, total int64) {
+	n := len(cost)
+	if n == 0 {
+		return nil, 0
+	}
+	m := len(cost[0])
+	// the rows and columns are numbered from 1, such that row[0] and the potentials at 0 are free to use
+	u := make([]int64, n+1)  // potentials of the rows
+	v := make([]int64, m+1)  // potentials of the columns
+	row := make([]int, m+1)  // the row assigned to each column, or 0
+	prev := make([]int, m+1) // the previous column on the augmenting path
+	minSlack := make([]int64, m+1)
+	used := make([]bool, m+1)
+	for i := 1; i <= n; i++ {
+		row[0] = i
+		column := 0
+		for j := range minSlack {
+			minSlack[j], used[j] = math.MaxInt64, false
+		}
+		for row[column] != 0 {
+			used[column] = true
+			r, delta, next := row[column], int64(math.MaxInt64), 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := cost[r-1][j-1] - u[r] - v[j]; slack < minSlack[j] {
+					minSlack[j], prev[j] = slack, column
+				}
+				if minSlack[j] < delta {
+					delta, next = minSlack[j], j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[row[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			column = next
+		}
+		for column != 0 {
+			p := prev[column]
+			row[column] = row[p]
+			column = p
+		}
+	}
+	assignment = make([]int, n)
+	for j := 1; j <= m; j++ {
+		if row[j] != 0 {
+			assignment[row[j]-1] = j - 1
+			total += cost[row[j]-1][j-1]
+		}
+	}
+	return assignment, total
+}
@@ -0,0 +1,168 @@
+package kauri
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"optitree/opt"
+)
+
+// locationPlacement returns the placement problem of replicas in the given locations,
+// where the first location is the root, and the next MaxChild locations are the internal nodes.
+func locationPlacement(t *testing.T, locations ...string) placement {
+	t.Helper()
+	latencies := opt.NewLatencies(len(locations))
+	for i, a := range locations {
+		for j, b := range locations {
+			latency, ok := backend.LocationLatency(a, b)
+			if !ok {
+				t.Fatalf("unknown latency between '%s' and '%s'", a, b)
+			}
+			latencies[i][j] = opt.Latency(latency.Microseconds())
+		}
+	}
+	return placement{latencies: latencies, internal: MaxChild, quorumSize: hotstuff.QuorumSize(len(locations))}
+}
+
+// randomPlacement returns a placement problem with random, asymmetric latencies between 1 and 100 milliseconds.
+func randomPlacement(rnd *rand.Rand, n int) placement {
+	latencies := opt.NewLatencies(n)
+	for i := range latencies {
+		for j := range latencies[i] {
+			if i != j {
+				latencies[i][j] = opt.Latency(1000 + rnd.Intn(99000))
+			}
+		}
+	}
+	return placement{latencies: latencies, internal: MaxChild, quorumSize: hotstuff.QuorumSize(n)}
+}
+
+// score is the cost of a placement for each of the objectives of the leaf placement algorithms.
+type score struct {
+	roundTrips    int64       // the sum of the round-trip times between the internal nodes and their leaves
+	latestArrival int64       // the latest arrival of the votes of a subtree at the root
+	qcLatency     opt.Latency // the QC latency in the model of the tree optimizer
+}
+
+func scorePlacement(p placement, assignment []int) (s score) {
+	for node := 1; node <= p.internal; node++ {
+		s.latestArrival = max(s.latestArrival, int64(p.latencies[0][node])+int64(p.latencies[node][0]))
+	}
+	for slot, leaf := range assignment {
+		s.roundTrips += p.roundTrip(p.parent(slot), leaf)
+		s.latestArrival = max(s.latestArrival, p.arrival(p.parent(slot), leaf))
+	}
+	s.qcLatency = p.qcLatency(assignment)
+	return s
+}
+
+// checkAssignment checks that the assignment places distinct leaves in all slots.
+func checkAssignment(t *testing.T, name string, p placement, assignment []int) {
+	t.Helper()
+	if len(assignment) != p.slots() {
+		t.Fatalf("%s: placed %d leaves, want %d", name, len(assignment), p.slots())
+	}
+	tree := p.tree(assignment)
+	sorted := slices.Clone(tree)
+	slices.Sort(sorted)
+	for i, node := range sorted {
+		if node != i {
+			t.Fatalf("%s: the tree %v is not a permutation of the nodes", name, tree)
+		}
+	}
+}
+
+func TestLeafPlacementLocations(t *testing.T) {
+	// The root is in Bahrain, and the internal nodes are in Mumbai and Seoul. Mumbai greedily takes
+	// its nearest leaves, Hong Kong and Osaka, which leaves the distant Cape Town and Central for Seoul.
+	p := locationPlacement(t, "Bahrain", "Mumbai", "Seoul", "Cape Town", "Central", "Hong Kong", "Osaka")
+	scores := make(map[string]score)
+	for name, place := range leafPlacements {
+		assignment := place(p)
+		checkAssignment(t, name, p, assignment)
+		scores[name] = scorePlacement(p, assignment)
+		t.Logf("%s: %v, %+v", name, p.tree(assignment), scores[name])
+	}
+	for name, s := range scores {
+		if s.qcLatency < scores[LeafPlacementQuorum].qcLatency {
+			t.Errorf("%s: QC latency %d is lower than that of the quorum placement %d", name, s.qcLatency, scores[LeafPlacementQuorum].qcLatency)
+		}
+	}
+	if greedy, quorum := scores[LeafPlacementGreedy], scores[LeafPlacementQuorum]; quorum.qcLatency >= greedy.qcLatency {
+		t.Errorf("expected the quorum placement to improve on the greedy QC latency %d, got %d", greedy.qcLatency, quorum.qcLatency)
+	}
+}
+
+func TestLeafPlacementObjectives(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	// partial trees, a full tree, and a tree with leaves that do not fit below the internal nodes
+	for _, n := range []int{4, 5, 6, 7, 9} {
+		for i := 0; i < 50; i++ {
+			p := randomPlacement(rnd, n)
+			scores := make(map[string]score)
+			for name, place := range leafPlacements {
+				assignment := place(p)
+				checkAssignment(t, name, p, assignment)
+				scores[name] = scorePlacement(p, assignment)
+			}
+			// each algorithm is optimal for its own objective
+			for name, s := range scores {
+				if hungarian := scores[LeafPlacementHungarian]; s.roundTrips < hungarian.roundTrips {
+					t.Errorf("n=%d: %s has round trips %d, lower than hungarian %d", n, name, s.roundTrips, hungarian.roundTrips)
+				}
+				if balanced := scores[LeafPlacementBalanced]; s.latestArrival < balanced.latestArrival {
+					t.Errorf("n=%d: %s has latest arrival %d, earlier than balanced %d", n, name, s.latestArrival, balanced.latestArrival)
+				}
+				if quorum := scores[LeafPlacementQuorum]; s.qcLatency < quorum.qcLatency {
+					t.Errorf("n=%d: %s has QC latency %d, lower than quorum %d", n, name, s.qcLatency, quorum.qcLatency)
+				}
+			}
+		}
+	}
+}
+
+func TestLeafPlacementTies(t *testing.T) {
+	// with equal latencies, the leaves are placed in ID order
+	p := placement{latencies: opt.NewLatencies(7), internal: MaxChild, quorumSize: hotstuff.QuorumSize(7)}
+	for i := range p.latencies {
+		for j := range p.latencies[i] {
+			if i != j {
+				p.latencies[i][j] = 10000
+			}
+		}
+	}
+	for name, place := range leafPlacements {
+		first := place(p)
+		checkAssignment(t, name, p, first)
+		if second := place(p); !slices.Equal(first, second) {
+			t.Errorf("%s: placed the leaves in %v and then in %v", name, first, second)
+		}
+	}
+	if got := placeGreedy(p); !slices.Equal(got, []int{3, 4, 5, 6}) {
+		t.Errorf("greedy: got %v, want the leaves in ID order", got)
+	}
+}
+
+func TestInternalNodes(t *testing.T) {
+	if got := internalNodes([]hotstuff.ID{3, 5, 7}, 5); !slices.Equal(got, []hotstuff.ID{5, 3, 7}) {
+		t.Errorf("expected the leader to swap places with the first replica, got %v", got)
+	}
+	if got := internalNodes([]hotstuff.ID{3, 5, 7}, 1); !slices.Equal(got, []hotstuff.ID{1, 5, 7}) {
+		t.Errorf("expected the leader to replace the first replica, got %v", got)
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	cost := [][]int64{
+		{4, 1, 3, 9},
+		{2, 0, 5, 9},
+		{3, 2, 2, 9},
+	}
+	assignment, total := hungarian(cost)
+	if want := []int{1, 0, 2}; !slices.Equal(assignment, want) || total != 5 {
+		t.Errorf("got assignment %v with cost %d, want %v with cost 5", assignment, total, want)
+	}
+}
@@ -0,0 +1,79 @@
+package kauri
+
+import (
+	"context"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/kauripb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// Network is used by Kauri to send contributions to the other replicas in the tree.
+// By default, Kauri uses the gorums backend. Another implementation, such as a simulated network,
+// can be used by adding it to the modules.
+type Network interface {
+	// SendContribution sends the contribution to the replica with the given ID.
+	SendContribution(id hotstuff.ID, contribution *kauripb.Contribution)
+}
+
+// NetworkWrapper is implemented by modules that alter how Kauri sends its contributions, such as byzantine strategies.
+// If such a module is added to the modules, Kauri sends its contributions through the wrapped network.
+type NetworkWrapper interface {
+	// WrapNetwork wraps the network and returns the altered network.
+	WrapNetwork(Network) Network
+}
+
+// gorumsNetwork sends the contributions through the gorums backend.
+type gorumsNetwork struct {
+	configuration *backend.Config
+	server        *backend.Server
+	eventLoop     *eventloop.EventLoop
+	logger        logging.Logger
+
+	nodes map[hotstuff.ID]*kauripb.Node
+}
+
+func newGorumsNetwork(mods *modules.Core) *gorumsNetwork {
+	n := &gorumsNetwork{nodes: make(map[hotstuff.ID]*kauripb.Node)}
+	mods.Get(
+		&n.configuration,
+		&n.server,
+		&n.eventLoop,
+		&n.logger,
+	)
+	n.eventLoop.RegisterObserver(backend.ConnectedEvent{}, func(_ any) {
+		n.postInit()
+	})
+	return n
+}
+
+func (n *gorumsNetwork) postInit() {
+	kauripb.RegisterKauriServer(n.server.GetGorumsServer(), serviceImpl{n})
+	kauriCfg := kauripb.ConfigurationFromRaw(n.configuration.GetRawConfiguration(), nil)
+	for _, node := range kauriCfg.Nodes() {
+		n.nodes[hotstuff.ID(node.ID())] = node
+	}
+}
+
+// SendContribution sends the contribution to the replica with the given ID.
+func (n *gorumsNetwork) SendContribution(id hotstuff.ID, contribution *kauripb.Contribution) {
+	node, isPresent := n.nodes[id]
+	if !isPresent {
+		n.logger.Warnf("Unable to send the contribution to replica %d", id)
+		return
+	}
+	node.SendContribution(context.Background(), contribution)
+}
+
+type serviceImpl struct {
+	n *gorumsNetwork
+}
+
+func (i serviceImpl) SendContribution(ctx gorums.ServerCtx, request *kauripb.Contribution) {
+	i.n.server.InduceLatency(hotstuff.ID(request.GetID()))
+	i.n.eventLoop.AddEvent(ContributionRecvEvent{Contribution: request})
+}
@@ -1,161 +1,94 @@
 package kauri
 
 import (
-	"fmt"
+	"maps"
+	"slices"
 	"testing"
-	"time"
 
 	"github.com/relab/hotstuff"
+	"optitree/opt"
 )
 
-type TestInternalNodesData struct {
-	suspicions map[hotstuff.ID]map[hotstuff.ID]int
-	expelled   []hotstuff.ID
+// lineLatencies returns the latencies of replicas with the given IDs, where the latency between
+// two replicas is the larger of their IDs, minus one.
+func lineLatencies(ids []hotstuff.ID) opt.Latencies {
+	latencies := opt.NewLatencies(len(ids))
+	for i, a := range ids {
+		for j, b := range ids {
+			if i != j {
+				latencies[i][j] = opt.Latency(max(a, b) - 1)
+			}
+		}
+	}
+	return latencies
 }
 
-func TestComputeMG1(t *testing.T) {
-	suspicionTests := []TestInternalNodesData{
-		{ // All nodes suspect one node
+func TestOptimizeTree(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name       string
+		leader     hotstuff.ID
+		suspicions map[hotstuff.ID]map[hotstuff.ID]int
+		faults     int
+		// the replicas that must not be internal nodes
+		leaves []hotstuff.ID
+	}{
+		{name: "no suspicions", leader: 1},
+		{name: "no suspicions/leader=5", leader: 5},
+		{
+			name:   "all suspect one",
+			leader: 2,
 			suspicions: map[hotstuff.ID]map[hotstuff.ID]int{
-				1: {
-					2: 1,
-					3: 1,
-					4: 1,
-					5: 1,
-				},
-				2: {
-					1: 1,
-				},
-				3: {
-					1: 1,
-				},
-				4: {
-					1: 1,
-				},
-				5: {
-					1: 1,
-				},
+				1: {2: 1, 3: 1, 4: 1, 5: 1},
 			},
-			expelled: []hotstuff.ID{1},
+			faults: 1,
+			leaves: []hotstuff.ID{1},
 		},
-		{ // All nodes suspect one node
+		{
+			name:   "suspicions 1->{2,3}, 2->{1,4}",
+			leader: 7,
 			suspicions: map[hotstuff.ID]map[hotstuff.ID]int{
-				1: {
-					2: 1,
-					3: 1,
-				},
-				2: {
-					1: 1,
-					4: 1,
-				},
-				3: {
-					1: 1,
-				},
-				4: {
-					1: 1,
-				},
-				5: {},
+				2: {1: 1},
+				3: {1: 1},
+				1: {2: 1},
+				4: {2: 1},
 			},
-			expelled: []hotstuff.ID{1, 2, 3, 4},
+			faults: 1,
+			leaves: []hotstuff.ID{1, 2, 3, 4},
 		},
-		{ // All nodes suspect one node
+		{
+			name:   "unknown replicas and zero counts are ignored",
+			leader: 3,
 			suspicions: map[hotstuff.ID]map[hotstuff.ID]int{
-				1: {
-					2: 1,
-					3: 1,
-					4: 1,
-				},
-				2: {
-					1: 1,
-					4: 1,
-					3: 1,
-				},
-				3: {
-					1: 1,
-					2: 1,
-				},
-				4: {
-					1: 1,
-					2: 1,
-				},
-				5: {},
+				1: {2: 0},
+				9: {1: 1},
 			},
-			expelled: []hotstuff.ID{1, 2, 3, 4},
 		},
 	}
-
-	tree := NewOptiTree(1)
-	for _, test := range suspicionTests {
-		internal := tree.getTotalInternalNodesSet(test.suspicions)
-		t.Logf("Internal nodes: %v\n", internal)
-	}
-}
-
-func TestGetTotalInternalNodesSet(t *testing.T) {
-	tree := NewOptiTree(1)
-	latencyMatrix := Latencies{
-		{0, 0, 0, 0, 0, 0, 0, 0, 0},
-		{0, 0, 1, 2, 3, 4, 5, 6, 7},
-		{0, 1, 0, 2, 3, 4, 5, 6, 7},
-		{0, 1, 2, 0, 3, 4, 5, 6, 7},
-		{0, 1, 2, 3, 0, 4, 5, 6, 7},
-		{0, 1, 2, 3, 4, 0, 5, 6, 7},
-		{0, 1, 2, 3, 4, 5, 0, 6, 7},
-		{0, 1, 2, 3, 4, 5, 6, 0, 7},
-		{0, 1, 2, 3, 4, 5, 6, 7, 0},
-	}
-	suspicions := map[hotstuff.ID]map[hotstuff.ID]int{
-		1: {}, 2: {}, 3: {}, 4: {}, 5: {}, 6: {}, 7: {},
-	}
-	configuration := []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}
-	cleanLatencyMatrix(latencyMatrix)
-	treePos := tree.GetTree(suspicions, latencyMatrix, configuration)
-	t.Logf("Internal nodes: %v\n", treePos)
-
-	ot1 := NewOptiTree(5)
-	treePos = ot1.GetTree(suspicions, latencyMatrix, configuration)
-	t.Logf("Internal nodes: %v\n", treePos)
-}
-
-func cleanLatencyMatrix(latencyMatrix Latencies) {
-	for node, neighbors := range latencyMatrix {
-		for neighbor, value := range neighbors {
-			latencyMatrix[node][neighbor] = max(value, latencyMatrix[neighbor][node])
-		}
-	}
-}
-
-func TestSimulatedAnnealing(t *testing.T) {
-	tree := NewOptiTree(1)
-	latencyMatrix := Latencies{
-		{0, 0, 0, 0, 0, 0, 0, 0, 0},
-		{0, 0, 1, 2, 3, 4, 5, 6, 7},
-		{0, 1, 0, 2, 3, 4, 5, 6, 7},
-		{0, 1, 2, 0, 3, 4, 5, 6, 7},
-		{0, 1, 2, 3, 0, 4, 5, 6, 7},
-		{0, 1, 2, 3, 4, 0, 5, 6, 7},
-		{0, 1, 2, 3, 4, 5, 0, 6, 7},
-		{0, 1, 2, 3, 4, 5, 6, 0, 7},
-		{0, 1, 2, 3, 4, 5, 6, 7, 0},
-	}
-	suspicions := make(map[hotstuff.ID]map[hotstuff.ID]int)
-	configuration := []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}
-	cleanLatencyMatrix(latencyMatrix)
-	treePos := tree.GetTree(suspicions, latencyMatrix, configuration)
-	fmt.Printf("Tree %v\n", treePos)
-	treePos = tree.SimulatedAnnealing(treePos, 2*time.Second, 5, latencyMatrix)
-	fmt.Printf("Tree %v\n", treePos)
-}
-
-func TestLatencies(t *testing.T) {
-	latencies, err := loadLatencies("latencies/wonderproxy.csv")
-	if err != nil {
-		t.Fatal(err)
-	}
-	latencies.Print([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 3)
-	latencies, err = loadLatencies("latencies/aws.csv")
-	if err != nil {
-		t.Fatal(err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latencies := lineLatencies(ids)
+			treePos := optimizeTree(ids, tt.leader, latencies, tt.suspicions, tt.faults, 1)
+			tree := make([]hotstuff.ID, len(ids))
+			for id, pos := range treePos {
+				if slices.Contains(ids, id) && pos >= 0 && pos < len(tree) {
+					tree[pos] = id
+				}
+			}
+			if slices.Contains(tree, 0) || len(treePos) != len(ids) {
+				t.Fatalf("the tree positions %v are not a permutation of the replicas", treePos)
+			}
+			if tree[0] != tt.leader {
+				t.Errorf("placed %d at the root, want the leader %d", tree[0], tt.leader)
+			}
+			for _, id := range tt.leaves {
+				if pos := treePos[id]; pos >= 1 && pos <= MaxChild {
+					t.Errorf("placed the suspected replica %d at internal position %d", id, pos)
+				}
+			}
+			if again := optimizeTree(ids, tt.leader, latencies, tt.suspicions, tt.faults, 1); !maps.Equal(treePos, again) {
+				t.Errorf("computed the tree %v and then %v", treePos, again)
+			}
+		})
 	}
-	latencies.Print([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 3)
 }
@@ -16,6 +16,7 @@ type TreeConfiguration interface {
 	GetChildren() []hotstuff.ID
 	GetSubTreeNodes() []hotstuff.ID
 	GetParent() (hotstuff.ID, bool)
+	GetRoot() hotstuff.ID
 }
 
 // FaultFreeTree implements a fault free tree configuration.
@@ -65,6 +66,11 @@ func (t *FaultFreeTree) GetParent() (hotstuff.ID, bool) {
 	return t.posToIDMapping[(myPos-1)/MaxChild], true
 }
 
+// GetRoot returns the ID of the root.
+func (t *FaultFreeTree) GetRoot() hotstuff.ID {
+	return t.posToIDMapping[0]
+}
+
 // GetChildren returns the children of the replicas, if any.
 func (t *FaultFreeTree) GetChildren() []hotstuff.ID {
 	return t.GetChildrenOfNode(t.ID)
@@ -0,0 +1,259 @@
+// Package kvstore provides a deterministic key-value store that can be replicated by the consensus protocol.
+//
+// The store is enabled through the `--modules` flag, and the clients can generate key-value operations with
+// the kv workload:
+//
+//	./hotstuff run --modules="kvstore" --workload="kv"
+//
+// The operations are encoded in the payloads of the client commands with Encode, and the results that the replicas
+// return to the clients can be decoded with DecodeResult. Commands whose payloads are not valid operations,
+// such as the payloads of the other workloads, are not applied and return the Invalid status.
+package kvstore
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	modules.RegisterModule("kvstore", New)
+}
+
+// Op is the type of an operation.
+type Op byte
+
+// The operations of the store.
+const (
+	Put    Op = iota + 1 // sets the value of the key
+	Get                  // returns the value of the key
+	Delete               // removes the key
+	CAS                  // sets the value of the key if its current value is the expected value
+)
+
+func (op Op) String() string {
+	switch op {
+	case Put:
+		return "put"
+	case Get:
+		return "get"
+	case Delete:
+		return "delete"
+	case CAS:
+		return "cas"
+	}
+	return fmt.Sprintf("Op(%d)", op)
+}
+
+// Status is the outcome of an operation.
+type Status byte
+
+// The statuses of the results.
+const (
+	OK       Status = iota + 1 // the operation succeeded
+	NotFound                   // the key of a get, delete or compare-and-swap operation does not exist
+	Mismatch                   // the current value of a compare-and-swap operation was not the expected value
+	Invalid                    // the payload of the command is not a valid operation
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "ok"
+	case NotFound:
+		return "not-found"
+	case Mismatch:
+		return "mismatch"
+	case Invalid:
+		return "invalid"
+	}
+	return fmt.Sprintf("Status(%d)", s)
+}
+
+// Operation is an operation on the store.
+type Operation struct {
+	Op       Op
+	Key      string
+	Value    []byte // the new value of a put or compare-and-swap operation
+	Expected []byte // the expected current value of a compare-and-swap operation
+}
+
+// Encode returns the payload of a command that performs the operation.
+// The payload is the operation, followed by the length-prefixed key, value and expected value.
+func Encode(op Operation) []byte {
+	b := make([]byte, 0, 1+3*binary.MaxVarintLen64+len(op.Key)+len(op.Value)+len(op.Expected))
+	b = append(b, byte(op.Op))
+	for _, field := range [][]byte{[]byte(op.Key), op.Value, op.Expected} {
+		b = binary.AppendUvarint(b, uint64(len(field)))
+		b = append(b, field...)
+	}
+	return b
+}
+
+var errInvalid = errors.New("kvstore: invalid operation")
+
+// Decode returns the operation encoded in the payload of a command.
+func Decode(b []byte) (op Operation, err error) {
+	if len(b) == 0 {
+		return op, errInvalid
+	}
+	op.Op = Op(b[0])
+	if op.Op < Put || op.Op > CAS {
+		return op, errInvalid
+	}
+	b = b[1:]
+	var fields [3][]byte
+	for i := range fields {
+		n, size := binary.Uvarint(b)
+		if size <= 0 || n > uint64(len(b)-size) {
+			return op, errInvalid
+		}
+		fields[i] = b[size : size+int(n)]
+		b = b[size+int(n):]
+	}
+	if len(b) != 0 {
+		return op, errInvalid
+	}
+	op.Key = string(fields[0])
+	op.Value = fields[1]
+	op.Expected = fields[2]
+	return op, nil
+}
+
+// Result is the result of an operation.
+type Result struct {
+	Status Status
+	Value  []byte // the value of a get operation
+}
+
+// EncodeResult returns the encoding of the result that is returned to the client.
+func EncodeResult(r Result) []byte {
+	return append([]byte{byte(r.Status)}, r.Value...)
+}
+
+// DecodeResult returns the result encoded by EncodeResult.
+func DecodeResult(b []byte) (r Result, err error) {
+	if len(b) == 0 || Status(b[0]) < OK || Status(b[0]) > Invalid {
+		return r, errInvalid
+	}
+	return Result{Status: Status(b[0]), Value: b[1:]}, nil
+}
+
+// Store is a key-value store. It implements modules.StateMachine.
+type Store struct {
+	data map[string][]byte
+}
+
+// New returns a new, empty key-value store.
+func New() modules.StateMachine {
+	return NewStore()
+}
+
+// NewStore returns a new, empty key-value store.
+func NewStore() *Store {
+	return &Store{data: make(map[string][]byte)}
+}
+
+// Apply executes the operation encoded in the payload of a command and returns the encoded result.
+func (s *Store) Apply(data []byte) []byte {
+	op, err := Decode(data)
+	if err != nil {
+		return EncodeResult(Result{Status: Invalid})
+	}
+	return EncodeResult(s.Execute(op))
+}
+
+// Execute executes the operation.
+func (s *Store) Execute(op Operation) Result {
+	current, found := s.data[op.Key]
+	switch op.Op {
+	case Put:
+		s.data[op.Key] = clone(op.Value)
+		return Result{Status: OK}
+	case Get:
+		if !found {
+			return Result{Status: NotFound}
+		}
+		return Result{Status: OK, Value: clone(current)}
+	case Delete:
+		if !found {
+			return Result{Status: NotFound}
+		}
+		delete(s.data, op.Key)
+		return Result{Status: OK}
+	case CAS:
+		if !found {
+			return Result{Status: NotFound}
+		}
+		if string(current) != string(op.Expected) {
+			return Result{Status: Mismatch}
+		}
+		s.data[op.Key] = clone(op.Value)
+		return Result{Status: OK}
+	}
+	return Result{Status: Invalid}
+}
+
+// Len returns the number of keys in the store.
+func (s *Store) Len() int {
+	return len(s.data)
+}
+
+// Digest returns the SHA-256 hash of the length-prefixed keys and values of the store, in key order.
+// Stores with the same keys and values have the same digest, regardless of the operations that led to them.
+func (s *Store) Digest() []byte {
+	digest := sha256.Sum256(s.Snapshot())
+	return digest[:]
+}
+
+// Snapshot returns the length-prefixed keys and values of the store, in key order,
+// such that stores with the same keys and values have the same snapshot.
+func (s *Store) Snapshot() []byte {
+	keys := make([]string, 0, len(s.data))
+	size := 0
+	for key, value := range s.data {
+		keys = append(keys, key)
+		size += 2*binary.MaxVarintLen64 + len(key) + len(value)
+	}
+	sort.Strings(keys)
+	b := make([]byte, 0, size)
+	for _, key := range keys {
+		for _, field := range [][]byte{[]byte(key), s.data[key]} {
+			b = binary.AppendUvarint(b, uint64(len(field)))
+			b = append(b, field...)
+		}
+	}
+	return b
+}
+
+// Restore replaces the keys and values of the store with those encoded by Snapshot.
+func (s *Store) Restore(snapshot []byte) error {
+	data := make(map[string][]byte)
+	for len(snapshot) > 0 {
+		var fields [2][]byte
+		for i := range fields {
+			n, size := binary.Uvarint(snapshot)
+			if size <= 0 || n > uint64(len(snapshot)-size) {
+				return errors.New("kvstore: invalid snapshot")
+			}
+			fields[i] = clone(snapshot[size : size+int(n)])
+			snapshot = snapshot[size+int(n):]
+		}
+		data[string(fields[0])] = fields[1]
+	}
+	s.data = data
+	return nil
+}
+
+var (
+	_ modules.StateMachine = (*Store)(nil)
+	_ modules.Snapshotter  = (*Store)(nil)
+)
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,119 @@
+package kvstore
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	ops := []Operation{
+		{Op: Put, Key: "a", Value: []byte("1")},
+		{Op: Get, Key: "a"},
+		{Op: Delete, Key: ""},
+		{Op: CAS, Key: "b", Value: []byte("new"), Expected: []byte("old")},
+	}
+	for _, op := range ops {
+		got, err := Decode(Encode(op))
+		if err != nil {
+			t.Fatalf("Decode(Encode(%+v)): %v", op, err)
+		}
+		if got.Op != op.Op || got.Key != op.Key || !bytes.Equal(got.Value, op.Value) || !bytes.Equal(got.Expected, op.Expected) {
+			t.Errorf("Decode(Encode(%+v)) = %+v", op, got)
+		}
+	}
+	invalid := [][]byte{
+		nil,
+		{0},
+		{byte(CAS) + 1, 0, 0, 0},
+		{byte(Put), 5, 'a'},
+		append(Encode(Operation{Op: Get, Key: "a"}), 0),
+	}
+	for _, b := range invalid {
+		if _, err := Decode(b); err == nil {
+			t.Errorf("Decode(%v): expected an error", b)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := NewStore()
+	tests := []struct {
+		op   Operation
+		want Result
+	}{
+		{Operation{Op: Get, Key: "a"}, Result{Status: NotFound}},
+		{Operation{Op: CAS, Key: "a", Value: []byte("2")}, Result{Status: NotFound}},
+		{Operation{Op: Put, Key: "a", Value: []byte("1")}, Result{Status: OK}},
+		{Operation{Op: Get, Key: "a"}, Result{Status: OK, Value: []byte("1")}},
+		{Operation{Op: CAS, Key: "a", Value: []byte("2"), Expected: []byte("0")}, Result{Status: Mismatch}},
+		{Operation{Op: CAS, Key: "a", Value: []byte("2"), Expected: []byte("1")}, Result{Status: OK}},
+		{Operation{Op: Get, Key: "a"}, Result{Status: OK, Value: []byte("2")}},
+		{Operation{Op: Delete, Key: "a"}, Result{Status: OK}},
+		{Operation{Op: Delete, Key: "a"}, Result{Status: NotFound}},
+	}
+	for _, test := range tests {
+		got, err := DecodeResult(s.Apply(Encode(test.op)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != test.want.Status || !bytes.Equal(got.Value, test.want.Value) {
+			t.Errorf("%+v: got %+v, want %+v", test.op, got, test.want)
+		}
+	}
+	if got, _ := DecodeResult(s.Apply([]byte("not an operation"))); got.Status != Invalid {
+		t.Errorf("invalid payload: got status %v, want %v", got.Status, Invalid)
+	}
+	if s.Len() != 0 {
+		t.Errorf("got %d keys, want 0", s.Len())
+	}
+}
+
+func TestDigest(t *testing.T) {
+	a, b := NewStore(), NewStore()
+	if !bytes.Equal(a.Digest(), b.Digest()) {
+		t.Error("empty stores have different digests")
+	}
+	a.Execute(Operation{Op: Put, Key: "x", Value: []byte("1")})
+	a.Execute(Operation{Op: Put, Key: "y", Value: []byte("2")})
+	b.Execute(Operation{Op: Put, Key: "y", Value: []byte("0")})
+	b.Execute(Operation{Op: Put, Key: "z", Value: []byte("3")})
+	if bytes.Equal(a.Digest(), b.Digest()) {
+		t.Error("stores with different contents have the same digest")
+	}
+	b.Execute(Operation{Op: CAS, Key: "y", Value: []byte("2"), Expected: []byte("0")})
+	b.Execute(Operation{Op: Delete, Key: "z"})
+	b.Execute(Operation{Op: Put, Key: "x", Value: []byte("1")})
+	if !bytes.Equal(a.Digest(), b.Digest()) {
+		t.Error("stores with the same contents have different digests")
+	}
+	// the keys and values are length-prefixed, so moving bytes between them changes the digest
+	c := NewStore()
+	c.Execute(Operation{Op: Put, Key: "x1", Value: nil})
+	d := NewStore()
+	d.Execute(Operation{Op: Put, Key: "x", Value: []byte("1")})
+	if bytes.Equal(c.Digest(), d.Digest()) {
+		t.Error("different stores with the same concatenation have the same digest")
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	a := NewStore()
+	a.Execute(Operation{Op: Put, Key: "x", Value: []byte("1")})
+	a.Execute(Operation{Op: Put, Key: "empty", Value: nil})
+	a.Execute(Operation{Op: Put, Key: "y", Value: []byte("22")})
+
+	b := NewStore()
+	b.Execute(Operation{Op: Put, Key: "z", Value: []byte("3")})
+	if err := b.Restore(a.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != a.Len() || !bytes.Equal(a.Digest(), b.Digest()) {
+		t.Error("the restored store differs from the snapshotted store")
+	}
+	if got := b.Execute(Operation{Op: Get, Key: "y"}); got.Status != OK || string(got.Value) != "22" {
+		t.Errorf("get y: got %+v, want value 22", got)
+	}
+	if err := b.Restore(a.Snapshot()[:3]); err == nil {
+		t.Error("a truncated snapshot was restored")
+	}
+}
@@ -0,0 +1,266 @@
+package leaderrotation
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"optitree/opt"
+)
+
+func init() {
+	modules.RegisterModule("latency", NewLatencyBased)
+}
+
+const (
+	// epochLength is the number of consecutive views that share a leader.
+	epochLength = 10
+	// latencyWindow is the number of committed blocks whose latency vectors are used.
+	latencyWindow = 100
+)
+
+// latencyMatrix holds one-way latencies in microseconds, indexed [from][to].
+type latencyMatrix map[hotstuff.ID]map[hotstuff.ID]uint32
+
+type latencyBased struct {
+	blockChain    modules.BlockChain
+	configuration modules.Configuration
+	consensus     modules.Consensus
+	ranking       modules.Ranking
+	logger        logging.Logger
+
+	mut     sync.Mutex
+	leaders map[hotstuff.View]hotstuff.ID // leader per epoch
+}
+
+// InitModule gives the module a reference to the Core object.
+func (lb *latencyBased) InitModule(mods *modules.Core) {
+	mods.Get(
+		&lb.blockChain,
+		&lb.configuration,
+		&lb.consensus,
+		&lb.logger,
+	)
+	mods.TryGet(&lb.ranking)
+}
+
+// GetLeader returns the id of the leader in the given view.
+//
+// The leader of an epoch is the unsuspected replica with the lowest expected QC latency,
+// computed from the latency vectors of committed blocks up to the epoch's anchor block,
+// and the suspicions of the ranking state after the anchor block was committed.
+// The anchor is the last committed block at least ChainLength views before the epoch starts,
+// which is the same block on every replica that has committed that far.
+// Epochs without enough latency data rotate among the active replicas at the anchor's view.
+// A replica that lacks the anchor block or its ranking state also uses round-robin,
+// but does not remember that leader, such that it chooses again once it has caught up.
+// Once chosen from the anchor block, the leader of an epoch does not change.
+func (lb *latencyBased) GetLeader(view hotstuff.View) hotstuff.ID {
+	epoch := view / epochLength
+	lb.mut.Lock()
+	defer lb.mut.Unlock()
+	if leader, ok := lb.leaders[epoch]; ok {
+		return leader
+	}
+	leader, ok := lb.chooseLeader(view)
+	if ok {
+		lb.leaders[epoch] = leader
+	}
+	lb.prune()
+	return leader
+}
+
+// chooseLeader chooses the leader of the epoch of the given view.
+// It returns false if the leader is a fallback because of missing local state.
+func (lb *latencyBased) chooseLeader(view hotstuff.View) (hotstuff.ID, bool) {
+	epoch := view / epochLength
+	chainLength := hotstuff.View(lb.consensus.ChainLength())
+	epochStart := epoch * epochLength
+	if epochStart < chainLength {
+		return lb.roundRobin(epoch, 0), true
+	}
+	anchorView := epochStart - chainLength
+
+	block := lb.consensus.CommittedBlock()
+	if block.QuorumCert().Signature() == nil || block.View() < anchorView {
+		lb.logger.Debugf("fallback to round-robin (view=%d, commitHead=%d)", view, block.View())
+		return lb.roundRobin(epoch, anchorView), false
+	}
+	ok := true
+	for ok && block.View() > anchorView {
+		block, ok = lb.blockChain.Get(block.Parent())
+	}
+	if !ok {
+		lb.logger.Debugf("fallback to round-robin (view=%d): anchor block not found", view)
+		return lb.roundRobin(epoch, anchorView), false
+	}
+
+	ids := lb.activeReplicas(anchorView)
+	candidates, ok := lb.candidates(block, ids)
+	if !ok {
+		lb.logger.Debugf("fallback to round-robin (view=%d): no ranking state for the anchor block", view)
+		return lb.roundRobin(epoch, anchorView), false
+	}
+	leader, ok := chooseLatencyLeader(lb.committedLatencies(block), candidates, ids,
+		lb.configuration.QuorumSize(view))
+	if !ok {
+		lb.logger.Debugf("fallback to round-robin (view=%d): not enough latency data", view)
+		return lb.roundRobin(epoch, anchorView), true
+	}
+	lb.logger.Debugf("chose id %d for epoch %d", leader, epoch)
+	return leader, true
+}
+
+// activeReplicas returns the IDs of the active replicas in the given view, in increasing order.
+// Without a reconfiguration at or before the view, these are the replicas of the configuration that are active.
+func (lb *latencyBased) activeReplicas(view hotstuff.View) []hotstuff.ID {
+	if schedule, ok := lb.configuration.(activeSchedule); ok {
+		if active, ok := schedule.ActiveReplicasAt(view); ok && len(active) > 0 {
+			return active
+		}
+	}
+	ids := make([]hotstuff.ID, 0, lb.configuration.Len())
+	for id, replica := range lb.configuration.Replicas() {
+		if replica.Active() {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// roundRobin returns the leader of the epoch when rotating among the active replicas in the given view.
+func (lb *latencyBased) roundRobin(epoch, view hotstuff.View) hotstuff.ID {
+	ids := lb.activeReplicas(view)
+	if len(ids) == 0 {
+		return chooseRoundRobin(epoch, lb.configuration.Len())
+	}
+	return ids[int(epoch%hotstuff.View(len(ids)))]
+}
+
+// prune forgets the leaders of the epochs before the epoch of the last committed block.
+// The caller must hold lb.mut.
+func (lb *latencyBased) prune() {
+	committedEpoch := lb.consensus.CommittedBlock().View() / epochLength
+	maps.DeleteFunc(lb.leaders, func(epoch hotstuff.View, _ hotstuff.ID) bool {
+		return epoch < committedEpoch
+	})
+}
+
+// committedLatencies collects the latency vectors of the quorum certificates of
+// the last latencyWindow blocks up to and including block. Each vector holds the
+// time from the proposal of the certified block until each vote was created.
+// Newer measurements take precedence over older ones.
+func (lb *latencyBased) committedLatencies(block *hotstuff.Block) latencyMatrix {
+	latencies := make(latencyMatrix)
+	ok := true
+	for i := 0; ok && i < latencyWindow && block != hotstuff.GetGenesis(); i++ {
+		qc := block.QuorumCert()
+		if certified, found := lb.blockChain.Get(qc.BlockHash()); found {
+			from := certified.Proposer()
+			if _, exists := latencies[from]; !exists {
+				latencies[from] = make(map[hotstuff.ID]uint32)
+			}
+			for _, v := range qc.LatencyVector() {
+				to := hotstuff.ID(v >> 24)
+				if _, exists := latencies[from][to]; !exists {
+					latencies[from][to] = v & 0x00FFFFFF
+				}
+			}
+		}
+		block, ok = lb.blockChain.Get(block.Parent())
+	}
+	return latencies
+}
+
+// candidates returns the replicas that are not excluded by the suspicion graph of the ranking state
+// after the anchor block was committed. It returns false if that ranking state is not known.
+func (lb *latencyBased) candidates(anchor *hotstuff.Block, ids []hotstuff.ID) ([]hotstuff.ID, bool) {
+	if lb.ranking == nil {
+		return ids, true
+	}
+	committed, ok := lb.consensus.(modules.CommittedRanking)
+	if !ok {
+		return nil, false
+	}
+	matrix, _, ok := committed.SuspicionsAt(anchor.Hash())
+	if !ok {
+		return nil, false
+	}
+	index := make(map[hotstuff.ID]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	suspicions := opt.NewSuspicions(len(ids))
+	for a, row := range matrix {
+		for b, count := range row {
+			i, okA := index[a]
+			j, okB := index[b]
+			if okA && okB && i != j && count > 0 {
+				suspicions.Suspect(i, j)
+			}
+		}
+	}
+	candidates := make([]hotstuff.ID, 0, len(ids))
+	for _, i := range suspicions.Candidates() {
+		candidates = append(candidates, ids[i])
+	}
+	return candidates, true
+}
+
+// chooseLatencyLeader returns the candidate with the lowest QC latency when
+// collecting votes directly from the other replicas, as modeled by opt.QCLatency.
+// Missing latencies are taken from the opposite direction; replicas with no
+// measurement towards a candidate do not count towards its quorum.
+// Ties are broken by the lowest ID. It returns false if no candidate can reach a quorum.
+func chooseLatencyLeader(latencies latencyMatrix, candidates, ids []hotstuff.ID, quorumSize int) (hotstuff.ID, bool) {
+	lookup := func(a, b hotstuff.ID) (uint32, bool) {
+		if l, ok := latencies[a][b]; ok {
+			return l, true
+		}
+		l, ok := latencies[b][a]
+		return l, ok
+	}
+	matrix := opt.NewLatencies(len(ids))
+	for i, a := range ids {
+		for j, b := range ids {
+			if l, ok := lookup(a, b); ok {
+				matrix[i][j] = opt.Latency(l)
+			}
+		}
+	}
+	var (
+		leader hotstuff.ID
+		best   opt.Latency
+		found  bool
+	)
+	for i, candidate := range ids {
+		if !slices.Contains(candidates, candidate) {
+			continue
+		}
+		tree := []int{i}
+		for j, id := range ids {
+			if _, ok := lookup(candidate, id); ok && j != i {
+				tree = append(tree, j)
+			}
+		}
+		if len(tree) < quorumSize {
+			continue
+		}
+		latency := matrix.QCLatency(quorumSize, len(tree)-1, opt.AsNodes(tree), false)
+		if !found || latency < best {
+			leader, best, found = candidate, latency, true
+		}
+	}
+	return leader, found
+}
+
+// NewLatencyBased returns a new latency-aware leader rotation implementation.
+func NewLatencyBased() modules.LeaderRotation {
+	return &latencyBased{
+		leaders: make(map[hotstuff.View]hotstuff.ID),
+	}
+}
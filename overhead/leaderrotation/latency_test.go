@@ -0,0 +1,204 @@
+package leaderrotation
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestChooseLatencyLeader(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4}
+	// replica 3 is close to everyone, replica 1 is far from everyone.
+	latencies := latencyMatrix{
+		1: {2: 900, 3: 800, 4: 950},
+		2: {3: 100, 4: 500},
+		3: {4: 120},
+	}
+	tests := []struct {
+		name       string
+		latencies  latencyMatrix
+		candidates []hotstuff.ID
+		want       hotstuff.ID
+		wantOK     bool
+	}{
+		{name: "all", latencies: latencies, candidates: ids, want: 3, wantOK: true},
+		{name: "suspected", latencies: latencies, candidates: []hotstuff.ID{1, 2, 4}, want: 2, wantOK: true},
+		{name: "no-candidates", latencies: latencies, candidates: nil, wantOK: false},
+		{name: "no-data", latencies: latencyMatrix{}, candidates: ids, wantOK: false},
+		{name: "partial-data", latencies: latencyMatrix{4: {1: 50, 2: 60}}, candidates: ids, want: 4, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := chooseLatencyLeader(tt.latencies, tt.candidates, ids, 3)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("chooseLatencyLeader() = (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// committedRankingStub is a consensus module that only provides the committed block and the committed ranking state.
+type committedRankingStub struct {
+	modules.Consensus
+	committed  *hotstuff.Block
+	suspicions map[hotstuff.Hash]map[hotstuff.ID]map[hotstuff.ID]int
+}
+
+func (c committedRankingStub) CommittedBlock() *hotstuff.Block { return c.committed }
+
+func (c committedRankingStub) ChainLength() int { return 3 }
+
+func (c committedRankingStub) SuspicionsAt(block hotstuff.Hash) (map[hotstuff.ID]map[hotstuff.ID]int, []hotstuff.ID, bool) {
+	suspicions, ok := c.suspicions[block]
+	return suspicions, nil, ok
+}
+
+// rankingStub is a ranking module whose current suspicions differ from the committed ones.
+type rankingStub struct {
+	modules.Ranking
+}
+
+func (rankingStub) GetSuspicionMatrix() map[hotstuff.ID]map[hotstuff.ID]int {
+	return map[hotstuff.ID]map[hotstuff.ID]int{3: {1: 1, 2: 1, 4: 1}}
+}
+
+// configurationStub is a configuration with a fixed number of replicas.
+type configurationStub struct {
+	modules.Configuration
+	n int
+}
+
+func (c configurationStub) Len() int { return c.n }
+
+func (c configurationStub) QuorumSize(hotstuff.View) int { return hotstuff.QuorumSize(c.n) }
+
+// ActiveReplicasAt returns the replicas 1 to n in every view.
+func (c configurationStub) ActiveReplicasAt(hotstuff.View) ([]hotstuff.ID, bool) {
+	ids := make([]hotstuff.ID, 0, c.n)
+	for id := hotstuff.ID(1); id <= hotstuff.ID(c.n); id++ {
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+func TestLatencyCandidates(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4}
+	anchor := hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash(), nil), "", 1, 1, time.Time{})
+	lb := &latencyBased{
+		consensus: committedRankingStub{suspicions: map[hotstuff.Hash]map[hotstuff.ID]map[hotstuff.ID]int{
+			anchor.Hash(): {2: {1: 1, 3: 1, 4: 1}},
+		}},
+		ranking: rankingStub{},
+	}
+	// the committed state suspects replica 2, while the local state suspects replica 3
+	got, ok := lb.candidates(anchor, ids)
+	if !ok || slices.Contains(got, 2) || !slices.Contains(got, 3) {
+		t.Errorf("candidates() = (%v, %t), want the candidates of the committed state", got, ok)
+	}
+	other := hotstuff.NewBlock(anchor.Hash(), hotstuff.NewQuorumCert(nil, 1, anchor.Hash(), nil), "", 2, 2, time.Time{})
+	if got, ok := lb.candidates(other, ids); ok {
+		t.Errorf("candidates() = (%v, true) for a block without committed ranking state, want false", got)
+	}
+}
+
+func TestLatencyLeaderCache(t *testing.T) {
+	stub := committedRankingStub{committed: hotstuff.GetGenesis()}
+	lb := &latencyBased{
+		consensus:     &stub,
+		configuration: configurationStub{n: 4},
+		logger:        logging.New("test"),
+		leaders:       make(map[hotstuff.View]hotstuff.ID),
+	}
+	// the epochs before ChainLength views use round-robin per epoch
+	for view := hotstuff.View(0); view < epochLength; view++ {
+		if got := lb.GetLeader(view); got != 1 {
+			t.Errorf("GetLeader(%d) = %d, want 1", view, got)
+		}
+	}
+	lb.leaders[1] = 4
+	if got := lb.GetLeader(epochLength + 1); got != 4 {
+		t.Errorf("GetLeader(%d) = %d, want the cached leader 4", epochLength+1, got)
+	}
+	stub.committed = hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash(), nil), "", 2*epochLength, 1, time.Time{})
+	lb.leaders[3] = 2
+	lb.GetLeader(4 * epochLength)
+	if _, ok := lb.leaders[0]; ok {
+		t.Error("expected the leader of epoch 0 to be pruned")
+	}
+	if _, ok := lb.leaders[1]; ok {
+		t.Error("expected the leader of epoch 1 to be pruned")
+	}
+	if got := lb.leaders[3]; got != 2 {
+		t.Errorf("expected the leader of epoch 3 to be kept, got %d", got)
+	}
+}
+
+// latencyChain returns a chain of blocks in views 1 to n whose quorum certificates carry latency vectors
+// in which replica 3 is close to everyone.
+func latencyChain(n hotstuff.View) (blockChainStub, []*hotstuff.Block) {
+	latency := func(a, b hotstuff.ID) uint32 {
+		if a == 3 || b == 3 {
+			return 100
+		}
+		return 900
+	}
+	chain := blockChainStub{blocks: map[hotstuff.Hash]*hotstuff.Block{hotstuff.GetGenesis().Hash(): hotstuff.GetGenesis()}}
+	blocks := []*hotstuff.Block{hotstuff.GetGenesis()}
+	for view := hotstuff.View(1); view <= n; view++ {
+		parent := blocks[len(blocks)-1]
+		var (
+			sigs   []*ecdsa.Signature
+			vector []uint32
+		)
+		for id := hotstuff.ID(1); id <= 4; id++ {
+			sigs = append(sigs, ecdsa.RestoreSignature(big.NewInt(int64(id)), big.NewInt(int64(view)), id))
+			if id != parent.Proposer() {
+				vector = append(vector, uint32(id)<<24|latency(parent.Proposer(), id))
+			}
+		}
+		qc := hotstuff.NewQuorumCert(ecdsa.RestoreMultiSignature(sigs), parent.View(), parent.Hash(), vector)
+		block := hotstuff.NewBlock(parent.Hash(), qc, "", view, hotstuff.ID(view%4)+1, time.Time{})
+		chain.blocks[block.Hash()] = block
+		blocks = append(blocks, block)
+	}
+	return chain, blocks
+}
+
+// TestLatencyLeaderCommitHeads tests that replicas that have committed different blocks after the anchor block
+// choose the same leader, and that a replica that has not committed the anchor block chooses again once it has.
+func TestLatencyLeaderCommitHeads(t *testing.T) {
+	chain, blocks := latencyChain(30)
+	newReplica := func(committed *hotstuff.Block) (*latencyBased, *committedRankingStub) {
+		stub := &committedRankingStub{committed: committed}
+		lb := NewLatencyBased().(*latencyBased)
+		lb.blockChain, lb.consensus, lb.logger = chain, stub, logging.New("test")
+		lb.configuration = configurationStub{n: 4}
+		return lb, stub
+	}
+	// the epoch of view 10 starts in view 10, so its anchor is the block of view 7
+	const view = epochLength
+	a, _ := newReplica(blocks[8])
+	b, _ := newReplica(blocks[30])
+	if got, want := a.GetLeader(view), b.GetLeader(view); got != want || got != 3 {
+		t.Errorf("GetLeader(%d) = %d and %d, want 3 on both replicas", view, got, want)
+	}
+
+	// the lagging replica falls back to round-robin among the replicas 1 to 4
+	lagging, stub := newReplica(blocks[5])
+	if got := lagging.GetLeader(view); got != 2 {
+		t.Errorf("GetLeader(%d) = %d before committing the anchor block, want 2", view, got)
+	}
+	if _, ok := lagging.leaders[view/epochLength]; ok {
+		t.Error("remembered the leader of an epoch whose anchor block is not committed")
+	}
+	stub.committed = blocks[20]
+	if got := lagging.GetLeader(view); got != 3 {
+		t.Errorf("GetLeader(%d) = %d after committing the anchor block, want 3", view, got)
+	}
+}
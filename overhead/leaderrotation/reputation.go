@@ -1,10 +1,12 @@
 package leaderrotation
 
 import (
+	"maps"
 	"math/rand"
+	"slices"
+	"sync"
 
 	wr "github.com/mroth/weightedrand"
-	"golang.org/x/exp/slices"
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/logging"
@@ -17,19 +19,29 @@ func init() {
 
 type reputationsMap map[hotstuff.ID]float64
 
+// reputationsEntry holds the reputations after a committed block.
+type reputationsEntry struct {
+	view        hotstuff.View
+	reputations reputationsMap
+}
+
 type repBased struct {
-	configuration  modules.Configuration
-	consensus      modules.Consensus
-	opts           *modules.Options
-	logger         logging.Logger
-	prevCommitHead *hotstuff.Block
-	reputations    reputationsMap // latest reputations
+	blockChain    modules.BlockChain
+	configuration modules.Configuration
+	consensus     modules.Consensus
+	opts          *modules.Options
+	logger        logging.Logger
+
+	mut sync.Mutex
+	// reputations after the recently committed blocks, indexed by block hash.
+	reputations map[hotstuff.Hash]reputationsEntry
 }
 
 // InitModule gives the module a reference to the Core object.
 // It also allows the module to set module options using the OptionsBuilder
 func (r *repBased) InitModule(mods *modules.Core) {
 	mods.Get(
+		&r.blockChain,
 		&r.configuration,
 		&r.consensus,
 		&r.opts,
@@ -37,53 +49,54 @@ func (r *repBased) InitModule(mods *modules.Core) {
 	)
 }
 
-// TODO: should GetLeader be thread-safe?
-
-// GetLeader returns the id of the leader in the given view
+// GetLeader returns the id of the leader in the given view.
+// The leader is chosen based on the reputations after the last committed block
+// at least ChainLength views before the given view. Since the reputations are derived
+// from the chain, leaders of older views can be looked up as long as the blocks are available.
+// GetLeader is safe for concurrent use.
 func (r *repBased) GetLeader(view hotstuff.View) hotstuff.ID {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	numReplicas := r.configuration.Len()
+	chainLength := hotstuff.View(r.consensus.ChainLength())
+	if view < chainLength {
+		return chooseRoundRobin(view, numReplicas)
+	}
 	block := r.consensus.CommittedBlock()
-	if block.View() > view-hotstuff.View(r.consensus.ChainLength()) {
-		// TODO: it could be possible to lookup leaders for older views if we
-		// store a copy of the reputations in a metadata field of each block.
-		r.logger.Error("looking up leaders of old views is not supported")
+	ok := true
+	for ok && block.View() > view-chainLength {
+		block, ok = r.blockChain.Get(block.Parent())
+	}
+	if !ok {
+		r.logger.Errorf("failed to find the committed block for view %d", view)
 		return 0
 	}
 
-	numReplicas := r.configuration.Len()
 	// use round-robin for the first few views until we get a signature
 	if block.QuorumCert().Signature() == nil {
 		return chooseRoundRobin(view, numReplicas)
 	}
 
-	voters := block.QuorumCert().Signature().Participants()
-	numVotes := 0
-	voters.ForEach(func(hotstuff.ID) {
-		numVotes++
-	})
-
-	frac := float64((2.0 / 3.0) * float64(numReplicas))
-	reputation := ((float64(numVotes) - frac) / frac)
+	reputations, ok := r.reputationsAt(block)
+	if !ok {
+		r.logger.Errorf("failed to compute reputations for view %d", view)
+		return 0
+	}
+	r.prune()
 
-	weights := make([]wr.Choice, 0, numVotes)
-	voters.ForEach(func(voterID hotstuff.ID) {
-		// we should only update the reputations once for each commit head.
-		if r.prevCommitHead.View() < block.View() {
-			r.reputations[voterID] += reputation
-		}
+	weights := make([]wr.Choice, 0, numReplicas)
+	block.QuorumCert().Signature().Participants().ForEach(func(voterID hotstuff.ID) {
 		weights = append(weights, wr.Choice{
 			Item:   voterID,
-			Weight: uint(r.reputations[voterID] * 10),
+			Weight: uint(reputations[voterID] * 10),
 		})
 	})
 
-	slices.SortFunc(weights, func(a, b wr.Choice) bool {
-		return a.Item.(hotstuff.ID) >= b.Item.(hotstuff.ID)
+	slices.SortFunc(weights, func(a, b wr.Choice) int {
+		return int(b.Item.(hotstuff.ID)) - int(a.Item.(hotstuff.ID))
 	})
 
-	if r.prevCommitHead.View() < block.View() {
-		r.prevCommitHead = block
-	}
-
 	r.logger.Debug(weights)
 
 	chooser, err := wr.NewChooser(weights...)
@@ -101,10 +114,81 @@ func (r *repBased) GetLeader(view hotstuff.View) hotstuff.ID {
 	return leader
 }
 
+// reputationsAt returns the reputations after the given block, computing and storing
+// the reputations of any ancestors that have not been computed yet.
+func (r *repBased) reputationsAt(block *hotstuff.Block) (reputationsMap, bool) {
+	var pending []*hotstuff.Block
+	entry, ok := r.reputations[block.Hash()]
+	for !ok {
+		pending = append(pending, block)
+		parent, found := r.blockChain.Get(block.Parent())
+		if !found {
+			return nil, false
+		}
+		block = parent
+		entry, ok = r.reputations[block.Hash()]
+	}
+	reputations := entry.reputations
+	for i := len(pending) - 1; i >= 0; i-- {
+		reputations = r.updateReputations(reputations, pending[i])
+		r.reputations[pending[i].Hash()] = reputationsEntry{view: pending[i].View(), reputations: reputations}
+	}
+	return reputations, true
+}
+
+// prune forgets the reputations that later calls to GetLeader cannot reach. These calls use the reputations after
+// blocks that are at most ChainLength views older than the last committed block, and the reputations of newer blocks
+// are computed from those of the newest block before them, which is kept. The reputations of older views are computed
+// again from the genesis block, as long as their blocks are available.
+// The caller must hold r.mut.
+func (r *repBased) prune() {
+	committed := r.consensus.CommittedBlock().View()
+	chainLength := hotstuff.View(r.consensus.ChainLength())
+	if committed < chainLength {
+		return
+	}
+	threshold := committed - chainLength
+	var base hotstuff.View
+	for _, entry := range r.reputations {
+		if entry.view <= threshold && entry.view > base {
+			base = entry.view
+		}
+	}
+	maps.DeleteFunc(r.reputations, func(_ hotstuff.Hash, entry reputationsEntry) bool {
+		return entry.view < base && entry.view > 0
+	})
+}
+
+// updateReputations returns a copy of the reputations updated with the voters of the block's QC.
+func (r *repBased) updateReputations(prev reputationsMap, block *hotstuff.Block) reputationsMap {
+	reputations := make(reputationsMap, len(prev))
+	for id, reputation := range prev {
+		reputations[id] = reputation
+	}
+	signature := block.QuorumCert().Signature()
+	if signature == nil {
+		return reputations
+	}
+	voters := signature.Participants()
+	numVotes := 0
+	voters.ForEach(func(hotstuff.ID) {
+		numVotes++
+	})
+
+	frac := float64((2.0 / 3.0) * float64(r.configuration.Len()))
+	reputation := ((float64(numVotes) - frac) / frac)
+
+	voters.ForEach(func(voterID hotstuff.ID) {
+		reputations[voterID] += reputation
+	})
+	return reputations
+}
+
 // NewRepBased returns a new random reputation-based leader rotation implementation
 func NewRepBased() modules.LeaderRotation {
 	return &repBased{
-		reputations:    make(reputationsMap),
-		prevCommitHead: hotstuff.GetGenesis(),
+		reputations: map[hotstuff.Hash]reputationsEntry{
+			hotstuff.GetGenesis().Hash(): {reputations: make(reputationsMap)},
+		},
 	}
 }
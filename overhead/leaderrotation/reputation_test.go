@@ -0,0 +1,56 @@
+package leaderrotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+// blockChainStub is a block chain that holds the given blocks.
+type blockChainStub struct {
+	modules.BlockChain
+	blocks map[hotstuff.Hash]*hotstuff.Block
+}
+
+func (bc blockChainStub) Get(hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	block, ok := bc.blocks[hash]
+	return block, ok
+}
+
+func TestReputationPrune(t *testing.T) {
+	chain := blockChainStub{blocks: map[hotstuff.Hash]*hotstuff.Block{hotstuff.GetGenesis().Hash(): hotstuff.GetGenesis()}}
+	blocks := []*hotstuff.Block{hotstuff.GetGenesis()}
+	for view := hotstuff.View(1); view <= 21; view++ {
+		parent := blocks[len(blocks)-1]
+		block := hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash(), nil), "", view, 1, time.Time{})
+		chain.blocks[block.Hash()] = block
+		blocks = append(blocks, block)
+	}
+	stub := &committedRankingStub{committed: blocks[20]}
+	r := NewRepBased().(*repBased)
+	r.blockChain = chain
+	r.consensus = stub
+	r.configuration = configurationStub{n: 4}
+
+	if _, ok := r.reputationsAt(blocks[20]); !ok {
+		t.Fatal("failed to compute the reputations")
+	}
+	r.prune()
+	// the committed block is in view 20, and ChainLength is 3
+	if len(r.reputations) != 5 {
+		t.Errorf("kept the reputations of %d blocks, want 5", len(r.reputations))
+	}
+	for _, block := range []*hotstuff.Block{blocks[0], blocks[17], blocks[18], blocks[19], blocks[20]} {
+		if _, ok := r.reputations[block.Hash()]; !ok {
+			t.Errorf("expected the reputations after the block in view %d to be kept", block.View())
+		}
+	}
+	if _, ok := r.reputationsAt(blocks[21]); !ok {
+		t.Error("failed to compute the reputations of a new block after pruning")
+	}
+	if _, ok := r.reputationsAt(blocks[10]); !ok {
+		t.Error("failed to compute the reputations of an old block after pruning")
+	}
+}
@@ -17,9 +17,21 @@ func (rr *roundRobin) InitModule(mods *modules.Core) {
 	mods.Get(&rr.configuration)
 }
 
-// GetLeader returns the id of the leader in the given view
+// activeSchedule is implemented by configurations that keep track of
+// the active replicas in each view across reconfigurations.
+type activeSchedule interface {
+	ActiveReplicasAt(view hotstuff.View) ([]hotstuff.ID, bool)
+}
+
+// GetLeader returns the id of the leader in the given view.
+// After a reconfiguration, the leaders rotate among the active replicas of the reconfiguration.
+// Before that, they rotate among the replicas that are active.
 func (rr roundRobin) GetLeader(view hotstuff.View) hotstuff.ID {
-	// TODO: does not support reconfiguration
+	if schedule, ok := rr.configuration.(activeSchedule); ok {
+		if active, ok := schedule.ActiveReplicasAt(view); ok && len(active) > 0 {
+			return active[int(view%hotstuff.View(len(active)))]
+		}
+	}
 	// assume IDs start at 1
 	leader := hotstuff.ID(1)
 	for true {
@@ -0,0 +1,50 @@
+package leaderrotation
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+// replicaStub is a replica that is either active or passive.
+type replicaStub struct {
+	modules.Replica
+	active bool
+}
+
+func (r replicaStub) Active() bool { return r.active }
+
+// scheduleStub is a configuration of four replicas, where replica 2 is passive,
+// with a reconfiguration to the replicas 3 and 4 in view 10.
+type scheduleStub struct {
+	modules.Configuration
+}
+
+func (scheduleStub) Len() int { return 4 }
+
+func (scheduleStub) Replica(id hotstuff.ID) (modules.Replica, bool) {
+	return replicaStub{active: id != 2}, id >= 1 && id <= 4
+}
+
+func (scheduleStub) ActiveReplicasAt(view hotstuff.View) ([]hotstuff.ID, bool) {
+	if view < 10 {
+		return nil, false
+	}
+	return []hotstuff.ID{3, 4}, true
+}
+
+func TestRoundRobinActive(t *testing.T) {
+	rr := roundRobin{configuration: scheduleStub{}}
+	// before the reconfiguration, the passive replica 2 is skipped
+	for view, want := range []hotstuff.ID{1, 3, 3, 4, 1, 3} {
+		if got := rr.GetLeader(hotstuff.View(view)); got != want {
+			t.Errorf("GetLeader(%d) = %d, want %d", view, got, want)
+		}
+	}
+	for view, want := range map[hotstuff.View]hotstuff.ID{10: 3, 11: 4, 12: 3} {
+		if got := rr.GetLeader(view); got != want {
+			t.Errorf("GetLeader(%d) = %d, want %d", view, got, want)
+		}
+	}
+}
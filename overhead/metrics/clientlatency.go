@@ -19,6 +19,7 @@ func init() {
 // ClientLatency processes LatencyMeasurementEvents, and writes LatencyMeasurements to the metrics logger.
 type ClientLatency struct {
 	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
 	opts          *modules.Options
 
 	wf Welford
@@ -26,24 +27,21 @@ type ClientLatency struct {
 
 // InitModule gives the module access to the other modules.
 func (lr *ClientLatency) InitModule(mods *modules.Core) {
-	var (
-		eventLoop *eventloop.EventLoop
-		logger    logging.Logger
-	)
+	var logger logging.Logger
 
 	mods.Get(
 		&lr.metricsLogger,
 		&lr.opts,
-		&eventLoop,
+		&lr.eventLoop,
 		&logger,
 	)
 
-	eventLoop.RegisterHandler(client.LatencyMeasurementEvent{}, func(event any) {
+	lr.eventLoop.RegisterHandler(client.LatencyMeasurementEvent{}, func(event any) {
 		latencyEvent := event.(client.LatencyMeasurementEvent)
 		lr.addLatency(latencyEvent.Latency)
 	})
 
-	eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+	lr.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
 		lr.tick(event.(types.TickEvent))
 	})
 
@@ -59,7 +57,7 @@ func (lr *ClientLatency) addLatency(latency time.Duration) {
 func (lr *ClientLatency) tick(_ types.TickEvent) {
 	mean, variance, count := lr.wf.Get()
 	event := &types.LatencyMeasurement{
-		Event:    types.NewClientEvent(uint32(lr.opts.ID()), time.Now()),
+		Event:    types.NewClientEvent(uint32(lr.opts.ID()), lr.eventLoop.Now()),
 		Latency:  mean,
 		Variance: variance,
 		Count:    count,
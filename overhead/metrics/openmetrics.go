@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/client"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+// OpenMetrics exports live metrics of the replicas and clients of a worker in the OpenMetrics text format.
+// Unlike the metrics that are written to the metrics logger, these are updated as events happen,
+// and do not depend on the measurement interval.
+type OpenMetrics struct {
+	registry *prometheus.Registry
+
+	commits       *prometheus.CounterVec
+	commands      *prometheus.CounterVec
+	views         *prometheus.GaugeVec
+	timeouts      *prometheus.CounterVec
+	suspicions    *prometheus.GaugeVec
+	treePositions *prometheus.GaugeVec
+	treeLatency   *prometheus.GaugeVec
+	latency       *prometheus.HistogramVec
+}
+
+// NewOpenMetrics returns a new OpenMetrics exporter.
+func NewOpenMetrics() *OpenMetrics {
+	replica := []string{"replica"}
+	om := &OpenMetrics{
+		registry: prometheus.NewRegistry(),
+		commits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hotstuff",
+			Name:      "commits_total",
+			Help:      "Number of blocks committed by the replica.",
+		}, replica),
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hotstuff",
+			Name:      "commands_total",
+			Help:      "Number of client commands executed by the replica.",
+		}, replica),
+		views: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "hotstuff",
+			Name:      "view",
+			Help:      "The current view of the replica.",
+		}, replica),
+		timeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hotstuff",
+			Name:      "view_timeouts_total",
+			Help:      "Number of views that the replica left because of a timeout.",
+		}, replica),
+		suspicions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "hotstuff",
+			Name:      "suspicions",
+			Help:      "Number of suspicions against the suspect that the replica has recorded.",
+		}, []string{"replica", "suspect"}),
+		treePositions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "hotstuff",
+			Name:      "kauri_tree_position",
+			Help:      "Position of the member in the Kauri tree of the replica, where the root has position 0.",
+		}, []string{"replica", "member"}),
+		treeLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "hotstuff",
+			Name:      "kauri_tree_predicted_qc_latency_seconds",
+			Help:      "QC latency of the Kauri tree of the replica, as predicted by the tree optimizer.",
+		}, replica),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "hotstuff",
+			Name:      "client_latency_seconds",
+			Help:      "Latency of the commands of the client.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"client"}),
+	}
+	om.registry.MustRegister(
+		om.commits, om.commands, om.views, om.timeouts, om.suspicions,
+		om.treePositions, om.treeLatency, om.latency,
+	)
+	return om
+}
+
+// Handler returns an HTTP handler that serves the metrics.
+func (om *OpenMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(om.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+// ReplicaModule returns a module that records the metrics of a replica.
+func (om *OpenMetrics) ReplicaModule() modules.Module {
+	return &replicaOpenMetrics{om: om}
+}
+
+// ClientModule returns a module that records the metrics of a client.
+func (om *OpenMetrics) ClientModule() modules.Module {
+	return &clientOpenMetrics{om: om}
+}
+
+type replicaOpenMetrics struct {
+	om      *OpenMetrics
+	ranking modules.Ranking
+	id      string
+}
+
+// InitModule gives the module access to the other modules.
+func (r *replicaOpenMetrics) InitModule(mods *modules.Core) {
+	var (
+		eventLoop *eventloop.EventLoop
+		opts      *modules.Options
+	)
+	mods.Get(&eventLoop, &opts)
+	mods.TryGet(&r.ranking)
+	r.id = strconv.Itoa(int(opts.ID()))
+
+	eventLoop.RegisterObserver(hotstuff.CommitEvent{}, func(event any) {
+		r.om.commits.WithLabelValues(r.id).Inc()
+		r.om.commands.WithLabelValues(r.id).Add(float64(event.(hotstuff.CommitEvent).Commands))
+	})
+	eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		r.viewChange(event.(synchronizer.ViewChangeEvent))
+	})
+	eventLoop.RegisterObserver(hotstuff.TreeChangeEvent{}, func(event any) {
+		r.treeChange(event.(hotstuff.TreeChangeEvent))
+	})
+}
+
+func (r *replicaOpenMetrics) viewChange(event synchronizer.ViewChangeEvent) {
+	r.om.views.WithLabelValues(r.id).Set(float64(event.View))
+	if event.Timeout {
+		r.om.timeouts.WithLabelValues(r.id).Inc()
+	}
+	if r.ranking == nil {
+		return
+	}
+	for suspect, suspectors := range r.ranking.GetSuspicionMatrix() {
+		count := 0
+		for _, n := range suspectors {
+			count += n
+		}
+		r.om.suspicions.WithLabelValues(r.id, strconv.Itoa(int(suspect))).Set(float64(count))
+	}
+}
+
+func (r *replicaOpenMetrics) treeChange(event hotstuff.TreeChangeEvent) {
+	r.om.treePositions.DeletePartialMatch(prometheus.Labels{"replica": r.id})
+	for pos, member := range event.Tree {
+		r.om.treePositions.WithLabelValues(r.id, strconv.Itoa(int(member))).Set(float64(pos))
+	}
+	r.om.treeLatency.WithLabelValues(r.id).Set(event.Predicted.Seconds())
+}
+
+type clientOpenMetrics struct {
+	om *OpenMetrics
+}
+
+// InitModule gives the module access to the other modules.
+func (c *clientOpenMetrics) InitModule(mods *modules.Core) {
+	var (
+		eventLoop *eventloop.EventLoop
+		opts      *modules.Options
+	)
+	mods.Get(&eventLoop, &opts)
+	latency := c.om.latency.WithLabelValues(strconv.Itoa(int(opts.ID())))
+
+	eventLoop.RegisterObserver(client.LatencyMeasurementEvent{}, func(event any) {
+		latency.Observe(event.(client.LatencyMeasurementEvent).Latency.Seconds())
+	})
+}
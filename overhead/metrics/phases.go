@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	RegisterReplicaMetric("consensus-phases", func() any {
+		return &ConsensusPhases{}
+	})
+}
+
+// ConsensusPhases records the start and end of the dissemination, aggregation,
+// QC formation and commit phases of each view.
+type ConsensusPhases struct {
+	metricsLogger Logger
+	opts          *modules.Options
+}
+
+// InitModule gives the module access to the other modules.
+func (cp *ConsensusPhases) InitModule(mods *modules.Core) {
+	var (
+		eventLoop *eventloop.EventLoop
+		logger    logging.Logger
+	)
+
+	mods.Get(
+		&cp.metricsLogger,
+		&cp.opts,
+		&eventLoop,
+		&logger,
+	)
+
+	eventLoop.RegisterHandler(hotstuff.PhaseEvent{}, func(event any) {
+		cp.recordPhase(event.(hotstuff.PhaseEvent))
+	})
+
+	logger.Info("ConsensusPhases metric enabled")
+}
+
+func (cp *ConsensusPhases) recordPhase(event hotstuff.PhaseEvent) {
+	phase := &types.ConsensusPhase{
+		Event: types.NewReplicaEvent(uint32(cp.opts.ID()), event.End),
+		View:  uint64(event.View),
+		Phase: string(event.Phase),
+		Start: timestamppb.New(event.Start),
+		End:   timestamppb.New(event.End),
+	}
+	if event.Predicted > 0 {
+		phase.Predicted = durationpb.New(event.Predicted)
+	}
+	cp.metricsLogger.Log(phase)
+}
@@ -0,0 +1,262 @@
+package plotting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// metricLabels are the axis labels of the summary metrics.
+var metricLabels = map[string]string{
+	MetricLatency:       "Latency (ms)",
+	MetricThroughput:    "Throughput (commands/second)",
+	MetricTimeouts:      "View timeouts (per interval)",
+	MetricProposalBytes: "Proposal bytes (per interval)",
+	MetricDissemination: "Dissemination (ms)",
+	MetricAggregation:   "Aggregation (ms)",
+	MetricQC:            "QC latency (ms)",
+	MetricCommit:        "Commit latency (ms)",
+	MetricQCPrediction:  "QC prediction error (ms)",
+	MetricSentMessages:  "Sent messages (per interval)",
+	MetricSentBytes:     "Sent bytes (per interval)",
+}
+
+// Comparison compares the metrics of several configurations, such as Kauri and OptiTree,
+// or different numbers of faults. Each configuration is identified by a label, and may have been run several times.
+type Comparison struct {
+	labels []string // in the order they were first added
+	runs   map[string][]*Summary
+}
+
+// NewComparison returns a new Comparison.
+func NewComparison() Comparison {
+	return Comparison{runs: make(map[string][]*Summary)}
+}
+
+// AddRun adds the measurements of one run of the configuration with the given label.
+func (c *Comparison) AddRun(label string, run *Summary) {
+	if _, ok := c.runs[label]; !ok {
+		c.labels = append(c.labels, label)
+	}
+	c.runs[label] = append(c.runs[label], run)
+}
+
+// ComparisonRow holds the statistics of one metric of one configuration across its runs.
+// The value of a run is the mean of the metric over all clients/replicas.
+type ComparisonRow struct {
+	Label  string  `json:"label"`
+	Metric string  `json:"metric"`
+	Runs   int     `json:"runs"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	CI95   float64 `json:"ci95"` // half-width of the 95% confidence interval of the mean; 0 with only one run
+}
+
+// Compute returns the statistics of the given metrics for each configuration, in the order the configurations were added.
+// The warmup and cooldown are applied to each run as in Summary.Compute. Runs without measurements of a metric are ignored.
+func (c *Comparison) Compute(metrics []string, warmup, cooldown time.Duration) []ComparisonRow {
+	var rows []ComparisonRow
+	for _, label := range c.labels {
+		values := make(map[string][]float64)
+		for _, run := range c.runs[label] {
+			for _, row := range run.Compute(label, warmup, cooldown) {
+				if row.ID == 0 {
+					values[row.Metric] = append(values[row.Metric], row.Mean)
+				}
+			}
+		}
+		for _, metric := range metrics {
+			if v, ok := values[metric]; ok {
+				rows = append(rows, comparisonRow(label, metric, v))
+			}
+		}
+	}
+	return rows
+}
+
+// Metrics returns the metrics that were measured in any run, in the order of the summary metrics.
+func (c *Comparison) Metrics() []string {
+	measured := make(map[string]bool)
+	var all []string
+	for _, label := range c.labels {
+		for _, run := range c.runs[label] {
+			for _, metric := range run.metrics() {
+				base, _, _ := strings.Cut(metric, "/")
+				if _, ok := run.measurements[base]; ok && !measured[metric] {
+					measured[metric] = true
+					all = append(all, metric)
+				}
+			}
+		}
+	}
+	return all
+}
+
+func comparisonRow(label, metric string, values []float64) ComparisonRow {
+	row := ComparisonRow{Label: label, Metric: metric, Runs: len(values)}
+	row.Mean = stat.Mean(values, nil)
+	if n := len(values); n > 1 {
+		row.StdDev = stat.StdDev(values, nil)
+		t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(n - 1)}.Quantile(0.975)
+		row.CI95 = t * row.StdDev / math.Sqrt(float64(n))
+	}
+	return row
+}
+
+var comparisonHeaders = []string{"label", "metric", "runs", "mean", "stddev", "ci95", "lower", "upper"}
+
+// WriteComparisonCSV writes the rows in CSV format, including the bounds of the confidence intervals.
+func WriteComparisonCSV(wr io.Writer, rows []ComparisonRow) error {
+	w := csv.NewWriter(wr)
+	if err := w.Write(comparisonHeaders); err != nil {
+		return err
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, row := range rows {
+		err := w.Write([]string{
+			row.Label, row.Metric, strconv.Itoa(row.Runs), format(row.Mean), format(row.StdDev),
+			format(row.CI95), format(row.Mean - row.CI95), format(row.Mean + row.CI95),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// PlotComparison plots the mean of the metric for each configuration, with error bars showing the 95% confidence intervals.
+// It draws a bar chart, or a line if line is true. The line uses the labels as x values if they are all numbers,
+// e.g. fault counts. The CSV format contains the aggregated numbers of the metric.
+func PlotComparison(filename, metric string, rows []ComparisonRow, line bool) error {
+	var points comparisonPoints
+	for _, row := range rows {
+		if row.Metric == metric {
+			points = append(points, row)
+		}
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("no measurements of '%s' found", metric)
+	}
+	if path.Ext(filename) == ".csv" {
+		return writeComparisonFile(filename, points)
+	}
+
+	ylabel, ok := metricLabels[metric]
+	if !ok {
+		ylabel = metric
+	}
+	numeric := line && points.numeric()
+	return GonumPlot(filename, "", ylabel, func(plt *plot.Plot) error {
+		if line {
+			xys := make(plotter.XYs, len(points))
+			for i, p := range points {
+				xys[i].X, xys[i].Y = points.x(i, numeric), p.Mean
+			}
+			if err := plotutil.AddLinePoints(plt, xys); err != nil {
+				return fmt.Errorf("failed to add line plot: %w", err)
+			}
+		} else {
+			bars, err := plotter.NewBarChart(points, vg.Points(30))
+			if err != nil {
+				return fmt.Errorf("failed to add bar chart: %w", err)
+			}
+			bars.Color = plotutil.Color(0)
+			plt.Add(bars)
+		}
+		errorBars, err := plotter.NewYErrorBars(comparisonErrors{points, numeric})
+		if err != nil {
+			return fmt.Errorf("failed to add error bars: %w", err)
+		}
+		plt.Add(errorBars)
+		if !numeric {
+			labels := make([]string, len(points))
+			for i, p := range points {
+				labels[i] = p.Label
+			}
+			plt.NominalX(labels...)
+		}
+		// keep the outermost bars and error bars clear of the axes
+		padding := 0.5
+		if numeric {
+			padding = max((plt.X.Max-plt.X.Min)*0.05, 0.5)
+		}
+		plt.X.Min -= padding
+		plt.X.Max += padding
+		return nil
+	})
+}
+
+func writeComparisonFile(filename string, rows []ComparisonRow) (err error) {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return WriteComparisonCSV(f, rows)
+}
+
+// comparisonPoints are the rows of one metric.
+type comparisonPoints []ComparisonRow
+
+// Len returns the number of configurations.
+func (p comparisonPoints) Len() int {
+	return len(p)
+}
+
+// Value returns the mean of configuration i.
+func (p comparisonPoints) Value(i int) float64 {
+	return p[i].Mean
+}
+
+// numeric returns true if all labels are numbers.
+func (p comparisonPoints) numeric() bool {
+	for _, row := range p {
+		if _, err := strconv.ParseFloat(row.Label, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// x returns the x value of configuration i, which is either its label or its index.
+func (p comparisonPoints) x(i int, numeric bool) float64 {
+	if numeric {
+		x, _ := strconv.ParseFloat(p[i].Label, 64)
+		return x
+	}
+	return float64(i)
+}
+
+// comparisonErrors are the confidence intervals of the rows of one metric.
+type comparisonErrors struct {
+	comparisonPoints
+	numeric bool
+}
+
+// XY returns the position of configuration i.
+func (e comparisonErrors) XY(i int) (x, y float64) {
+	return e.x(i, e.numeric), e.comparisonPoints[i].Mean
+}
+
+// YError returns the confidence interval of configuration i.
+func (e comparisonErrors) YError(i int) (low, high float64) {
+	return e.comparisonPoints[i].CI95, e.comparisonPoints[i].CI95
+}
@@ -0,0 +1,76 @@
+package plotting
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// throughputRun returns the summary of a run where the replica had the given throughput.
+func throughputRun(throughput uint64) *Summary {
+	start := time.Unix(1000, 0)
+	summary := NewSummary()
+	summary.Add(&types.StartEvent{Event: types.NewReplicaEvent(1, start)})
+	for i := 1; i <= 3; i++ {
+		summary.Add(&types.ThroughputMeasurement{
+			Event:    types.NewReplicaEvent(1, start.Add(time.Duration(i)*time.Second)),
+			Commands: throughput,
+			Duration: durationpb.New(time.Second),
+		})
+	}
+	return &summary
+}
+
+func TestComparison(t *testing.T) {
+	comparison := NewComparison()
+	comparison.AddRun("kauri", throughputRun(100))
+	comparison.AddRun("optitree", throughputRun(200))
+	comparison.AddRun("kauri", throughputRun(110))
+	comparison.AddRun("optitree", throughputRun(220))
+	comparison.AddRun("kauri", throughputRun(120))
+
+	if metrics := comparison.Metrics(); len(metrics) != 1 || metrics[0] != MetricThroughput {
+		t.Fatalf("got metrics %v, want [%s]", metrics, MetricThroughput)
+	}
+	rows := comparison.Compute(comparison.Metrics(), 0, 0)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2: %v", len(rows), rows)
+	}
+	// t(0.975, 2) = 4.303 and t(0.975, 1) = 12.706
+	want := []ComparisonRow{
+		{Label: "kauri", Metric: MetricThroughput, Runs: 3, Mean: 110, StdDev: 10, CI95: 4.302653 * 10 / math.Sqrt(3)},
+		{Label: "optitree", Metric: MetricThroughput, Runs: 2, Mean: 210, StdDev: math.Sqrt(200), CI95: 12.706205 * math.Sqrt(200) / math.Sqrt(2)},
+	}
+	for i, row := range rows {
+		w := want[i]
+		if row.Label != w.Label || row.Metric != w.Metric || row.Runs != w.Runs ||
+			math.Abs(row.Mean-w.Mean) > 1e-9 || math.Abs(row.StdDev-w.StdDev) > 1e-9 || math.Abs(row.CI95-w.CI95) > 1e-3 {
+			t.Errorf("row %d: got %+v, want %+v", i, row, w)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteComparisonCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "kauri,throughput,3,110,10,") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	dir := t.TempDir()
+	for _, line := range []bool{false, true} {
+		if err := PlotComparison(filepath.Join(dir, "comparison.png"), MetricThroughput, rows, line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := PlotComparison(filepath.Join(dir, "comparison.png"), MetricLatency, rows, false); err == nil {
+		t.Error("expected an error when comparing a metric without measurements")
+	}
+}
@@ -99,7 +99,7 @@ func GroupByTimeInterval(startTimes *StartTimes, m MeasurementMap, interval time
 			for indices[i] < len(measurements) {
 				m := measurements[indices[i]]
 				// check if this measurement falls within the current time interval
-				t, ok := startTimes.ClientOffset(m.GetEvent().GetID(), m.GetEvent().GetTimestamp().AsTime())
+				t, ok := startTimes.Offset(m.GetEvent())
 				if ok && t < currentTime+interval {
 					// add it to the group and move to the next measurement
 					group.Measurements = append(group.Measurements, m)
@@ -0,0 +1,146 @@
+package plotting
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+)
+
+// PredictionRow compares the measured QC latency of a view with the predicted QC latency.
+// All latencies are in milliseconds.
+type PredictionRow struct {
+	View      uint64  `json:"view"`
+	Measured  float64 `json:"measured"`
+	Predicted float64 `json:"predicted"`
+	// Replica is the QC latency that the leader predicted for its tree, or 0 if it did not predict one.
+	Replica       float64 `json:"replica"`
+	Error         float64 `json:"error"`          // measured minus predicted
+	RelativeError float64 `json:"relative_error"` // error divided by predicted
+}
+
+// PredictionReport holds the per-view comparison of measured and predicted QC latency, and the statistics of the error.
+type PredictionReport struct {
+	Predicted         float64         `json:"predicted"`
+	Views             []PredictionRow `json:"views"`
+	Error             Stats           `json:"error"`
+	RelativeError     Stats           `json:"relative_error"`
+	MeanAbsoluteError float64         `json:"mean_absolute_error"`
+}
+
+// QCPrediction collects the QC latencies recorded by the consensus-phases metric
+// in order to compare them with a predicted QC latency.
+type QCPrediction struct {
+	startTimes StartTimes
+	phases     map[uint64]*types.ConsensusPhase
+}
+
+// NewQCPrediction returns a new QCPrediction.
+func NewQCPrediction() QCPrediction {
+	return QCPrediction{
+		startTimes: NewStartTimes(),
+		phases:     make(map[uint64]*types.ConsensusPhase),
+	}
+}
+
+// Add adds a measurement.
+func (p *QCPrediction) Add(measurement any) {
+	p.startTimes.Add(measurement)
+	phase, ok := measurement.(*types.ConsensusPhase)
+	if !ok || phase.GetPhase() != "qc" {
+		return
+	}
+	// only the first QC of each view counts
+	if old, ok := p.phases[phase.GetView()]; ok && !old.GetEnd().AsTime().After(phase.GetEnd().AsTime()) {
+		return
+	}
+	p.phases[phase.GetView()] = phase
+}
+
+// Compute compares the QC latency of each view with the predicted latency.
+// Views whose QC was formed less than warmup after the replica started are ignored.
+func (p *QCPrediction) Compute(predicted, warmup time.Duration) PredictionReport {
+	report := PredictionReport{Predicted: milliseconds(predicted)}
+	views := make([]uint64, 0, len(p.phases))
+	for view := range p.phases {
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i] < views[j] })
+
+	var errs, relErrs []sample
+	for _, view := range views {
+		phase := p.phases[view]
+		event := phase.GetEvent()
+		if t, ok := p.startTimes.ReplicaOffset(event.GetID(), event.GetTimestamp().AsTime()); ok && t < warmup {
+			continue
+		}
+		row := PredictionRow{
+			View:      view,
+			Measured:  milliseconds(phase.GetEnd().AsTime().Sub(phase.GetStart().AsTime())),
+			Predicted: report.Predicted,
+		}
+		if phase.GetPredicted() != nil {
+			row.Replica = milliseconds(phase.GetPredicted().AsDuration())
+		}
+		row.Error = row.Measured - row.Predicted
+		if row.Predicted > 0 {
+			row.RelativeError = row.Error / row.Predicted
+		}
+		report.Views = append(report.Views, row)
+		errs = append(errs, sample{value: row.Error, weight: 1})
+		relErrs = append(relErrs, sample{value: row.RelativeError, weight: 1})
+		report.MeanAbsoluteError += math.Abs(row.Error)
+	}
+	if len(report.Views) > 0 {
+		report.Error = computeStats(errs)
+		report.RelativeError = computeStats(relErrs)
+		report.MeanAbsoluteError /= float64(len(report.Views))
+	}
+	return report
+}
+
+var predictionHeaders = []string{"view", "measured", "predicted", "replica", "error", "relative_error"}
+
+// WritePredictionCSV writes a row for each view, followed by a row with the means of all views, which has the view "all".
+func WritePredictionCSV(wr io.Writer, report PredictionReport) error {
+	w := csv.NewWriter(wr)
+	if err := w.Write(predictionHeaders); err != nil {
+		return err
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	var mean PredictionRow
+	for _, row := range report.Views {
+		err := w.Write([]string{
+			strconv.FormatUint(row.View, 10), format(row.Measured), format(row.Predicted),
+			format(row.Replica), format(row.Error), format(row.RelativeError),
+		})
+		if err != nil {
+			return err
+		}
+		mean.Measured += row.Measured
+		mean.Replica += row.Replica
+	}
+	if n := float64(len(report.Views)); n > 0 {
+		err := w.Write([]string{
+			"all", format(mean.Measured / n), format(report.Predicted),
+			format(mean.Replica / n), format(report.Error.Mean), format(report.RelativeError.Mean),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WritePredictionJSON writes the report as JSON.
+func WritePredictionJSON(wr io.Writer, report PredictionReport) error {
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,52 @@
+package plotting
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestQCPrediction(t *testing.T) {
+	start := time.Unix(1000, 0)
+	prediction := NewQCPrediction()
+	prediction.Add(&types.StartEvent{Event: types.NewReplicaEvent(1, start)})
+	qc := func(view uint64, begin time.Time, latency time.Duration) *types.ConsensusPhase {
+		return &types.ConsensusPhase{
+			Event:     types.NewReplicaEvent(1, begin.Add(latency)),
+			View:      view,
+			Phase:     "qc",
+			Start:     timestamppb.New(begin),
+			End:       timestamppb.New(begin.Add(latency)),
+			Predicted: durationpb.New(100 * time.Millisecond),
+		}
+	}
+	prediction.Add(qc(1, start, 500*time.Millisecond)) // ignored by the warmup
+	prediction.Add(qc(2, start.Add(time.Second), 110*time.Millisecond))
+	prediction.Add(qc(3, start.Add(2*time.Second), 90*time.Millisecond))
+	prediction.Add(qc(3, start.Add(2*time.Second), 120*time.Millisecond)) // a later QC for the same view
+	prediction.Add(&types.ConsensusPhase{Event: types.NewReplicaEvent(2, start), View: 2, Phase: "commit"})
+
+	report := prediction.Compute(100*time.Millisecond, time.Second)
+	want := []PredictionRow{
+		{View: 2, Measured: 110, Predicted: 100, Replica: 100, Error: 10, RelativeError: 0.1},
+		{View: 3, Measured: 90, Predicted: 100, Replica: 100, Error: -10, RelativeError: -0.1},
+	}
+	if len(report.Views) != len(want) {
+		t.Fatalf("got %d views, want %d: %v", len(report.Views), len(want), report.Views)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for i, row := range report.Views {
+		w := want[i]
+		if row.View != w.View || !near(row.Measured, w.Measured) || !near(row.Predicted, w.Predicted) ||
+			!near(row.Replica, w.Replica) || !near(row.Error, w.Error) || !near(row.RelativeError, w.RelativeError) {
+			t.Errorf("view %d: got %+v, want %+v", w.View, row, w)
+		}
+	}
+	if !near(report.Error.Mean, 0) || !near(report.MeanAbsoluteError, 10) {
+		t.Errorf("got mean error %v and mean absolute error %v, want 0 and 10", report.Error.Mean, report.MeanAbsoluteError)
+	}
+}
@@ -63,3 +63,11 @@ func (s *StartTimes) ReplicaOffset(id uint32, t time.Time) (offset time.Duration
 	}
 	return t.Sub(startTime), true
 }
+
+// Offset returns the time offset of the event from the start time of the client or replica that recorded it.
+func (s *StartTimes) Offset(event *types.Event) (offset time.Duration, ok bool) {
+	if event.GetClient() {
+		return s.ClientOffset(event.GetID(), event.GetTimestamp().AsTime())
+	}
+	return s.ReplicaOffset(event.GetID(), event.GetTimestamp().AsTime())
+}
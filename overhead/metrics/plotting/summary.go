@@ -0,0 +1,397 @@
+package plotting
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+// The metrics computed by Summary.
+const (
+	MetricLatency       = "latency"        // client latency in milliseconds
+	MetricThroughput    = "throughput"     // replica throughput in commands per second
+	MetricTimeouts      = "timeouts"       // view timeouts per measurement interval
+	MetricProposalBytes = "proposal-bytes" // bytes proposed per measurement interval
+
+	MetricDissemination = "phase-dissemination" // time in milliseconds for a proposal to reach a replica from its sender
+	MetricAggregation   = "phase-aggregation"   // time in milliseconds for a replica to aggregate the votes of its subtree
+	MetricQC            = "phase-qc"            // time in milliseconds for the leader to form a QC for its proposal
+	MetricCommit        = "phase-commit"        // time in milliseconds from a block was sent until it was committed
+	MetricQCPrediction  = "qc-prediction-error" // measured minus predicted QC latency in milliseconds
+
+	MetricSentMessages = "sent-messages" // messages sent to other replicas per measurement interval
+	MetricSentBytes    = "sent-bytes"    // serialized bytes sent to other replicas per measurement interval
+)
+
+var summaryMetrics = []string{
+	MetricLatency, MetricThroughput, MetricTimeouts, MetricProposalBytes,
+	MetricDissemination, MetricAggregation, MetricQC, MetricCommit, MetricQCPrediction,
+	MetricSentMessages, MetricSentBytes,
+}
+
+// predictionError is a QC phase measurement with a predicted duration.
+type predictionError struct {
+	*types.ConsensusPhase
+}
+
+// sentTraffic is a traffic measurement, restricted to the messages sent with the given method, or all methods if empty.
+type sentTraffic struct {
+	*types.TrafficMeasurement
+	method string
+	bytes  bool
+}
+
+func (st sentTraffic) sum() (sum uint64) {
+	for _, count := range st.GetCounts() {
+		if !count.GetSent() || (st.method != "" && methodName(count.GetMethod()) != st.method) {
+			continue
+		}
+		if st.bytes {
+			sum += count.GetBytes()
+		} else {
+			sum += count.GetMessages()
+		}
+	}
+	return sum
+}
+
+// methodName strips the package name from a full gRPC method name, e.g. hotstuffpb.Hotstuff.Propose becomes Hotstuff.Propose.
+func methodName(method string) string {
+	if i := strings.Index(method, "."); i >= 0 {
+		return method[i+1:]
+	}
+	return method
+}
+
+// Stats holds summary statistics for a metric.
+type Stats struct {
+	Count  uint64  `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Min    float64 `json:"min"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	P99    float64 `json:"p99"`
+	Max    float64 `json:"max"`
+	Sum    float64 `json:"sum"`
+}
+
+// SummaryRow holds the statistics for one metric of one experiment,
+// either for a single client/replica, or for all of them if ID is 0.
+type SummaryRow struct {
+	Experiment string `json:"experiment"`
+	Metric     string `json:"metric"`
+	ID         uint32 `json:"id"`
+	Stats
+}
+
+// sample is a value that was measured in one measurement interval.
+// The weight is the number of observations that the value represents,
+// and the variance is the sample variance of those observations.
+type sample struct {
+	value    float64
+	weight   float64
+	variance float64
+}
+
+// Summary computes summary statistics of client latency, throughput, view timeouts, proposal bytes,
+// the durations of the consensus phases, and the messages and bytes sent to other replicas.
+// The sent messages and bytes are also computed for each gRPC method, as the metrics
+// sent-messages/<method> and sent-bytes/<method>.
+type Summary struct {
+	startTimes   StartTimes
+	measurements map[string]MeasurementMap
+	methods      map[string]bool // the gRPC methods of sent messages
+}
+
+// NewSummary returns a new Summary.
+func NewSummary() Summary {
+	return Summary{
+		startTimes:   NewStartTimes(),
+		measurements: make(map[string]MeasurementMap),
+		methods:      make(map[string]bool),
+	}
+}
+
+// ReadSummary reads the measurements of an experiment, given either a measurements file or the output directory
+// of an experiment, in which case all '*/measurements.json' files in the directory are read.
+func ReadSummary(experiment string) (*Summary, error) {
+	info, err := os.Stat(experiment)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{experiment}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(experiment, "*", "measurements.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no measurements found in '%s'", experiment)
+		}
+	}
+	summary := NewSummary()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = NewReader(f, &summary).ReadAll()
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", file, err)
+		}
+	}
+	return &summary, nil
+}
+
+// Add adds a measurement to the summary.
+func (s *Summary) Add(measurement any) {
+	s.startTimes.Add(measurement)
+
+	var metric string
+	switch m := measurement.(type) {
+	case *types.LatencyMeasurement:
+		if !m.GetEvent().GetClient() || m.GetCount() == 0 {
+			return
+		}
+		metric = MetricLatency
+	case *types.ThroughputMeasurement:
+		if m.GetEvent().GetClient() || m.GetDuration().AsDuration() <= 0 {
+			return
+		}
+		metric = MetricThroughput
+	case *types.ViewTimeouts:
+		if m.GetEvent().GetClient() {
+			return
+		}
+		metric = MetricTimeouts
+	case *types.SentBytes:
+		if m.GetEvent().GetClient() {
+			return
+		}
+		metric = MetricProposalBytes
+	case *types.ConsensusPhase:
+		metric = "phase-" + m.GetPhase()
+		if m.GetPredicted() != nil {
+			s.add(MetricQCPrediction, predictionError{m})
+		}
+	case *types.TrafficMeasurement:
+		if m.GetEvent().GetClient() {
+			return
+		}
+		for _, count := range m.GetCounts() {
+			if count.GetSent() {
+				s.methods[methodName(count.GetMethod())] = true
+			}
+		}
+		s.add(MetricSentMessages, sentTraffic{m, "", false})
+		s.add(MetricSentBytes, sentTraffic{m, "", true})
+		return
+	default:
+		return
+	}
+	s.add(metric, measurement.(Measurement))
+}
+
+func (s *Summary) add(metric string, m Measurement) {
+	mm, ok := s.measurements[metric]
+	if !ok {
+		mm = NewMeasurementMap()
+		s.measurements[metric] = mm
+	}
+	mm.Add(m.GetEvent().GetID(), m)
+}
+
+// Compute returns the statistics of each metric for each client/replica, followed by the statistics for all of them.
+// Measurements taken less than warmup after the client/replica started, or less than cooldown before its last
+// measurement, are ignored. The percentiles of client latency are computed from the averages of each measurement interval.
+func (s *Summary) Compute(experiment string, warmup, cooldown time.Duration) []SummaryRow {
+	var rows []SummaryRow
+	for _, metric := range s.metrics() {
+		base, method, _ := strings.Cut(metric, "/")
+		mm, ok := s.measurements[base]
+		if !ok {
+			continue
+		}
+		ids := make([]uint32, 0, mm.NumIDs())
+		for id := range mm.m {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		var all []sample
+		for _, id := range ids {
+			measurements, _ := mm.Get(id)
+			if method != "" {
+				measurements = sentByMethod(measurements, method)
+			}
+			samples := s.trim(measurements, warmup, cooldown)
+			if len(samples) == 0 {
+				continue
+			}
+			all = append(all, samples...)
+			rows = append(rows, SummaryRow{Experiment: experiment, Metric: metric, ID: id, Stats: computeStats(samples)})
+		}
+		if len(all) > 0 {
+			rows = append(rows, SummaryRow{Experiment: experiment, Metric: metric, Stats: computeStats(all)})
+		}
+	}
+	return rows
+}
+
+// metrics returns the summary metrics, followed by the per-method metrics of the sent messages and bytes.
+func (s *Summary) metrics() []string {
+	methods := make([]string, 0, len(s.methods))
+	for method := range s.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	metrics := append([]string(nil), summaryMetrics...)
+	for _, method := range methods {
+		metrics = append(metrics, MetricSentMessages+"/"+method, MetricSentBytes+"/"+method)
+	}
+	return metrics
+}
+
+// sentByMethod restricts the traffic measurements to the given method.
+func sentByMethod(measurements []Measurement, method string) []Measurement {
+	restricted := make([]Measurement, len(measurements))
+	for i, m := range measurements {
+		st := m.(sentTraffic)
+		st.method = method
+		restricted[i] = st
+	}
+	return restricted
+}
+
+func (s *Summary) trim(measurements []Measurement, warmup, cooldown time.Duration) []sample {
+	offset := func(m Measurement) (time.Duration, bool) {
+		event := m.GetEvent()
+		if event.GetClient() {
+			return s.startTimes.ClientOffset(event.GetID(), event.GetTimestamp().AsTime())
+		}
+		return s.startTimes.ReplicaOffset(event.GetID(), event.GetTimestamp().AsTime())
+	}
+	var end time.Duration
+	for _, m := range measurements {
+		if t, ok := offset(m); ok && t > end {
+			end = t
+		}
+	}
+	samples := make([]sample, 0, len(measurements))
+	for _, m := range measurements {
+		if t, ok := offset(m); ok && (t < warmup || t > end-cooldown) {
+			continue
+		}
+		samples = append(samples, toSample(m))
+	}
+	return samples
+}
+
+func toSample(m Measurement) sample {
+	switch m := m.(type) {
+	case *types.LatencyMeasurement:
+		variance := m.GetVariance()
+		if math.IsNaN(variance) {
+			variance = 0
+		}
+		return sample{value: m.GetLatency(), weight: float64(m.GetCount()), variance: variance}
+	case *types.ThroughputMeasurement:
+		return sample{value: float64(m.GetCommands()) / m.GetDuration().AsDuration().Seconds(), weight: 1}
+	case *types.ViewTimeouts:
+		return sample{value: float64(m.GetTimeouts()), weight: 1}
+	case *types.SentBytes:
+		return sample{value: float64(m.GetSendEventrd()), weight: 1}
+	case *types.ConsensusPhase:
+		return sample{value: milliseconds(m.GetEnd().AsTime().Sub(m.GetStart().AsTime())), weight: 1}
+	case sentTraffic:
+		return sample{value: float64(m.sum()), weight: 1}
+	case predictionError:
+		measured := m.GetEnd().AsTime().Sub(m.GetStart().AsTime())
+		return sample{value: milliseconds(measured - m.GetPredicted().AsDuration()), weight: 1}
+	}
+	panic(fmt.Sprintf("unexpected measurement type %T", m))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// computeStats computes the statistics of the samples. The standard deviation
+// includes the variance within each sample, if any.
+func computeStats(samples []sample) Stats {
+	sort.Slice(samples, func(i, j int) bool { return samples[i].value < samples[j].value })
+	values := make([]float64, len(samples))
+	weights := make([]float64, len(samples))
+	var st Stats
+	for i, smp := range samples {
+		values[i], weights[i] = smp.value, smp.weight
+		st.Sum += smp.value * smp.weight
+	}
+	total := floats.Sum(weights)
+	st.Count = uint64(total)
+	st.Mean = st.Sum / total
+	st.Min = values[0]
+	st.Max = values[len(values)-1]
+	st.P50 = stat.Quantile(0.50, stat.Empirical, values, weights)
+	st.P90 = stat.Quantile(0.90, stat.Empirical, values, weights)
+	st.P99 = stat.Quantile(0.99, stat.Empirical, values, weights)
+	if total > 1 {
+		var ss float64
+		for _, smp := range samples {
+			d := smp.value - st.Mean
+			ss += smp.weight*d*d + math.Max(smp.weight-1, 0)*smp.variance
+		}
+		st.StdDev = math.Sqrt(ss / (total - 1))
+	}
+	return st
+}
+
+var summaryHeaders = []string{"experiment", "metric", "id", "count", "mean", "stddev", "min", "p50", "p90", "p99", "max", "sum"}
+
+// WriteSummaryCSV writes the rows in CSV format. The statistics for all clients/replicas have the id "all".
+func WriteSummaryCSV(wr io.Writer, rows []SummaryRow) error {
+	w := csv.NewWriter(wr)
+	if err := w.Write(summaryHeaders); err != nil {
+		return err
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, row := range rows {
+		id := "all"
+		if row.ID != 0 {
+			id = strconv.FormatUint(uint64(row.ID), 10)
+		}
+		err := w.Write([]string{
+			row.Experiment, row.Metric, id, strconv.FormatUint(row.Count, 10),
+			format(row.Mean), format(row.StdDev), format(row.Min), format(row.P50),
+			format(row.P90), format(row.P99), format(row.Max), format(row.Sum),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteSummaryJSON writes the rows as a JSON array.
+func WriteSummaryJSON(wr io.Writer, rows []SummaryRow) error {
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
@@ -0,0 +1,138 @@
+package plotting
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSummary(t *testing.T) {
+	start := time.Unix(1000, 0)
+	summary := NewSummary()
+	summary.Add(&types.StartEvent{Event: types.NewClientEvent(1, start)})
+	summary.Add(&types.StartEvent{Event: types.NewReplicaEvent(1, start)})
+	summary.Add(&types.StartEvent{Event: types.NewReplicaEvent(2, start)})
+	for i := 1; i <= 10; i++ {
+		now := start.Add(time.Duration(i) * time.Second)
+		summary.Add(&types.LatencyMeasurement{Event: types.NewClientEvent(1, now), Latency: float64(i), Count: uint64(i)})
+		for id := uint32(1); id <= 2; id++ {
+			summary.Add(&types.ThroughputMeasurement{
+				Event:    types.NewReplicaEvent(id, now),
+				Commands: uint64(100 * id),
+				Duration: durationpb.New(time.Second),
+			})
+		}
+	}
+
+	// the first and last two seconds are trimmed, leaving the measurements from 3 to 8 seconds.
+	rows := summary.Compute("test", 3*time.Second, 2*time.Second)
+	want := []SummaryRow{
+		{Experiment: "test", Metric: MetricLatency, ID: 1, Stats: Stats{Count: 33, Min: 3, Max: 8, P50: 6, Sum: 199}},
+		{Experiment: "test", Metric: MetricLatency, ID: 0, Stats: Stats{Count: 33, Min: 3, Max: 8, P50: 6, Sum: 199}},
+		{Experiment: "test", Metric: MetricThroughput, ID: 1, Stats: Stats{Count: 6, Min: 100, Max: 100, P50: 100, Sum: 600}},
+		{Experiment: "test", Metric: MetricThroughput, ID: 2, Stats: Stats{Count: 6, Min: 200, Max: 200, P50: 200, Sum: 1200}},
+		{Experiment: "test", Metric: MetricThroughput, ID: 0, Stats: Stats{Count: 12, Min: 100, Max: 200, P50: 100, Sum: 1800}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i, row := range rows {
+		w := want[i]
+		if row.Metric != w.Metric || row.ID != w.ID || row.Count != w.Count ||
+			row.Min != w.Min || row.Max != w.Max || row.P50 != w.P50 || row.Sum != w.Sum {
+			t.Errorf("row %d: got %+v, want %+v", i, row, w)
+		}
+		if mean := w.Sum / float64(w.Count); math.Abs(row.Mean-mean) > 1e-9 {
+			t.Errorf("row %d: got mean %v, want %v", i, row.Mean, mean)
+		}
+	}
+	if sd := rows[3].StdDev; sd != 0 {
+		t.Errorf("got stddev %v for constant throughput, want 0", sd)
+	}
+}
+
+func TestSummaryPhases(t *testing.T) {
+	start := time.Unix(1000, 0)
+	summary := NewSummary()
+	summary.Add(&types.StartEvent{Event: types.NewReplicaEvent(1, start)})
+	for i := 1; i <= 4; i++ {
+		begin := start.Add(time.Duration(i) * time.Second)
+		summary.Add(&types.ConsensusPhase{
+			Event:     types.NewReplicaEvent(1, begin),
+			View:      uint64(i),
+			Phase:     "qc",
+			Start:     timestamppb.New(begin),
+			End:       timestamppb.New(begin.Add(time.Duration(10*i) * time.Millisecond)),
+			Predicted: durationpb.New(10 * time.Millisecond),
+		})
+	}
+
+	rows := summary.Compute("test", 0, 0)
+	want := []SummaryRow{
+		{Experiment: "test", Metric: MetricQC, ID: 1, Stats: Stats{Count: 4, Min: 10, Max: 40, Sum: 100}},
+		{Experiment: "test", Metric: MetricQC, ID: 0, Stats: Stats{Count: 4, Min: 10, Max: 40, Sum: 100}},
+		{Experiment: "test", Metric: MetricQCPrediction, ID: 1, Stats: Stats{Count: 4, Min: 0, Max: 30, Sum: 60}},
+		{Experiment: "test", Metric: MetricQCPrediction, ID: 0, Stats: Stats{Count: 4, Min: 0, Max: 30, Sum: 60}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i, row := range rows {
+		w := want[i]
+		if row.Metric != w.Metric || row.ID != w.ID || row.Count != w.Count ||
+			math.Abs(row.Min-w.Min) > 1e-9 || math.Abs(row.Max-w.Max) > 1e-9 || math.Abs(row.Sum-w.Sum) > 1e-9 {
+			t.Errorf("row %d: got %+v, want %+v", i, row, w)
+		}
+	}
+}
+
+func TestSummaryTraffic(t *testing.T) {
+	start := time.Unix(1000, 0)
+	summary := NewSummary()
+	summary.Add(&types.StartEvent{Event: types.NewReplicaEvent(1, start)})
+	summary.Add(&types.TrafficMeasurement{
+		Event: types.NewReplicaEvent(1, start.Add(time.Second)),
+		Counts: []*types.TrafficCount{
+			{View: 1, Peer: 2, Method: "hotstuffpb.Hotstuff.Propose", Sent: true, Messages: 1, Bytes: 100},
+			{View: 1, Peer: 3, Method: "hotstuffpb.Hotstuff.Propose", Sent: true, Messages: 1, Bytes: 100},
+			{View: 1, Peer: 2, Method: "hotstuffpb.Hotstuff.Vote", Sent: false, Messages: 1, Bytes: 50},
+		},
+	})
+	// the Propose method is not used in the second interval, which counts as zero
+	summary.Add(&types.TrafficMeasurement{
+		Event: types.NewReplicaEvent(1, start.Add(2*time.Second)),
+		Counts: []*types.TrafficCount{
+			{View: 2, Peer: 2, Method: "kauripb.Kauri.SendContribution", Sent: true, Messages: 2, Bytes: 60},
+		},
+	})
+
+	rows := summary.Compute("test", 0, 0)
+	want := []SummaryRow{
+		{Experiment: "test", Metric: MetricSentMessages, ID: 1, Stats: Stats{Count: 2, Min: 2, Max: 2, Sum: 4}},
+		{Experiment: "test", Metric: MetricSentMessages, ID: 0, Stats: Stats{Count: 2, Min: 2, Max: 2, Sum: 4}},
+		{Experiment: "test", Metric: MetricSentBytes, ID: 1, Stats: Stats{Count: 2, Min: 60, Max: 200, Sum: 260}},
+		{Experiment: "test", Metric: MetricSentBytes, ID: 0, Stats: Stats{Count: 2, Min: 60, Max: 200, Sum: 260}},
+		{Experiment: "test", Metric: MetricSentMessages + "/Hotstuff.Propose", ID: 1, Stats: Stats{Count: 2, Min: 0, Max: 2, Sum: 2}},
+		{Experiment: "test", Metric: MetricSentMessages + "/Hotstuff.Propose", ID: 0, Stats: Stats{Count: 2, Min: 0, Max: 2, Sum: 2}},
+		{Experiment: "test", Metric: MetricSentBytes + "/Hotstuff.Propose", ID: 1, Stats: Stats{Count: 2, Min: 0, Max: 200, Sum: 200}},
+		{Experiment: "test", Metric: MetricSentBytes + "/Hotstuff.Propose", ID: 0, Stats: Stats{Count: 2, Min: 0, Max: 200, Sum: 200}},
+		{Experiment: "test", Metric: MetricSentMessages + "/Kauri.SendContribution", ID: 1, Stats: Stats{Count: 2, Min: 0, Max: 2, Sum: 2}},
+		{Experiment: "test", Metric: MetricSentMessages + "/Kauri.SendContribution", ID: 0, Stats: Stats{Count: 2, Min: 0, Max: 2, Sum: 2}},
+		{Experiment: "test", Metric: MetricSentBytes + "/Kauri.SendContribution", ID: 1, Stats: Stats{Count: 2, Min: 0, Max: 60, Sum: 60}},
+		{Experiment: "test", Metric: MetricSentBytes + "/Kauri.SendContribution", ID: 0, Stats: Stats{Count: 2, Min: 0, Max: 60, Sum: 60}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i, row := range rows {
+		w := want[i]
+		if row.Metric != w.Metric || row.ID != w.ID || row.Count != w.Count ||
+			row.Min != w.Min || row.Max != w.Max || row.Sum != w.Sum {
+			t.Errorf("row %d: got %+v, want %+v", i, row, w)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package plotting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"image/color"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+)
+
+// SuspicionPlot plots a heatmap of the committed suspicion matrix at the end of the experiment.
+type SuspicionPlot struct {
+	replicas map[uint32]struct{}
+	latest   *types.SuspicionMatrix
+}
+
+// NewSuspicionPlot returns a new suspicion matrix plotter.
+func NewSuspicionPlot() SuspicionPlot {
+	return SuspicionPlot{replicas: make(map[uint32]struct{})}
+}
+
+// Add adds a measurement to the plotter.
+func (p *SuspicionPlot) Add(measurement any) {
+	if start, ok := measurement.(*types.StartEvent); ok && !start.GetEvent().GetClient() {
+		p.replicas[start.GetEvent().GetID()] = struct{}{}
+	}
+
+	m, ok := measurement.(*types.SuspicionMatrix)
+	if !ok {
+		return
+	}
+	p.replicas[m.GetEvent().GetID()] = struct{}{}
+	for _, s := range m.GetSuspicions() {
+		p.replicas[s.GetSuspect()] = struct{}{}
+		p.replicas[s.GetSuspector()] = struct{}{}
+	}
+	// the suspicions are committed, so the snapshot of the highest view is the most complete
+	if p.latest == nil || m.GetView() > p.latest.GetView() ||
+		(m.GetView() == p.latest.GetView() && m.GetEvent().GetTimestamp().AsTime().After(p.latest.GetEvent().GetTimestamp().AsTime())) {
+		p.latest = m
+	}
+}
+
+// Plot plots the number of suspicions that each replica (x) raised against each other replica (y).
+// The CSV format contains one row for each pair of replicas with at least one suspicion.
+func (p *SuspicionPlot) Plot(filename string) error {
+	const (
+		xlabel = "Suspector"
+		ylabel = "Suspect"
+	)
+	if p.latest == nil {
+		return fmt.Errorf("no suspicion measurements found")
+	}
+	if path.Ext(filename) == ".csv" {
+		return p.writeCSV(filename, []string{ylabel, xlabel, "Suspicions"})
+	}
+	grid := newSuspicionGrid(p.replicas, p.latest)
+	if len(grid.ids) < 2 {
+		return fmt.Errorf("cannot plot suspicions of fewer than two replicas")
+	}
+	return GonumPlot(filename, xlabel, ylabel, func(plt *plot.Plot) error {
+		plt.Title.Text = fmt.Sprintf("Suspicions in view %d", p.latest.GetView())
+
+		heatmap := plotter.NewHeatMap(grid, whiteToRed(64))
+		if heatmap.Max <= heatmap.Min {
+			// no suspicions
+			heatmap.Max = heatmap.Min + 1
+		}
+		plt.Add(heatmap)
+
+		counts := plotter.XYLabels{}
+		for c := range grid.ids {
+			for r := range grid.ids {
+				counts.XYs = append(counts.XYs, plotter.XY{X: grid.X(c), Y: grid.Y(r)})
+				counts.Labels = append(counts.Labels, strconv.Itoa(int(grid.Z(c, r))))
+			}
+		}
+		labels, err := plotter.NewLabels(counts)
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+		for i := range labels.TextStyle {
+			labels.TextStyle[i].XAlign = -0.5
+			labels.TextStyle[i].YAlign = -0.5
+		}
+		plt.Add(labels)
+
+		ticks := make([]plot.Tick, len(grid.ids))
+		for i, id := range grid.ids {
+			ticks[i] = plot.Tick{Value: float64(id), Label: strconv.Itoa(int(id))}
+		}
+		plt.X.Tick.Marker = plot.ConstantTicks(ticks)
+		plt.Y.Tick.Marker = plot.ConstantTicks(ticks)
+		return nil
+	})
+}
+
+// whiteToRed returns a palette from white to red, so that the counts are readable on every cell.
+func whiteToRed(colors int) palette.Palette {
+	heat := palette.Heat(colors, 1).Colors()
+	reversed := make([]color.Color, len(heat))
+	for i, c := range heat {
+		reversed[len(heat)-1-i] = c
+	}
+	return colorPalette(reversed)
+}
+
+type colorPalette []color.Color
+
+// Colors returns the colors of the palette.
+func (p colorPalette) Colors() []color.Color {
+	return p
+}
+
+func (p *SuspicionPlot) writeCSV(filename string, headers []string) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	wr := csv.NewWriter(f)
+	err = wr.Write(headers)
+	if err != nil {
+		return err
+	}
+	for _, s := range p.latest.GetSuspicions() {
+		err = wr.Write([]string{fmt.Sprint(s.GetSuspect()), fmt.Sprint(s.GetSuspector()), fmt.Sprint(s.GetCount())})
+		if err != nil {
+			return err
+		}
+	}
+	wr.Flush()
+	if err := wr.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// suspicionGrid is a plotter.GridXYZ of the suspicion counts,
+// where the columns are the suspectors and the rows are the suspects.
+type suspicionGrid struct {
+	ids    []uint32
+	counts map[[2]uint32]uint64
+}
+
+func newSuspicionGrid(replicas map[uint32]struct{}, m *types.SuspicionMatrix) suspicionGrid {
+	grid := suspicionGrid{counts: make(map[[2]uint32]uint64)}
+	for id := range replicas {
+		grid.ids = append(grid.ids, id)
+	}
+	sort.Slice(grid.ids, func(i, j int) bool { return grid.ids[i] < grid.ids[j] })
+	for _, s := range m.GetSuspicions() {
+		grid.counts[[2]uint32{s.GetSuspector(), s.GetSuspect()}] = s.GetCount()
+	}
+	return grid
+}
+
+// Dims returns the dimensions of the grid.
+func (g suspicionGrid) Dims() (c, r int) {
+	return len(g.ids), len(g.ids)
+}
+
+// Z returns the number of suspicions that the suspector in column c raised against the suspect in row r.
+func (g suspicionGrid) Z(c, r int) float64 {
+	return float64(g.counts[[2]uint32{g.ids[c], g.ids[r]}])
+}
+
+// X returns the ID of the suspector in column c.
+func (g suspicionGrid) X(c int) float64 {
+	return float64(g.ids[c])
+}
+
+// Y returns the ID of the suspect in row r.
+func (g suspicionGrid) Y(r int) float64 {
+	return float64(g.ids[r])
+}
@@ -0,0 +1,123 @@
+package plotting
+
+import (
+	"fmt"
+	"image/color"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+)
+
+// TimeoutPlot plots the view timeouts of the replicas over time,
+// with markers where the replicas changed their Kauri tree or committed a reconfiguration.
+type TimeoutPlot struct {
+	startTimes       StartTimes
+	measurements     MeasurementMap
+	treeChanges      []Measurement
+	reconfigurations []Measurement
+}
+
+// NewTimeoutPlot returns a new view timeout plotter.
+func NewTimeoutPlot() TimeoutPlot {
+	return TimeoutPlot{
+		startTimes:   NewStartTimes(),
+		measurements: NewMeasurementMap(),
+	}
+}
+
+// Add adds a measurement to the plotter.
+func (p *TimeoutPlot) Add(measurement any) {
+	p.startTimes.Add(measurement)
+
+	switch m := measurement.(type) {
+	case *types.ViewTimeouts:
+		p.measurements.Add(m.GetEvent().GetID(), m)
+	case *types.TreeChange:
+		p.treeChanges = append(p.treeChanges, m)
+	case *types.Reconfiguration:
+		p.reconfigurations = append(p.reconfigurations, m)
+	}
+}
+
+// PlotAverage plots the average number of view timeouts per replica in each time interval.
+// The CSV format contains only the timeouts, and not the markers.
+func (p *TimeoutPlot) PlotAverage(filename string, measurementInterval time.Duration) (err error) {
+	const (
+		xlabel = "Time (seconds)"
+		ylabel = "View timeouts"
+	)
+	if path.Ext(filename) == ".csv" {
+		return CSVPlot(filename, []string{xlabel, ylabel}, func() plotter.XYer {
+			return avgTimeouts(p, measurementInterval)
+		})
+	}
+	return GonumPlot(filename, xlabel, ylabel, func(plt *plot.Plot) error {
+		timeouts := avgTimeouts(p, measurementInterval)
+		plt.Legend.Top = true
+		if err := plotutil.AddLinePoints(plt, "timeouts", timeouts); err != nil {
+			return fmt.Errorf("failed to add line plot: %w", err)
+		}
+		ymax := 1.0
+		for i := 0; i < timeouts.Len(); i++ {
+			_, y := timeouts.XY(i)
+			ymax = max(ymax, y)
+		}
+		markers := []struct {
+			name   string
+			times  []float64
+			color  color.Color
+			dashes int
+		}{
+			{"tree change", p.markers(p.treeChanges, func(m Measurement) uint64 { return m.(*types.TreeChange).GetView() }), plotutil.Color(1), 1},
+			{"reconfiguration", p.markers(p.reconfigurations, func(m Measurement) uint64 { return m.(*types.Reconfiguration).GetView() }), plotutil.Color(2), 2},
+		}
+		for _, marker := range markers {
+			for i, t := range marker.times {
+				line, err := plotter.NewLine(plotter.XYs{{X: t, Y: 0}, {X: t, Y: ymax}})
+				if err != nil {
+					return fmt.Errorf("failed to add %s marker: %w", marker.name, err)
+				}
+				line.Color = marker.color
+				line.Dashes = plotutil.Dashes(marker.dashes)
+				plt.Add(line)
+				if i == 0 {
+					plt.Legend.Add(marker.name, line)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// markers returns the times of the given events, in seconds since the start of the replica that recorded it.
+// Since every replica records the same tree changes and reconfigurations, only the first event of each view is used.
+func (p *TimeoutPlot) markers(events []Measurement, view func(Measurement) uint64) []float64 {
+	first := make(map[uint64]time.Duration)
+	for _, event := range events {
+		t, ok := p.startTimes.Offset(event.GetEvent())
+		if !ok {
+			continue
+		}
+		if prev, ok := first[view(event)]; !ok || t < prev {
+			first[view(event)] = t
+		}
+	}
+	times := make([]float64, 0, len(first))
+	for _, t := range first {
+		times = append(times, t.Seconds())
+	}
+	sort.Float64s(times)
+	return times
+}
+
+func avgTimeouts(p *TimeoutPlot, interval time.Duration) plotter.XYer {
+	intervals := GroupByTimeInterval(&p.startTimes, p.measurements, interval)
+	return TimeAndAverage(intervals, func(m Measurement) (float64, uint64) {
+		return float64(m.(*types.ViewTimeouts).GetTimeouts()), 1
+	})
+}
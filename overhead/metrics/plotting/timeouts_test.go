@@ -0,0 +1,98 @@
+package plotting
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+)
+
+func TestTimeoutPlot(t *testing.T) {
+	start := time.Unix(1000, 0)
+	p := NewTimeoutPlot()
+	for id := uint32(1); id <= 2; id++ {
+		p.Add(&types.StartEvent{Event: types.NewReplicaEvent(id, start)})
+	}
+	for i := 1; i <= 4; i++ {
+		now := start.Add(time.Duration(i) * time.Second)
+		for id := uint32(1); id <= 2; id++ {
+			p.Add(&types.ViewTimeouts{Event: types.NewReplicaEvent(id, now), Timeouts: uint64(i * int(id))})
+		}
+	}
+	// both replicas record the same tree change, but at slightly different times
+	p.Add(&types.TreeChange{Event: types.NewReplicaEvent(2, start.Add(2100*time.Millisecond)), View: 10})
+	p.Add(&types.TreeChange{Event: types.NewReplicaEvent(1, start.Add(2*time.Second)), View: 10})
+	p.Add(&types.TreeChange{Event: types.NewReplicaEvent(1, start.Add(3*time.Second)), View: 20})
+	p.Add(&types.Reconfiguration{Event: types.NewReplicaEvent(1, start.Add(time.Second)), View: 5})
+
+	timeouts := avgTimeouts(&p, time.Second)
+	if timeouts.Len() != 4 {
+		t.Fatalf("got %d points, want 4", timeouts.Len())
+	}
+	for i := 0; i < timeouts.Len(); i++ {
+		// the measurement after i+1 seconds falls in the interval that starts at i+1 seconds
+		if x, y := timeouts.XY(i); x != float64(i+1) || y != 1.5*float64(i+1) {
+			t.Errorf("point %d: got (%v, %v), want (%v, %v)", i, x, y, i+1, 1.5*float64(i+1))
+		}
+	}
+
+	treeChanges := p.markers(p.treeChanges, func(m Measurement) uint64 { return m.(*types.TreeChange).GetView() })
+	if want := []float64{2, 3}; !slices.Equal(treeChanges, want) {
+		t.Errorf("got tree change markers %v, want %v", treeChanges, want)
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"timeouts.png", "timeouts.csv"} {
+		if err := p.PlotAverage(filepath.Join(dir, name), time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSuspicionPlot(t *testing.T) {
+	start := time.Unix(1000, 0)
+	p := NewSuspicionPlot()
+	for id := uint32(1); id <= 4; id++ {
+		p.Add(&types.StartEvent{Event: types.NewReplicaEvent(id, start)})
+	}
+	p.Add(&types.SuspicionMatrix{
+		Event:      types.NewReplicaEvent(1, start.Add(time.Second)),
+		View:       10,
+		Suspicions: []*types.Suspicion{{Suspect: 3, Suspector: 1, Count: 2}, {Suspect: 3, Suspector: 2, Count: 1}},
+	})
+	p.Add(&types.SuspicionMatrix{
+		Event:      types.NewReplicaEvent(2, start.Add(2*time.Second)),
+		View:       8,
+		Suspicions: []*types.Suspicion{{Suspect: 3, Suspector: 1, Count: 1}},
+	})
+
+	grid := newSuspicionGrid(p.replicas, p.latest)
+	if c, r := grid.Dims(); c != 4 || r != 4 {
+		t.Fatalf("got %dx%d grid, want 4x4", c, r)
+	}
+	// the snapshot of view 10 is the latest one, even though it was recorded first
+	if z := grid.Z(0, 2); z != 2 {
+		t.Errorf("got %v suspicions by replica 1 against replica 3, want 2", z)
+	}
+	if z := grid.Z(2, 0); z != 0 {
+		t.Errorf("got %v suspicions by replica 3 against replica 1, want 0", z)
+	}
+
+	dir := t.TempDir()
+	if err := p.Plot(filepath.Join(dir, "suspicions.png")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Plot(filepath.Join(dir, "suspicions.csv")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "suspicions.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Suspect,Suspector,Suspicions\n3,1,2\n3,2,1\n"; string(b) != want {
+		t.Errorf("got CSV:\n%s\nwant:\n%s", b, want)
+	}
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("reconfigurations", func() any {
+		return &Reconfigurations{}
+	})
+}
+
+// Reconfigurations records the reconfigurations that the replica commits.
+type Reconfigurations struct {
+	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+}
+
+// InitModule gives the module access to the other modules.
+func (r *Reconfigurations) InitModule(mods *modules.Core) {
+	var logger logging.Logger
+
+	mods.Get(
+		&r.metricsLogger,
+		&r.opts,
+		&r.eventLoop,
+		&logger,
+	)
+
+	r.eventLoop.RegisterObserver(hotstuff.ReconfigurationMsg{}, func(event any) {
+		r.recordReconfiguration(event.(hotstuff.ReconfigurationMsg))
+	})
+
+	logger.Info("Reconfigurations metric enabled")
+}
+
+func (r *Reconfigurations) recordReconfiguration(event hotstuff.ReconfigurationMsg) {
+	active := make([]uint32, len(event.ActiveReplicas))
+	for i, id := range event.ActiveReplicas {
+		active[i] = uint32(id)
+	}
+	r.metricsLogger.Log(&types.Reconfiguration{
+		Event:          types.NewReplicaEvent(uint32(r.opts.ID()), r.eventLoop.Now()),
+		View:           uint64(event.View),
+		ActiveReplicas: active,
+		QuorumSize:     uint32(event.QuorumSize),
+	})
+}
@@ -1,8 +1,6 @@
 package metrics
 
 import (
-	"time"
-
 	"github.com/relab/hotstuff"
 
 	"github.com/relab/hotstuff/eventloop"
@@ -20,6 +18,7 @@ func init() {
 // Throughput measures throughput in commits per second, and commands per second.
 type ProposalBytes struct {
 	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
 	opts          *modules.Options
 
 	bytesCount uint64
@@ -27,24 +26,21 @@ type ProposalBytes struct {
 
 // InitModule gives the module access to the other modules.
 func (t *ProposalBytes) InitModule(mods *modules.Core) {
-	var (
-		eventLoop *eventloop.EventLoop
-		logger    logging.Logger
-	)
+	var logger logging.Logger
 
 	mods.Get(
 		&t.metricsLogger,
 		&t.opts,
-		&eventLoop,
+		&t.eventLoop,
 		&logger,
 	)
 
-	eventLoop.RegisterHandler(hotstuff.BlockBytesEvent{}, func(event any) {
+	t.eventLoop.RegisterHandler(hotstuff.BlockBytesEvent{}, func(event any) {
 		bytesSent := event.(hotstuff.BlockBytesEvent)
 		t.recordBytes(bytesSent.NumberBytes)
 	})
 
-	eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+	t.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
 		t.tick(event.(types.TickEvent))
 	})
 
@@ -56,7 +52,7 @@ func (t *ProposalBytes) recordBytes(bytesSent int) {
 }
 
 func (t *ProposalBytes) tick(tick types.TickEvent) {
-	now := time.Now()
+	now := t.eventLoop.Now()
 	event := &types.SentBytes{
 		Event:       types.NewReplicaEvent(uint32(t.opts.ID()), now),
 		SendEventrd: t.bytesCount,
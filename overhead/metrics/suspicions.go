@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"sort"
+
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func init() {
+	RegisterReplicaMetric("suspicions", func() any {
+		return &Suspicions{}
+	})
+}
+
+// Suspicions periodically records a snapshot of the suspicions that the replica's ranking module has committed.
+// It records nothing if the replica has no ranking module.
+type Suspicions struct {
+	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+	ranking       modules.Ranking
+
+	view uint64
+}
+
+// InitModule gives the module access to the other modules.
+func (s *Suspicions) InitModule(mods *modules.Core) {
+	var logger logging.Logger
+
+	mods.Get(
+		&s.metricsLogger,
+		&s.opts,
+		&s.eventLoop,
+		&logger,
+	)
+
+	if !mods.TryGet(&s.ranking) {
+		logger.Warn("Suspicions metric enabled without a ranking module")
+		return
+	}
+
+	s.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		s.view = uint64(event.(synchronizer.ViewChangeEvent).View)
+	})
+
+	s.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+		s.tick(event.(types.TickEvent))
+	})
+
+	logger.Info("Suspicions metric enabled")
+}
+
+func (s *Suspicions) tick(_ types.TickEvent) {
+	event := &types.SuspicionMatrix{
+		Event: types.NewReplicaEvent(uint32(s.opts.ID()), s.eventLoop.Now()),
+		View:  s.view,
+	}
+	for suspect, suspectors := range s.ranking.GetSuspicionMatrix() {
+		for suspector, count := range suspectors {
+			if count > 0 {
+				event.Suspicions = append(event.Suspicions, &types.Suspicion{
+					Suspect:   uint32(suspect),
+					Suspector: uint32(suspector),
+					Count:     uint64(count),
+				})
+			}
+		}
+	}
+	// sort the suspicions to make the output deterministic
+	sort.Slice(event.Suspicions, func(i, j int) bool {
+		a, b := event.Suspicions[i], event.Suspicions[j]
+		if a.Suspect != b.Suspect {
+			return a.Suspect < b.Suspect
+		}
+		return a.Suspector < b.Suspector
+	})
+	s.metricsLogger.Log(event)
+}
@@ -1,8 +1,6 @@
 package metrics
 
 import (
-	"time"
-
 	"github.com/relab/hotstuff"
 
 	"github.com/relab/hotstuff/eventloop"
@@ -21,6 +19,7 @@ func init() {
 // Throughput measures throughput in commits per second, and commands per second.
 type Throughput struct {
 	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
 	opts          *modules.Options
 	configuration modules.Configuration
 	commitCount   uint64
@@ -29,25 +28,22 @@ type Throughput struct {
 
 // InitModule gives the module access to the other modules.
 func (t *Throughput) InitModule(mods *modules.Core) {
-	var (
-		eventLoop *eventloop.EventLoop
-		logger    logging.Logger
-	)
+	var logger logging.Logger
 
 	mods.Get(
 		&t.metricsLogger,
 		&t.opts,
 		&t.configuration,
-		&eventLoop,
+		&t.eventLoop,
 		&logger,
 	)
 
-	eventLoop.RegisterHandler(hotstuff.CommitEvent{}, func(event any) {
+	t.eventLoop.RegisterHandler(hotstuff.CommitEvent{}, func(event any) {
 		commitEvent := event.(hotstuff.CommitEvent)
 		t.recordCommit(commitEvent.Commands)
 	})
 
-	eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+	t.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
 		t.tick(event.(types.TickEvent))
 	})
 
@@ -64,7 +60,7 @@ func (t *Throughput) tick(tick types.TickEvent) {
 	if ok && !replica.Active() {
 		return
 	}
-	now := time.Now()
+	now := t.eventLoop.Now()
 	event := &types.ThroughputMeasurement{
 		Event:    types.NewReplicaEvent(uint32(t.opts.ID()), now),
 		Commits:  t.commitCount,
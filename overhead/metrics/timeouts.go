@@ -1,8 +1,6 @@
 package metrics
 
 import (
-	"time"
-
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/metrics/types"
@@ -19,39 +17,39 @@ func init() {
 // ViewTimeouts is a metric that measures the number of view timeouts that happen.
 type ViewTimeouts struct {
 	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
 	opts          *modules.Options
 
 	numViews    uint64
 	numTimeouts uint64
+	view        uint64
 }
 
 // InitModule gives the module access to the other modules.
 func (vt *ViewTimeouts) InitModule(mods *modules.Core) {
-	var (
-		eventLoop *eventloop.EventLoop
-		logger    logging.Logger
-	)
+	var logger logging.Logger
 
 	mods.Get(
 		&vt.metricsLogger,
 		&vt.opts,
-		&eventLoop,
+		&vt.eventLoop,
 		&logger,
 	)
 
 	logger.Info("ViewTimeouts metric enabled.")
 
-	eventLoop.RegisterHandler(synchronizer.ViewChangeEvent{}, func(event any) {
+	vt.eventLoop.RegisterHandler(synchronizer.ViewChangeEvent{}, func(event any) {
 		vt.viewChange(event.(synchronizer.ViewChangeEvent))
 	})
 
-	eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+	vt.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
 		vt.tick(event.(types.TickEvent))
 	})
 }
 
 func (vt *ViewTimeouts) viewChange(event synchronizer.ViewChangeEvent) {
 	vt.numViews++
+	vt.view = uint64(event.View)
 	if event.Timeout {
 		vt.numTimeouts++
 	}
@@ -59,9 +57,10 @@ func (vt *ViewTimeouts) viewChange(event synchronizer.ViewChangeEvent) {
 
 func (vt *ViewTimeouts) tick(_ types.TickEvent) {
 	vt.metricsLogger.Log(&types.ViewTimeouts{
-		Event:    types.NewReplicaEvent(uint32(vt.opts.ID()), time.Now()),
+		Event:    types.NewReplicaEvent(uint32(vt.opts.ID()), vt.eventLoop.Now()),
 		Views:    vt.numViews,
 		Timeouts: vt.numTimeouts,
+		View:     vt.view,
 	})
 	vt.numViews = 0
 	vt.numTimeouts = 0
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func init() {
+	RegisterReplicaMetric("traffic", func() any {
+		return &Traffic{}
+	})
+}
+
+type trafficKey struct {
+	view   hotstuff.View
+	peer   hotstuff.ID
+	method string
+	sent   bool
+}
+
+type trafficCount struct {
+	messages uint64
+	bytes    uint64
+}
+
+// Traffic counts the messages and serialized bytes that the replica sends to and receives from each peer,
+// for each gRPC method and view. This covers all services on the replica server, such as the consensus
+// messages, Kauri contributions and Handel messages.
+type Traffic struct {
+	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+
+	view   atomic.Uint64
+	mut    sync.Mutex
+	counts map[trafficKey]trafficCount
+}
+
+// InitModule gives the module access to the other modules.
+func (t *Traffic) InitModule(mods *modules.Core) {
+	var logger logging.Logger
+
+	mods.Get(
+		&t.metricsLogger,
+		&t.opts,
+		&t.eventLoop,
+		&logger,
+	)
+
+	t.counts = make(map[trafficKey]trafficCount)
+
+	t.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		t.view.Store(uint64(event.(synchronizer.ViewChangeEvent).View))
+	})
+
+	t.eventLoop.RegisterObserver(types.TickEvent{}, func(event any) {
+		t.tick(event.(types.TickEvent))
+	})
+
+	logger.Info("Traffic metric enabled")
+}
+
+// CountMessage counts a message of the given gRPC method with the given serialized size.
+func (t *Traffic) CountMessage(peer hotstuff.ID, method string, size int, sent bool) {
+	key := trafficKey{
+		view:   hotstuff.View(t.view.Load()),
+		peer:   peer,
+		method: method,
+		sent:   sent,
+	}
+	t.mut.Lock()
+	count := t.counts[key]
+	count.messages++
+	count.bytes += uint64(size)
+	t.counts[key] = count
+	t.mut.Unlock()
+}
+
+func (t *Traffic) tick(_ types.TickEvent) {
+	t.mut.Lock()
+	counts := t.counts
+	t.counts = make(map[trafficKey]trafficCount, len(counts))
+	t.mut.Unlock()
+
+	event := &types.TrafficMeasurement{
+		Event:  types.NewReplicaEvent(uint32(t.opts.ID()), t.eventLoop.Now()),
+		Counts: make([]*types.TrafficCount, 0, len(counts)),
+	}
+	for key, count := range counts {
+		event.Counts = append(event.Counts, &types.TrafficCount{
+			View:     uint64(key.view),
+			Peer:     uint32(key.peer),
+			Method:   key.method,
+			Sent:     key.sent,
+			Messages: count.messages,
+			Bytes:    count.bytes,
+		})
+	}
+	// sort the counts to make the output deterministic
+	sort.Slice(event.Counts, func(i, j int) bool {
+		a, b := event.Counts[i], event.Counts[j]
+		if a.View != b.View {
+			return a.View < b.View
+		}
+		if a.Peer != b.Peer {
+			return a.Peer < b.Peer
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Sent && !b.Sent
+	})
+	t.metricsLogger.Log(event)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func init() {
+	RegisterReplicaMetric("tree-changes", func() any {
+		return &TreeChanges{}
+	})
+}
+
+// TreeChanges records the Kauri tree of the replica whenever it changes.
+type TreeChanges struct {
+	metricsLogger Logger
+	eventLoop     *eventloop.EventLoop
+	opts          *modules.Options
+}
+
+// InitModule gives the module access to the other modules.
+func (tc *TreeChanges) InitModule(mods *modules.Core) {
+	var logger logging.Logger
+
+	mods.Get(
+		&tc.metricsLogger,
+		&tc.opts,
+		&tc.eventLoop,
+		&logger,
+	)
+
+	tc.eventLoop.RegisterObserver(hotstuff.TreeChangeEvent{}, func(event any) {
+		tc.recordTree(event.(hotstuff.TreeChangeEvent))
+	})
+
+	logger.Info("TreeChanges metric enabled")
+}
+
+func (tc *TreeChanges) recordTree(event hotstuff.TreeChangeEvent) {
+	tree := make([]uint32, len(event.Tree))
+	for i, id := range event.Tree {
+		tree[i] = uint32(id)
+	}
+	change := &types.TreeChange{
+		Event: types.NewReplicaEvent(uint32(tc.opts.ID()), tc.eventLoop.Now()),
+		View:  uint64(event.View),
+		Tree:  tree,
+	}
+	if event.Predicted > 0 {
+		change.Predicted = durationpb.New(event.Predicted)
+	}
+	tc.metricsLogger.Log(change)
+}
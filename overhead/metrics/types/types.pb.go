@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
+// 	protoc-gen-go v1.28.0
 // 	protoc        v3.12.4
 // source: metrics/types/types.proto
 
@@ -287,6 +287,8 @@ type ViewTimeouts struct {
 	Views uint64 `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
 	// Number of view timeouts.
 	Timeouts uint64 `protobuf:"varint,3,opt,name=Timeouts,proto3" json:"Timeouts,omitempty"`
+	// The current view.
+	View uint64 `protobuf:"varint,4,opt,name=View,proto3" json:"View,omitempty"`
 }
 
 func (x *ViewTimeouts) Reset() {
@@ -342,6 +344,13 @@ func (x *ViewTimeouts) GetTimeouts() uint64 {
 	return 0
 }
 
+func (x *ViewTimeouts) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
 type SentBytes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -397,6 +406,520 @@ func (x *SentBytes) GetSendEventrd() uint64 {
 	return 0
 }
 
+// ConsensusPhase is the duration of a phase of a view, as measured by a replica.
+type ConsensusPhase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	View  uint64 `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	// One of dissemination, aggregation, qc or commit.
+	Phase string               `protobuf:"bytes,3,opt,name=Phase,proto3" json:"Phase,omitempty"`
+	Start *timestamp.Timestamp `protobuf:"bytes,4,opt,name=Start,proto3" json:"Start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=End,proto3" json:"End,omitempty"`
+	// The duration predicted by the tree optimizer, if known.
+	Predicted *duration.Duration `protobuf:"bytes,6,opt,name=Predicted,proto3" json:"Predicted,omitempty"`
+}
+
+func (x *ConsensusPhase) Reset() {
+	*x = ConsensusPhase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusPhase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusPhase) ProtoMessage() {}
+
+func (x *ConsensusPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusPhase.ProtoReflect.Descriptor instead.
+func (*ConsensusPhase) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConsensusPhase) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ConsensusPhase) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *ConsensusPhase) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ConsensusPhase) GetStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ConsensusPhase) GetEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ConsensusPhase) GetPredicted() *duration.Duration {
+	if x != nil {
+		return x.Predicted
+	}
+	return nil
+}
+
+// TrafficMeasurement is the number of messages and serialized bytes that a replica
+// sent to and received from the other replicas since the last measurement.
+type TrafficMeasurement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event  *Event          `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	Counts []*TrafficCount `protobuf:"bytes,2,rep,name=Counts,proto3" json:"Counts,omitempty"`
+}
+
+func (x *TrafficMeasurement) Reset() {
+	*x = TrafficMeasurement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrafficMeasurement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficMeasurement) ProtoMessage() {}
+
+func (x *TrafficMeasurement) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficMeasurement.ProtoReflect.Descriptor instead.
+func (*TrafficMeasurement) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TrafficMeasurement) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TrafficMeasurement) GetCounts() []*TrafficCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// TrafficCount is the traffic of one gRPC method with one peer in one view.
+type TrafficCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Peer uint32 `protobuf:"varint,2,opt,name=Peer,proto3" json:"Peer,omitempty"`
+	// The full gRPC method name, e.g. hotstuffpb.Hotstuff.Propose.
+	Method string `protobuf:"bytes,3,opt,name=Method,proto3" json:"Method,omitempty"`
+	// Sent is true for messages sent to the peer, and false for messages received from it.
+	Sent     bool   `protobuf:"varint,4,opt,name=Sent,proto3" json:"Sent,omitempty"`
+	Messages uint64 `protobuf:"varint,5,opt,name=Messages,proto3" json:"Messages,omitempty"`
+	Bytes    uint64 `protobuf:"varint,6,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+}
+
+func (x *TrafficCount) Reset() {
+	*x = TrafficCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrafficCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficCount) ProtoMessage() {}
+
+func (x *TrafficCount) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficCount.ProtoReflect.Descriptor instead.
+func (*TrafficCount) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TrafficCount) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *TrafficCount) GetPeer() uint32 {
+	if x != nil {
+		return x.Peer
+	}
+	return 0
+}
+
+func (x *TrafficCount) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *TrafficCount) GetSent() bool {
+	if x != nil {
+		return x.Sent
+	}
+	return false
+}
+
+func (x *TrafficCount) GetMessages() uint64 {
+	if x != nil {
+		return x.Messages
+	}
+	return 0
+}
+
+func (x *TrafficCount) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// TreeChange is recorded when a replica changes its Kauri tree.
+type TreeChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The view in which the tree was changed.
+	View uint64 `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	// The replica IDs in tree position order, with the root first.
+	Tree []uint32 `protobuf:"varint,3,rep,packed,name=Tree,proto3" json:"Tree,omitempty"`
+	// The QC latency of the tree predicted by the tree optimizer, if known.
+	Predicted *duration.Duration `protobuf:"bytes,4,opt,name=Predicted,proto3" json:"Predicted,omitempty"`
+}
+
+func (x *TreeChange) Reset() {
+	*x = TreeChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeChange) ProtoMessage() {}
+
+func (x *TreeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeChange.ProtoReflect.Descriptor instead.
+func (*TreeChange) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TreeChange) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TreeChange) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *TreeChange) GetTree() []uint32 {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+func (x *TreeChange) GetPredicted() *duration.Duration {
+	if x != nil {
+		return x.Predicted
+	}
+	return nil
+}
+
+// Reconfiguration is recorded when a replica commits a reconfiguration.
+type Reconfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The view from which the reconfiguration takes effect.
+	View           uint64   `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	ActiveReplicas []uint32 `protobuf:"varint,3,rep,packed,name=ActiveReplicas,proto3" json:"ActiveReplicas,omitempty"`
+	QuorumSize     uint32   `protobuf:"varint,4,opt,name=QuorumSize,proto3" json:"QuorumSize,omitempty"`
+}
+
+func (x *Reconfiguration) Reset() {
+	*x = Reconfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reconfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconfiguration) ProtoMessage() {}
+
+func (x *Reconfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconfiguration.ProtoReflect.Descriptor instead.
+func (*Reconfiguration) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Reconfiguration) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Reconfiguration) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *Reconfiguration) GetActiveReplicas() []uint32 {
+	if x != nil {
+		return x.ActiveReplicas
+	}
+	return nil
+}
+
+func (x *Reconfiguration) GetQuorumSize() uint32 {
+	if x != nil {
+		return x.QuorumSize
+	}
+	return 0
+}
+
+// SuspicionMatrix is a snapshot of the committed suspicions of a replica's ranking module.
+type SuspicionMatrix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The current view.
+	View       uint64       `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	Suspicions []*Suspicion `protobuf:"bytes,3,rep,name=Suspicions,proto3" json:"Suspicions,omitempty"`
+}
+
+func (x *SuspicionMatrix) Reset() {
+	*x = SuspicionMatrix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuspicionMatrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspicionMatrix) ProtoMessage() {}
+
+func (x *SuspicionMatrix) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspicionMatrix.ProtoReflect.Descriptor instead.
+func (*SuspicionMatrix) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SuspicionMatrix) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SuspicionMatrix) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *SuspicionMatrix) GetSuspicions() []*Suspicion {
+	if x != nil {
+		return x.Suspicions
+	}
+	return nil
+}
+
+// Suspicion is the number of committed suspicions that the suspector raised against the suspect.
+type Suspicion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Suspect   uint32 `protobuf:"varint,1,opt,name=Suspect,proto3" json:"Suspect,omitempty"`
+	Suspector uint32 `protobuf:"varint,2,opt,name=Suspector,proto3" json:"Suspector,omitempty"`
+	Count     uint64 `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *Suspicion) Reset() {
+	*x = Suspicion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suspicion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suspicion) ProtoMessage() {}
+
+func (x *Suspicion) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suspicion.ProtoReflect.Descriptor instead.
+func (*Suspicion) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Suspicion) GetSuspect() uint32 {
+	if x != nil {
+		return x.Suspect
+	}
+	return 0
+}
+
+func (x *Suspicion) GetSuspector() uint32 {
+	if x != nil {
+		return x.Suspector
+	}
+	return 0
+}
+
+func (x *Suspicion) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 var File_metrics_types_types_proto protoreflect.FileDescriptor
 
 var file_metrics_types_types_proto_rawDesc = []byte{
@@ -435,21 +958,86 @@ var file_metrics_types_types_proto_rawDesc = []byte{
 	0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x22, 0x64, 0x0a, 0x0c, 0x56, 0x69, 0x65, 0x77, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x78, 0x0a, 0x0c, 0x56, 0x69, 0x65, 0x77, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
 	0x74, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
 	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x69, 0x65, 0x77, 0x73, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08,
 	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x22, 0x51, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x74,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x6e,
-	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
-	0x73, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x72, 0x64, 0x42, 0x29, 0x5a, 0x27, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x22, 0x51, 0x0a, 0x09,
+	0x53, 0x65, 0x6e, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x72, 0x64, 0x22,
+	0xf7, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x45, 0x6e, 0x64,
+	0x12, 0x37, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x22, 0x65, 0x0a, 0x12, 0x54, 0x72, 0x61,
+	0x66, 0x66, 0x69, 0x63, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x22, 0x94, 0x01, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x65, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x0a, 0x54, 0x72, 0x65, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69,
+	0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12,
+	0x0a, 0x04, 0x54, 0x72, 0x65, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x54, 0x72,
+	0x65, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x0f,
+	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x0e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0x7b, 0x0a, 0x0f, 0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x30, 0x0a, 0x0a, 0x53, 0x75,
+	0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x59, 0x0a, 0x09,
+	0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x53, 0x75, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x53, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -464,7 +1052,7 @@ func file_metrics_types_types_proto_rawDescGZIP() []byte {
 	return file_metrics_types_types_proto_rawDescData
 }
 
-var file_metrics_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_metrics_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_metrics_types_types_proto_goTypes = []interface{}{
 	(*StartEvent)(nil),            // 0: types.StartEvent
 	(*Event)(nil),                 // 1: types.Event
@@ -472,22 +1060,40 @@ var file_metrics_types_types_proto_goTypes = []interface{}{
 	(*LatencyMeasurement)(nil),    // 3: types.LatencyMeasurement
 	(*ViewTimeouts)(nil),          // 4: types.ViewTimeouts
 	(*SentBytes)(nil),             // 5: types.SentBytes
-	(*timestamp.Timestamp)(nil),   // 6: google.protobuf.Timestamp
-	(*duration.Duration)(nil),     // 7: google.protobuf.Duration
+	(*ConsensusPhase)(nil),        // 6: types.ConsensusPhase
+	(*TrafficMeasurement)(nil),    // 7: types.TrafficMeasurement
+	(*TrafficCount)(nil),          // 8: types.TrafficCount
+	(*TreeChange)(nil),            // 9: types.TreeChange
+	(*Reconfiguration)(nil),       // 10: types.Reconfiguration
+	(*SuspicionMatrix)(nil),       // 11: types.SuspicionMatrix
+	(*Suspicion)(nil),             // 12: types.Suspicion
+	(*timestamp.Timestamp)(nil),   // 13: google.protobuf.Timestamp
+	(*duration.Duration)(nil),     // 14: google.protobuf.Duration
 }
 var file_metrics_types_types_proto_depIdxs = []int32{
-	1, // 0: types.StartEvent.Event:type_name -> types.Event
-	6, // 1: types.Event.Timestamp:type_name -> google.protobuf.Timestamp
-	1, // 2: types.ThroughputMeasurement.Event:type_name -> types.Event
-	7, // 3: types.ThroughputMeasurement.Duration:type_name -> google.protobuf.Duration
-	1, // 4: types.LatencyMeasurement.Event:type_name -> types.Event
-	1, // 5: types.ViewTimeouts.Event:type_name -> types.Event
-	1, // 6: types.SentBytes.Event:type_name -> types.Event
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	1,  // 0: types.StartEvent.Event:type_name -> types.Event
+	13, // 1: types.Event.Timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 2: types.ThroughputMeasurement.Event:type_name -> types.Event
+	14, // 3: types.ThroughputMeasurement.Duration:type_name -> google.protobuf.Duration
+	1,  // 4: types.LatencyMeasurement.Event:type_name -> types.Event
+	1,  // 5: types.ViewTimeouts.Event:type_name -> types.Event
+	1,  // 6: types.SentBytes.Event:type_name -> types.Event
+	1,  // 7: types.ConsensusPhase.Event:type_name -> types.Event
+	13, // 8: types.ConsensusPhase.Start:type_name -> google.protobuf.Timestamp
+	13, // 9: types.ConsensusPhase.End:type_name -> google.protobuf.Timestamp
+	14, // 10: types.ConsensusPhase.Predicted:type_name -> google.protobuf.Duration
+	1,  // 11: types.TrafficMeasurement.Event:type_name -> types.Event
+	8,  // 12: types.TrafficMeasurement.Counts:type_name -> types.TrafficCount
+	1,  // 13: types.TreeChange.Event:type_name -> types.Event
+	14, // 14: types.TreeChange.Predicted:type_name -> google.protobuf.Duration
+	1,  // 15: types.Reconfiguration.Event:type_name -> types.Event
+	1,  // 16: types.SuspicionMatrix.Event:type_name -> types.Event
+	12, // 17: types.SuspicionMatrix.Suspicions:type_name -> types.Suspicion
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_metrics_types_types_proto_init() }
@@ -568,6 +1174,90 @@ func file_metrics_types_types_proto_init() {
 				return nil
 			}
 		}
+		file_metrics_types_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusPhase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrafficMeasurement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrafficCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reconfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuspicionMatrix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Suspicion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -575,7 +1265,7 @@ func file_metrics_types_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_metrics_types_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
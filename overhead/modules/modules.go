@@ -47,6 +47,71 @@ type ExecutorExt interface {
 	Exec(block *hotstuff.Block)
 }
 
+// StateMachine is the replicated application that executes the payloads of the committed client commands.
+// It must be deterministic, such that the replicas that execute the same commands reach the same state.
+type StateMachine interface {
+	// Apply executes the payload of a command and returns the result that is sent to the client.
+	Apply(data []byte) (result []byte)
+	// Digest returns a digest of the current state.
+	Digest() []byte
+}
+
+// Snapshotter is implemented by modules whose state can be saved and restored, such as the ranking module.
+type Snapshotter interface {
+	// Snapshot returns an encoding of the current state.
+	Snapshot() []byte
+	// Restore replaces the current state with the state encoded by Snapshot.
+	Restore(snapshot []byte) error
+}
+
+// CommittedRanking is implemented by consensus modules that keep the committed ranking state after each recently
+// committed block. The committed ranking state only follows from the complaints of the committed blocks, so it is the
+// same on every replica that has committed the block, and can be used to make decisions that the replicas must agree on.
+type CommittedRanking interface {
+	// SuspicionsAt returns the suspicion matrix and the faulty replicas of the ranking state after the block was
+	// committed. It returns false if the block is not one of the recently committed blocks.
+	// The returned matrix and slice must not be modified.
+	SuspicionsAt(block hotstuff.Hash) (suspicions map[hotstuff.ID]map[hotstuff.ID]int, faulty []hotstuff.ID, ok bool)
+}
+
+// ExecutionState is implemented by executors whose state can be included in checkpoints and transferred to
+// replicas that have fallen behind. It is separate from Snapshotter, which the ranking module also implements.
+type ExecutionState interface {
+	// ExecutionSnapshot returns an encoding of the state of the executed commands.
+	// Executors that executed the same commands must return the same encoding.
+	ExecutionSnapshot() []byte
+	// RestoreExecution replaces the state of the executor with the state encoded by ExecutionSnapshot.
+	RestoreExecution(snapshot []byte) error
+}
+
+// StableStorage stores the state that a replica needs to restart without equivocating.
+// It is implemented by persistent BlockChain modules.
+type StableStorage interface {
+	// StoreLastVote records that the replica voted, proposed or timed out in the view.
+	// The record is durable when StoreLastVote returns without error, so it must be called before the vote or proposal
+	// is sent, and the vote or proposal must not be sent if it fails.
+	StoreLastVote(view hotstuff.View) error
+	// LastVote returns the highest view recorded by StoreLastVote.
+	LastVote() hotstuff.View
+	// StoreHighQC records the highest quorum certificate known to the replica, along with the block that it certifies.
+	StoreHighQC(qc hotstuff.QuorumCert)
+	// HighQC returns the quorum certificate recorded by StoreHighQC, if any.
+	HighQC() (hotstuff.QuorumCert, bool)
+	// StoreCommit records that the block was committed, and the snapshot of the ranking state after the commit.
+	// The snapshot is nil if the replica has no ranking module.
+	StoreCommit(block *hotstuff.Block, rankingState []byte)
+	// Committed returns the committed blocks in the order that they were committed, and the last ranking snapshot.
+	Committed() (blocks []*hotstuff.Block, rankingState []byte)
+	// Close closes the storage.
+	Close() error
+}
+
+// Recoverer is implemented by modules that restore their state from the stable storage when a replica starts.
+type Recoverer interface {
+	// Recover restores the state that was stored by a previous run of the replica.
+	Recover(storage StableStorage)
+}
+
 //go:generate mockgen -destination=../internal/mocks/forkhandler_mock.go -package=mocks . ForkHandler
 
 // ForkHandler handles commands that do not get committed due to a forked blockchain.
@@ -86,6 +151,13 @@ type BlockChain interface {
 	PruneToHeight(height hotstuff.View) (forkedBlocks []*hotstuff.Block)
 }
 
+// BlockDiscarder is implemented by BlockChain modules that can discard the blocks below a stable checkpoint.
+type BlockDiscarder interface {
+	// DiscardBefore removes the stored blocks whose view is lower than the view of the given committed block,
+	// and returns the number of removed blocks. The given block is kept as the base of the chain.
+	DiscardBefore(block *hotstuff.Block) int
+}
+
 //go:generate mockgen -destination=../internal/mocks/replica_mock.go -package=mocks . Replica
 
 // Replica represents a remote replica participating in the consensus protocol.
@@ -138,9 +210,30 @@ type Configuration interface {
 	GetLatency(sender hotstuff.ID, receiver hotstuff.ID) time.Duration
 }
 
+// RangeFetcher is implemented by configurations that can fetch a batch of blocks in one request.
+// The BlockChain uses it to catch up with the other replicas, instead of fetching the missing blocks one by one.
+type RangeFetcher interface {
+	// FetchRange requests the block with the given hash and up to count-1 of its ancestors,
+	// ordered from child to parent, stopping before the block with the stop hash.
+	FetchRange(ctx context.Context, hash, stop hotstuff.Hash, count int) (blocks []*hotstuff.Block, ok bool)
+}
+
+// CheckpointConfiguration is implemented by configurations that can exchange checkpoints with the other replicas.
+type CheckpointConfiguration interface {
+	// Checkpoint sends the signed checkpoint to all replicas.
+	Checkpoint(msg hotstuff.CheckpointMsg)
+	// FetchCheckpoint requests a stable checkpoint whose height is at least the given height from all the replicas,
+	// along with the state that is needed to install it. The certificate of the checkpoint is not verified.
+	FetchCheckpoint(ctx context.Context, height uint64) (snapshot hotstuff.CheckpointSnapshot, ok bool)
+}
+
 // Kauri module implements the Kauri protocol
 type Kauri interface {
 	Begin(s hotstuff.PartialCert, p hotstuff.ProposeMsg)
+	// ProposalSender returns the replica that must send the proposal of the block to this replica, which is its parent
+	// in the tree of the block's view, or the leader itself if this replica is the root. It returns false if the tree
+	// is not known, or if its root is not the proposer of the block.
+	ProposalSender(block *hotstuff.Block) (hotstuff.ID, bool)
 }
 
 type Ranking interface {
@@ -170,6 +263,11 @@ type Ranking interface {
 	//GetSuspectedNodes() map[hotstuff.ID]int
 	// GetFaultyNodes returns the faulty nodes
 	GetFaultyNodes() []hotstuff.ID
+	// CommitBlock applies the complaints of a committed block to the committed ranking state.
+	// It is called by the consensus module for every committed block, in commit order.
+	CommitBlock(block *hotstuff.Block)
+	// CommittedSuspicions returns the suspicion matrix and the faulty replicas of the committed ranking state.
+	CommittedSuspicions() (suspicions map[hotstuff.ID]map[hotstuff.ID]int, faulty []hotstuff.ID)
 }
 
 //go:generate mockgen -destination=../internal/mocks/consensus_mock.go -package=mocks . Consensus
@@ -190,6 +288,24 @@ type Consensus interface {
 	Commit(block *hotstuff.Block)
 }
 
+// Checkpointer takes periodic checkpoints of the committed state, and certifies them with the other replicas.
+// It is implemented by the synchronizer.
+type Checkpointer interface {
+	// Committed is called by the consensus module after it has executed the block at the given height of the
+	// committed chain. activeReplicas are the active replicas as of the last committed reconfiguration, if any.
+	// Committed is called while the consensus module commits, and must therefore not call the consensus module.
+	Committed(block *hotstuff.Block, height uint64, activeReplicas []hotstuff.ID)
+	// StableCheckpoint returns the latest stable checkpoint whose state this replica has, if any.
+	StableCheckpoint() (snapshot hotstuff.CheckpointSnapshot, ok bool)
+}
+
+// CheckpointInstaller is implemented by consensus modules that can skip ahead to a stable checkpoint,
+// instead of committing the blocks that lead up to it.
+type CheckpointInstaller interface {
+	// InstallCheckpoint makes the block of the checkpoint the last committed block, at the height of the checkpoint.
+	InstallCheckpoint(block *hotstuff.Block, checkpoint hotstuff.Checkpoint)
+}
+
 // LeaderRotation implements a leader rotation scheme.
 type LeaderRotation interface {
 	// GetLeader returns the id of the leader in the given view.
@@ -221,6 +337,14 @@ type Handel interface {
 	Begin(s hotstuff.PartialCert)
 }
 
+// TrafficCounter counts the messages that a replica sends to and receives from the other replicas.
+// CountMessage is called concurrently by the network goroutines, and must therefore be thread safe.
+type TrafficCounter interface {
+	// CountMessage counts a message of the given gRPC method with the given serialized size.
+	// sent is true if the message was sent to the peer, and false if it was received from the peer.
+	CountMessage(peer hotstuff.ID, method string, size int, sent bool)
+}
+
 // ExtendedExecutor turns the given Executor into an ExecutorExt.
 func ExtendedExecutor(executor Executor) ExecutorExt {
 	return executorWrapper{executor}
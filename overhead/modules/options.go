@@ -1,8 +1,10 @@
 package modules
 
 import (
+	"crypto/ecdsa"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/relab/hotstuff"
 )
@@ -41,8 +43,14 @@ type Options struct {
 	shouldUseHandel       bool
 	shouldVerifyVotesSync bool
 	shouldUseKuari        bool
+	shouldReconfigure     bool
 	sharedRandomSeed      int64
+	treePositions         []hotstuff.ID
+	treeDelta             time.Duration
+	leafPlacement         string
+	checkpointInterval    uint64
 	connectionMetadata    map[string]string
+	operatorKey           *ecdsa.PublicKey
 }
 
 func (opts *Options) ensureSpace(id OptionID) {
@@ -107,11 +115,44 @@ func (opts *Options) ShouldUseKauri() bool {
 	return opts.shouldUseKuari
 }
 
+// ShouldReconfigureOnSuspicion returns true if the leader should propose reconfigurations
+// that remove the replicas suspected by the ranking module.
+func (opts *Options) ShouldReconfigureOnSuspicion() bool {
+	return opts.shouldReconfigure
+}
+
+// TreePositions returns the IDs of the replicas in tree position order, or nil if the tree is not fixed.
+func (opts *Options) TreePositions() []hotstuff.ID {
+	return opts.treePositions
+}
+
+// TreeDelta returns the time to wait for the votes of each level of the tree.
+func (opts *Options) TreeDelta() time.Duration {
+	return opts.treeDelta
+}
+
+// LeafPlacement returns the name of the algorithm that places the leaves of the Kauri tree,
+// or the empty string if the tree is chosen by the tree optimizer.
+func (opts *Options) LeafPlacement() string {
+	return opts.leafPlacement
+}
+
+// CheckpointInterval returns the number of committed blocks between checkpoints, or 0 if checkpoints are disabled.
+func (opts *Options) CheckpointInterval() uint64 {
+	return opts.checkpointInterval
+}
+
 // ConnectionMetadata returns the metadata map that is sent when connecting to other replicas.
 func (opts *Options) ConnectionMetadata() map[string]string {
 	return opts.connectionMetadata
 }
 
+// OperatorKey returns the public key that verifies the operator's reconfiguration certificates,
+// or nil if the replica does not accept reconfigurations from the operator.
+func (opts *Options) OperatorKey() *ecdsa.PublicKey {
+	return opts.operatorKey
+}
+
 // SetShouldUseAggQC sets the ShouldUseAggQC setting to true.
 func (opts *Options) SetShouldUseAggQC() {
 	opts.shouldUseAggQC = true
@@ -136,6 +177,36 @@ func (opts *Options) SetShouldUseKauri() {
 	opts.shouldUseKuari = true
 }
 
+// SetShouldReconfigureOnSuspicion sets the ShouldReconfigureOnSuspicion setting to true.
+func (opts *Options) SetShouldReconfigureOnSuspicion() {
+	opts.shouldReconfigure = true
+}
+
+// SetTreePositions sets the IDs of the replicas in tree position order.
+func (opts *Options) SetTreePositions(ids []hotstuff.ID) {
+	opts.treePositions = ids
+}
+
+// SetTreeDelta sets the time to wait for the votes of each level of the tree.
+func (opts *Options) SetTreeDelta(delta time.Duration) {
+	opts.treeDelta = delta
+}
+
+// SetLeafPlacement sets the name of the algorithm that places the leaves of the Kauri tree.
+func (opts *Options) SetLeafPlacement(name string) {
+	opts.leafPlacement = name
+}
+
+// SetCheckpointInterval sets the number of committed blocks between checkpoints.
+func (opts *Options) SetCheckpointInterval(interval uint64) {
+	opts.checkpointInterval = interval
+}
+
+// SetOperatorKey sets the public key that verifies the operator's reconfiguration certificates.
+func (opts *Options) SetOperatorKey(key *ecdsa.PublicKey) {
+	opts.operatorKey = key
+}
+
 // SetConnectionMetadata sets the value of a key in the connection metadata map.
 //
 // NOTE: if the value contains binary data, the key must have the "-bin" suffix.
@@ -2,6 +2,9 @@ package ranking
 
 import (
 	"container/list"
+	"encoding/json"
+	"maps"
+	"slices"
 	"sort"
 
 	"github.com/relab/hotstuff"
@@ -27,6 +30,7 @@ func New() modules.Ranking {
 		faultyNodes:           make([]hotstuff.ID, 0),
 		leaderScore:           make(map[hotstuff.ID]float64),
 		latencyMatrix:         make(map[hotstuff.ID]map[hotstuff.ID]uint32),
+		committed:             newComplaintCacheState(),
 	}
 }
 
@@ -34,7 +38,7 @@ type ComplaintCache struct {
 	consensus             modules.Consensus
 	crypto                modules.Crypto
 	eventLoop             *eventloop.EventLoop
-	configuration         *backend.Config
+	configuration         modules.Configuration
 	opts                  *modules.Options
 	logger                logging.Logger
 	score                 map[hotstuff.ID]int
@@ -44,9 +48,12 @@ type ComplaintCache struct {
 	leaderScore           map[hotstuff.ID]float64
 	latencyMatrix         map[hotstuff.ID]map[hotstuff.ID]uint32
 	faultyNodes           []hotstuff.ID
-	complaintCache        *list.List
-	id                    hotstuff.ID
-	configLength          int
+	// committed is the state that follows from the complaints of the committed blocks, applied in commit order,
+	// whereas the state above also includes the complaints of the accepted blocks that are not committed yet.
+	committed      complaintCacheState
+	complaintCache *list.List
+	id             hotstuff.ID
+	configLength   int
 }
 
 // TODO initialize the score matrix
@@ -66,17 +73,142 @@ func (cc *ComplaintCache) InitModule(mods *modules.Core) {
 
 }
 
+// activeConfiguration is implemented by configurations that keep track of which replicas are active.
+type activeConfiguration interface {
+	ActiveReplicas() map[hotstuff.ID]modules.Replica
+}
+
 func (cc *ComplaintCache) postInit() {
-	replicas := cc.configuration.ActiveReplicas()
+	replicas := cc.configuration.Replicas()
+	if active, ok := cc.configuration.(activeConfiguration); ok {
+		replicas = active.ActiveReplicas()
+	}
 	cc.configLength = len(replicas)
+	// the state of a restarted replica has already been restored
 	for id := range replicas {
-		cc.score[id] = 100
-		cc.suspicionMatrix[id] = make(map[hotstuff.ID]int)
-		cc.alreadyVoted[id] = make(map[hotstuff.ID]uint64)
-		cc.serialNumForComplaint[id] = 0
+		if _, ok := cc.score[id]; !ok {
+			cc.score[id] = 100
+		}
+		if _, ok := cc.suspicionMatrix[id]; !ok {
+			cc.suspicionMatrix[id] = make(map[hotstuff.ID]int)
+		}
+		if _, ok := cc.alreadyVoted[id]; !ok {
+			cc.alreadyVoted[id] = make(map[hotstuff.ID]uint64)
+		}
+		if _, ok := cc.serialNumForComplaint[id]; !ok {
+			cc.serialNumForComplaint[id] = 0
+		}
+		if _, ok := cc.committed.Score[id]; !ok {
+			cc.committed.Score[id] = 100
+		}
 	}
 }
 
+// complaintCacheState is the state that the replicas derive from the complaints of the blocks.
+// The committed state is saved by Snapshot.
+type complaintCacheState struct {
+	Score           map[hotstuff.ID]int
+	SuspicionMatrix map[hotstuff.ID]map[hotstuff.ID]int
+	AlreadyVoted    map[hotstuff.ID]map[hotstuff.ID]uint64
+	FaultyNodes     []hotstuff.ID
+}
+
+func newComplaintCacheState() complaintCacheState {
+	return complaintCacheState{
+		Score:           make(map[hotstuff.ID]int),
+		SuspicionMatrix: make(map[hotstuff.ID]map[hotstuff.ID]int),
+		AlreadyVoted:    make(map[hotstuff.ID]map[hotstuff.ID]uint64),
+		FaultyNodes:     make([]hotstuff.ID, 0),
+	}
+}
+
+// apply counts the complaints that have not been counted before.
+func (s *complaintCacheState) apply(complaints []*hotstuff.Complaint) {
+	for _, complaint := range complaints {
+		if value, ok := s.AlreadyVoted[complaint.Complainee]; ok {
+			voted, isPreset := value[complaint.Complainant]
+			if isPreset {
+				if voted >= complaint.ID {
+					continue
+				}
+			}
+		} else {
+			s.AlreadyVoted[complaint.Complainee] = make(map[hotstuff.ID]uint64)
+		}
+		s.AlreadyVoted[complaint.Complainee][complaint.Complainant] = complaint.ID
+		if complaint.ComplaintType == hotstuff.Suspicion {
+			if _, ok := s.SuspicionMatrix[complaint.Complainee]; !ok {
+				s.SuspicionMatrix[complaint.Complainee] = make(map[hotstuff.ID]int)
+			}
+			s.SuspicionMatrix[complaint.Complainee][complaint.Complainant] += 1
+		} else {
+			penalty := hotstuff.Penalities[complaint.ComplaintType]
+			s.Score[complaint.Complainant] -= penalty
+			s.FaultyNodes = append(s.FaultyNodes, complaint.Complainant)
+		}
+	}
+}
+
+// clone returns a deep copy of the state.
+func (s complaintCacheState) clone() complaintCacheState {
+	c := complaintCacheState{
+		Score:           maps.Clone(s.Score),
+		SuspicionMatrix: make(map[hotstuff.ID]map[hotstuff.ID]int, len(s.SuspicionMatrix)),
+		AlreadyVoted:    make(map[hotstuff.ID]map[hotstuff.ID]uint64, len(s.AlreadyVoted)),
+		FaultyNodes:     slices.Clone(s.FaultyNodes),
+	}
+	for id, row := range s.SuspicionMatrix {
+		c.SuspicionMatrix[id] = maps.Clone(row)
+	}
+	for id, row := range s.AlreadyVoted {
+		c.AlreadyVoted[id] = maps.Clone(row)
+	}
+	return c
+}
+
+// Snapshot returns the state that follows from the complaints of the committed blocks, encoded as JSON,
+// whose maps are sorted by key, such that replicas that committed the same blocks have the same snapshot.
+// The pending complaints, the latencies and the serial numbers of this replica's complaints are not included.
+func (cc *ComplaintCache) Snapshot() []byte {
+	// the state only contains maps and slices of numbers, so marshaling cannot fail.
+	b, _ := json.Marshal(cc.committed)
+	return b
+}
+
+// Restore replaces the state of the complaint cache with the committed state saved by Snapshot.
+// The latencies are kept, and the serial numbers of the complaints are advanced past those of the counted
+// complaints, such that the new complaints of this replica are not ignored as duplicates.
+func (cc *ComplaintCache) Restore(snapshot []byte) error {
+	state := newComplaintCacheState()
+	if err := json.Unmarshal(snapshot, &state); err != nil {
+		return err
+	}
+	cc.committed = state
+	live := state.clone()
+	cc.score = live.Score
+	cc.suspicionMatrix = live.SuspicionMatrix
+	cc.alreadyVoted = live.AlreadyVoted
+	cc.faultyNodes = live.FaultyNodes
+	for _, voted := range cc.alreadyVoted {
+		for complainant, serialNum := range voted {
+			if serialNum > cc.serialNumForComplaint[complainant] {
+				cc.serialNumForComplaint[complainant] = serialNum
+			}
+		}
+	}
+	return nil
+}
+
+// CommitBlock applies the complaints of the committed block to the committed state.
+func (cc *ComplaintCache) CommitBlock(block *hotstuff.Block) {
+	cc.committed.apply(block.Complaints())
+}
+
+// CommittedSuspicions returns the suspicion matrix and the faulty replicas of the committed state.
+func (cc *ComplaintCache) CommittedSuspicions() (suspicions map[hotstuff.ID]map[hotstuff.ID]int, faulty []hotstuff.ID) {
+	return cc.committed.SuspicionMatrix, cc.committed.FaultyNodes
+}
+
 func (cc *ComplaintCache) GetSuspicionMatrix() map[hotstuff.ID]map[hotstuff.ID]int {
 	return cc.suspicionMatrix
 }
@@ -137,29 +269,14 @@ func (cc *ComplaintCache) CommitComplaints(complaints []*hotstuff.Complaint) {
 			}
 		}
 	}
-	for _, complaint := range complaints {
-		if value, ok := cc.alreadyVoted[complaint.Complainee]; ok {
-			voted, isPreset := value[complaint.Complainant]
-			if isPreset {
-				if voted >= complaint.ID {
-					continue
-				}
-			}
-		} else {
-			cc.alreadyVoted[complaint.Complainee] = make(map[hotstuff.ID]uint64)
-		}
-		cc.alreadyVoted[complaint.Complainee][complaint.Complainant] = complaint.ID
-		if complaint.ComplaintType == hotstuff.Suspicion {
-			if _, ok := cc.suspicionMatrix[complaint.Complainee]; !ok {
-				cc.suspicionMatrix[complaint.Complainee] = make(map[hotstuff.ID]int)
-			}
-			cc.suspicionMatrix[complaint.Complainee][complaint.Complainant] += 1
-		} else {
-			penalty := hotstuff.Penalities[complaint.ComplaintType]
-			cc.score[complaint.Complainant] -= penalty
-			cc.faultyNodes = append(cc.faultyNodes, complaint.Complainant)
-		}
+	state := complaintCacheState{
+		Score:           cc.score,
+		SuspicionMatrix: cc.suspicionMatrix,
+		AlreadyVoted:    cc.alreadyVoted,
+		FaultyNodes:     cc.faultyNodes,
 	}
+	state.apply(complaints)
+	cc.faultyNodes = state.FaultyNodes
 
 	//cc.logger.Info("Score after commit is", cc.suspicionMatrix)
 }
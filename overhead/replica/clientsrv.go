@@ -2,6 +2,8 @@ package replica
 
 import (
 	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
 	"errors"
 	"hash"
 	"net"
@@ -17,7 +19,6 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // clientSrv serves a client.
@@ -27,17 +28,24 @@ type clientSrv struct {
 	configuration modules.Configuration
 	mut           sync.Mutex
 	srv           *gorums.Server
-	awaitingCmds  map[cmdID]chan<- error
+	awaitingCmds  map[cmdID]chan<- cmdResult
 	cmdCache      *cmdCache
 	hash          hash.Hash
 	opts          *modules.Options
 	cmdCount      uint32
+	stateMachine  modules.StateMachine // executes the payloads of the commands, if set
+}
+
+// cmdResult is the outcome of a command that is returned to the client.
+type cmdResult struct {
+	data []byte
+	err  error
 }
 
 // newClientServer returns a new client server.
 func newClientServer(conf Config, srvOpts []gorums.ServerOption) (srv *clientSrv) {
 	srv = &clientSrv{
-		awaitingCmds: make(map[cmdID]chan<- error),
+		awaitingCmds: make(map[cmdID]chan<- cmdResult),
 		srv:          gorums.NewServer(srvOpts...),
 		cmdCache:     newCmdCache(int(conf.BatchSize)),
 		hash:         sha256.New(),
@@ -54,6 +62,7 @@ func (srv *clientSrv) InitModule(mods *modules.Core) {
 		&srv.configuration,
 		&srv.opts,
 	)
+	mods.TryGet(&srv.stateMachine)
 	srv.cmdCache.InitModule(mods)
 }
 
@@ -79,22 +88,22 @@ func (srv *clientSrv) Stop() {
 	srv.srv.Stop()
 }
 
-func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*emptypb.Empty, error) {
+func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.Result, error) {
 	replica, _ := srv.configuration.Replica(srv.opts.ID())
 	if !replica.Active() {
-		return &emptypb.Empty{}, errors.New("Not part of configuration")
+		return &clientpb.Result{}, errors.New("Not part of configuration")
 	}
 	id := cmdID{cmd.ClientID, cmd.SequenceNumber}
 
-	c := make(chan error)
+	c := make(chan cmdResult)
 	srv.mut.Lock()
 	srv.awaitingCmds[id] = c
 	srv.mut.Unlock()
 
 	srv.cmdCache.addCommand(cmd)
 	ctx.Release()
-	err := <-c
-	return &emptypb.Empty{}, err
+	result := <-c
+	return &clientpb.Result{Data: result.data}, result.err
 }
 
 func (srv *clientSrv) Exec(cmd hotstuff.Command) {
@@ -110,10 +119,14 @@ func (srv *clientSrv) Exec(cmd hotstuff.Command) {
 	for _, cmd := range batch.GetCommands() {
 		_, _ = srv.hash.Write(cmd.Data)
 		srv.cmdCount++
+		var result []byte
+		if srv.stateMachine != nil {
+			result = srv.stateMachine.Apply(cmd.Data)
+		}
 		srv.mut.Lock()
 		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
 		if done, ok := srv.awaitingCmds[id]; ok {
-			done <- nil
+			done <- cmdResult{data: result}
 			delete(srv.awaitingCmds, id)
 		}
 		srv.mut.Unlock()
@@ -134,9 +147,61 @@ func (srv *clientSrv) Fork(cmd hotstuff.Command) {
 		srv.mut.Lock()
 		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
 		if done, ok := srv.awaitingCmds[id]; ok {
-			done <- status.Error(codes.Aborted, "blockchain was forked")
+			done <- cmdResult{err: status.Error(codes.Aborted, "blockchain was forked")}
 			delete(srv.awaitingCmds, id)
 		}
 		srv.mut.Unlock()
 	}
 }
+
+// executionState exposes the state of the commands executed by the client server to the checkpoints.
+// It is a separate module from the executor wrapper, such that the client server is not initialized twice.
+type executionState struct {
+	srv *clientSrv
+}
+
+// ExecutionSnapshot returns the number of executed commands, the state of the hash of the executed commands,
+// and the snapshot of the state machine, if it can be snapshotted.
+func (es executionState) ExecutionSnapshot() []byte {
+	// the hash is a SHA-256 digest, whose state can always be marshaled
+	hashState, _ := es.srv.hash.(encoding.BinaryMarshaler).MarshalBinary()
+	b := binary.LittleEndian.AppendUint32(nil, es.srv.cmdCount)
+	b = binary.AppendUvarint(b, uint64(len(hashState)))
+	b = append(b, hashState...)
+	if snapshotter, ok := es.srv.stateMachine.(modules.Snapshotter); ok {
+		b = append(b, snapshotter.Snapshot()...)
+	}
+	return b
+}
+
+// RestoreExecution restores the state encoded by ExecutionSnapshot.
+func (es executionState) RestoreExecution(snapshot []byte) error {
+	errInvalid := errors.New("invalid execution snapshot")
+	if len(snapshot) < 4 {
+		return errInvalid
+	}
+	cmdCount := binary.LittleEndian.Uint32(snapshot)
+	n, size := binary.Uvarint(snapshot[4:])
+	if size <= 0 || n > uint64(len(snapshot)-4-size) {
+		return errInvalid
+	}
+	hashState := snapshot[4+size : 4+size+int(n)]
+	machineState := snapshot[4+size+int(n):]
+
+	h := sha256.New()
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(hashState); err != nil {
+		return err
+	}
+	if snapshotter, ok := es.srv.stateMachine.(modules.Snapshotter); ok {
+		if err := snapshotter.Restore(machineState); err != nil {
+			return err
+		}
+	} else if len(machineState) > 0 {
+		return errors.New("the execution snapshot contains a state machine snapshot, but there is no state machine")
+	}
+	es.srv.hash = h
+	es.srv.cmdCount = cmdCount
+	return nil
+}
+
+var _ modules.ExecutionState = executionState{}
@@ -111,6 +111,7 @@ func New(conf Config, builder modules.Builder) (replica *Replica) {
 
 		modules.ExtendedExecutor(srv.clientSrv),
 		modules.ExtendedForkHandler(srv.clientSrv),
+		executionState{srv.clientSrv},
 		srv.clientSrv.cmdCache,
 	)
 	srv.hs = builder.Build()
@@ -159,6 +160,18 @@ func (srv *Replica) Run(ctx context.Context) {
 	)
 	srv.hs.Get(&synchronizer, &eventLoop)
 
+	// restore the state of a restarted replica before it takes part in the protocol
+	var storage modules.StableStorage
+	if srv.hs.TryGet(&storage) {
+		var consensus modules.Consensus
+		srv.hs.Get(&consensus)
+		for _, module := range []any{consensus, synchronizer} {
+			if recoverer, ok := module.(modules.Recoverer); ok {
+				recoverer.Recover(storage)
+			}
+		}
+	}
+
 	synchronizer.Start(ctx)
 	eventLoop.Run(ctx)
 }
@@ -168,6 +181,23 @@ func (srv *Replica) Close() {
 	srv.clientSrv.Stop()
 	srv.cfg.Close()
 	srv.hsSrv.Stop()
+	var storage modules.StableStorage
+	if srv.hs.TryGet(&storage) {
+		if err := storage.Close(); err != nil {
+			srv.clientSrv.logger.Warnf("Failed to close storage: %v", err)
+		}
+	}
+}
+
+// StableCheckpoint returns the certificate of the latest stable checkpoint that the replica has,
+// or false if checkpoints are disabled or no checkpoint has become stable yet.
+func (srv *Replica) StableCheckpoint() (hotstuff.CheckpointCert, bool) {
+	var checkpointer modules.Checkpointer
+	if !srv.hs.TryGet(&checkpointer) {
+		return hotstuff.CheckpointCert{}, false
+	}
+	snapshot, ok := checkpointer.StableCheckpoint()
+	return snapshot.Cert, ok
 }
 
 // GetHash returns the hash of all executed commands.
@@ -179,3 +209,11 @@ func (srv *Replica) GetHash() (b []byte) {
 func (srv *Replica) GetCmdCount() (c uint32) {
 	return srv.clientSrv.cmdCount
 }
+
+// GetStateDigest returns the digest of the state machine, or nil if the replica has no state machine.
+func (srv *Replica) GetStateDigest() []byte {
+	if srv.clientSrv.stateMachine == nil {
+		return nil
+	}
+	return srv.clientSrv.stateMachine.Digest()
+}
@@ -0,0 +1,291 @@
+package synchronizer
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+// Checkpoints
+//
+// Every CheckpointInterval committed blocks, each replica takes a checkpoint of its committed state: the last committed
+// block, the digest of the state of the executed commands, the digest of the committed ranking state, and the active
+// replicas. The replica signs the checkpoint and sends its signature to the other replicas. When a replica has collected
+// the signatures of a quorum of replicas on the same checkpoint, it combines them into a checkpoint certificate, which
+// makes the checkpoint stable: at least one correct replica has committed it, so it is never rolled back.
+//
+// A stable checkpoint is the base of the chain: the blocks below it are discarded. A replica that learns of a stable
+// checkpoint that it has not committed yet catches up with it by fetching the blocks that it is missing, or, if the
+// other replicas have discarded those blocks, by fetching and installing the state of the checkpoint.
+
+// Committed takes a checkpoint if the height of the committed block is a multiple of the checkpoint interval,
+// and sends this replica's signature of the checkpoint to the other replicas.
+// It is called by the consensus module after it has executed the block.
+func (s *Synchronizer) Committed(block *hotstuff.Block, height uint64, activeReplicas []hotstuff.ID) {
+	interval := s.opts.CheckpointInterval()
+	if interval == 0 || height%interval != 0 {
+		return
+	}
+
+	executionState, rankingState := s.stateSnapshots()
+	snapshot := hotstuff.CheckpointSnapshot{
+		Cert: hotstuff.CheckpointCert{Checkpoint: hotstuff.Checkpoint{
+			Height:         height,
+			View:           block.View(),
+			Block:          block.Hash(),
+			ActiveReplicas: activeReplicas,
+			StateDigest:    stateDigest(executionState),
+			RankingDigest:  stateDigest(rankingState),
+		}},
+		Block:          block,
+		ExecutionState: executionState,
+		RankingState:   rankingState,
+	}
+	checkpoint := snapshot.Cert.Checkpoint
+
+	s.checkpointMut.Lock()
+	defer s.checkpointMut.Unlock()
+	if certified := s.certified.Checkpoint; height < certified.Height {
+		return
+	} else if height == certified.Height {
+		// the other replicas certified the checkpoint before this replica committed the block
+		if !certified.Equals(checkpoint) {
+			s.logger.Errorf("Checkpoint %d differs from the stable checkpoint: %v", height, checkpoint)
+			return
+		}
+		snapshot.Cert = s.certified
+		s.makeStable(snapshot)
+		return
+	}
+
+	sig, err := s.crypto.Sign(checkpoint.ToBytes())
+	if err != nil {
+		s.logger.Warnf("Failed to sign checkpoint: %v", err)
+		return
+	}
+	s.ownCheckpoints[height] = snapshot
+	msg := hotstuff.CheckpointMsg{ID: s.opts.ID(), Checkpoint: checkpoint, Signature: sig}
+	if cfg, ok := s.configuration.(modules.CheckpointConfiguration); ok {
+		cfg.Checkpoint(msg)
+	}
+	// the consensus module is committing, so this replica's signature is collected by the event loop
+	s.eventLoop.AddEvent(msg)
+}
+
+// OnCheckpoint collects a replica's signature of a checkpoint, and certifies the checkpoint when a quorum of replicas
+// have signed it. If this replica has not committed the certified checkpoint yet, it catches up with it.
+func (s *Synchronizer) OnCheckpoint(msg hotstuff.CheckpointMsg) {
+	checkpoint := msg.Checkpoint
+	s.checkpointMut.Lock()
+	old := checkpoint.Height <= s.certified.Checkpoint.Height
+	s.checkpointMut.Unlock()
+	if old {
+		return
+	}
+
+	if msg.Signature == nil || msg.Signature.Participants().Len() != 1 || !msg.Signature.Participants().Contains(msg.ID) ||
+		!s.crypto.Verify(msg.Signature, checkpoint.ToBytes()) {
+		s.logger.Infof("Checkpoint signature from replica %d could not be verified!", msg.ID)
+		return
+	}
+
+	s.checkpointMut.Lock()
+	cert, ok := s.addCheckpointSignature(msg)
+	if !ok {
+		s.checkpointMut.Unlock()
+		return
+	}
+	own, ok := s.ownCheckpoints[checkpoint.Height]
+	if ok && own.Cert.Checkpoint.Equals(checkpoint) {
+		own.Cert = cert
+		s.makeStable(own)
+		s.checkpointMut.Unlock()
+		return
+	}
+	s.checkpointMut.Unlock()
+
+	if ok {
+		s.logger.Errorf("Checkpoint %d differs from the stable checkpoint: %v", checkpoint.Height, own.Cert.Checkpoint)
+		return
+	}
+	s.catchUp(cert)
+}
+
+// addCheckpointSignature adds the signature to the signatures that were collected for the height of the checkpoint,
+// and returns the certificate of the checkpoint if a quorum of replicas have signed it.
+// The caller must hold checkpointMut.
+func (s *Synchronizer) addCheckpointSignature(msg hotstuff.CheckpointMsg) (cert hotstuff.CheckpointCert, ok bool) {
+	checkpoint := msg.Checkpoint
+	shares, ok := s.checkpointShares[checkpoint.Height]
+	if !ok {
+		shares = make(map[hotstuff.ID]hotstuff.CheckpointMsg)
+		s.checkpointShares[checkpoint.Height] = shares
+	}
+	if _, ok := shares[msg.ID]; ok {
+		return cert, false
+	}
+	shares[msg.ID] = msg
+
+	var signatures []hotstuff.QuorumSignature
+	for _, share := range shares {
+		if share.Checkpoint.Equals(checkpoint) {
+			signatures = append(signatures, share.Signature)
+		}
+	}
+	if len(signatures) < s.configuration.QuorumSize(checkpoint.View) {
+		return cert, false
+	}
+	sig, err := s.crypto.Combine(signatures...)
+	if err != nil {
+		s.logger.Warnf("Failed to combine checkpoint signatures: %v", err)
+		return cert, false
+	}
+
+	cert = hotstuff.CheckpointCert{Checkpoint: checkpoint, Signature: sig}
+	s.certified = cert
+	for height := range s.checkpointShares {
+		if height <= checkpoint.Height {
+			delete(s.checkpointShares, height)
+		}
+	}
+	return cert, true
+}
+
+// makeStable makes the snapshot the stable checkpoint, and discards the blocks and checkpoints below it.
+// The caller must hold checkpointMut.
+func (s *Synchronizer) makeStable(snapshot hotstuff.CheckpointSnapshot) {
+	height := snapshot.Cert.Checkpoint.Height
+	if s.hasStable && height <= s.stable.Cert.Checkpoint.Height {
+		return
+	}
+	s.stable, s.hasStable = snapshot, true
+	for h := range s.ownCheckpoints {
+		if h <= height {
+			delete(s.ownCheckpoints, h)
+		}
+	}
+	discarded := 0
+	if discarder, ok := s.blockChain.(modules.BlockDiscarder); ok {
+		discarded = discarder.DiscardBefore(snapshot.Block)
+	}
+	s.logger.Infof("Checkpoint %d in view %d is stable, discarded %d blocks", height, snapshot.Block.View(), discarded)
+}
+
+// StableCheckpoint returns the latest stable checkpoint whose state this replica has, if any.
+func (s *Synchronizer) StableCheckpoint() (snapshot hotstuff.CheckpointSnapshot, ok bool) {
+	s.checkpointMut.Lock()
+	defer s.checkpointMut.Unlock()
+	return s.stable, s.hasStable
+}
+
+// catchUp brings this replica up to a stable checkpoint that it has not committed. The blocks that lead up to the
+// checkpoint are fetched and committed if the other replicas still have them, and otherwise the state of the
+// checkpoint is fetched and installed.
+func (s *Synchronizer) catchUp(cert hotstuff.CheckpointCert) {
+	committed := s.consensus.CommittedBlock()
+	if cert.Checkpoint.View <= committed.View() {
+		return
+	}
+	if block, ok := s.blockChain.Get(cert.Checkpoint.Block); ok && s.blockChain.Extends(block, committed) {
+		// the block is committed by the quorum that certified the checkpoint, so it is safe to commit
+		if !block.Command().IsReconfiguration() {
+			s.acceptor.Proposed(block.Command())
+		}
+		s.consensus.Commit(block)
+		return
+	}
+	s.logger.Infof("Checkpoint %d: the blocks since view %d are no longer available, fetching the checkpoint",
+		cert.Checkpoint.Height, committed.View())
+	s.transferState(cert.Checkpoint.Height)
+}
+
+// transferState fetches a stable checkpoint at or above the given height from the other replicas,
+// and installs it if its certificate and state are valid.
+func (s *Synchronizer) transferState(height uint64) {
+	cfg, ok := s.configuration.(modules.CheckpointConfiguration)
+	if !ok {
+		s.logger.Warn("Checkpoint: the configuration cannot fetch checkpoints")
+		return
+	}
+	installer, ok := s.consensus.(modules.CheckpointInstaller)
+	if !ok {
+		s.logger.Warn("Checkpoint: the consensus module cannot install checkpoints")
+		return
+	}
+
+	ctx, cancel := TimeoutContext(s.eventLoop.Context(), s.eventLoop)
+	defer cancel()
+	snapshot, ok := cfg.FetchCheckpoint(ctx, height)
+	if !ok {
+		s.logger.Warnf("Checkpoint: could not fetch checkpoint %d", height)
+		return
+	}
+	if !s.verifyCheckpoint(snapshot) {
+		s.logger.Warnf("Checkpoint: the fetched checkpoint %d is invalid", snapshot.Cert.Checkpoint.Height)
+		return
+	}
+	checkpoint := snapshot.Cert.Checkpoint
+	committed := s.consensus.CommittedBlock()
+	if checkpoint.View <= committed.View() {
+		return
+	}
+
+	if s.executionState != nil {
+		if err := s.executionState.RestoreExecution(snapshot.ExecutionState); err != nil {
+			s.logger.Errorf("Checkpoint: failed to restore the execution state: %v", err)
+			return
+		}
+	}
+	if snapshotter, ok := s.ranking.(modules.Snapshotter); ok && snapshot.RankingState != nil {
+		if err := snapshotter.Restore(snapshot.RankingState); err != nil {
+			s.logger.Errorf("Checkpoint: failed to restore the ranking state: %v", err)
+		}
+	}
+	s.blockChain.Store(snapshot.Block)
+	installer.InstallCheckpoint(snapshot.Block, checkpoint)
+
+	s.checkpointMut.Lock()
+	if checkpoint.Height > s.certified.Checkpoint.Height {
+		s.certified = snapshot.Cert
+	}
+	s.makeStable(snapshot)
+	s.checkpointMut.Unlock()
+	s.logger.Infof("Checkpoint installed: caught up from view %d to view %d", committed.View(), checkpoint.View)
+}
+
+// verifyCheckpoint returns true if the certificate of the snapshot is signed by a quorum of replicas,
+// and the block and the state of the snapshot are those of the certified checkpoint.
+func (s *Synchronizer) verifyCheckpoint(snapshot hotstuff.CheckpointSnapshot) bool {
+	checkpoint, sig := snapshot.Cert.Checkpoint, snapshot.Cert.Signature
+	if sig == nil || sig.Participants().Len() < s.configuration.QuorumSize(checkpoint.View) ||
+		!s.crypto.Verify(sig, checkpoint.ToBytes()) {
+		return false
+	}
+	return snapshot.Block != nil && snapshot.Block.Hash() == checkpoint.Block && snapshot.Block.View() == checkpoint.View &&
+		bytes.Equal(stateDigest(snapshot.ExecutionState), checkpoint.StateDigest) &&
+		bytes.Equal(stateDigest(snapshot.RankingState), checkpoint.RankingDigest)
+}
+
+// stateSnapshots returns the snapshots of the execution state and the ranking state, or nil if they are not available.
+func (s *Synchronizer) stateSnapshots() (executionState, rankingState []byte) {
+	if s.executionState != nil {
+		executionState = s.executionState.ExecutionSnapshot()
+	}
+	if snapshotter, ok := s.ranking.(modules.Snapshotter); ok {
+		rankingState = snapshotter.Snapshot()
+	}
+	return executionState, rankingState
+}
+
+// stateDigest returns the SHA-256 hash of the state, or nil if the state is empty.
+func stateDigest(state []byte) []byte {
+	if len(state) == 0 {
+		return nil
+	}
+	digest := sha256.Sum256(state)
+	return digest[:]
+}
+
+var _ modules.Checkpointer = (*Synchronizer)(nil)
@@ -30,17 +30,28 @@ type Synchronizer struct {
 	highTC         hotstuff.TimeoutCert
 	highQC         hotstuff.QuorumCert
 	ranking        modules.Ranking
+	storage        modules.StableStorage
 
 	// A pointer to the last timeout message that we sent.
 	// If a timeout happens again before we advance to the next view,
 	// we will simply send this timeout again.
 	lastTimeout *hotstuff.TimeoutMsg
 
-	duration ViewDuration
-	timer    *time.Timer
+	duration     ViewDuration
+	stopTimer    func() bool // stops the timeout timer of the current view
+	timerStarted bool        // the timeout timer is not started until Start or OnLocalTimeout is called
 
 	// map of collected timeout messages per view
 	timeouts map[hotstuff.View]map[hotstuff.ID]hotstuff.TimeoutMsg
+
+	// checkpoint state; see checkpoint.go
+	executionState   modules.ExecutionState
+	checkpointMut    sync.Mutex                                        // to protect the following
+	ownCheckpoints   map[uint64]hotstuff.CheckpointSnapshot            // checkpoints taken by this replica that are not stable yet
+	checkpointShares map[uint64]map[hotstuff.ID]hotstuff.CheckpointMsg // collected checkpoint signatures per height
+	certified        hotstuff.CheckpointCert                           // the latest certified checkpoint
+	stable           hotstuff.CheckpointSnapshot                       // the latest stable checkpoint whose state this replica has
+	hasStable        bool
 }
 
 // InitModule initializes the synchronizer.
@@ -55,8 +66,14 @@ func (s *Synchronizer) InitModule(mods *modules.Core) {
 		&s.logger,
 		&s.opts,
 		&s.acceptor,
-		&s.ranking,
 	)
+	mods.TryGet(&s.ranking)
+	mods.TryGet(&s.storage)
+	mods.TryGet(&s.executionState)
+	if d, ok := s.duration.(*viewDuration); ok {
+		// measure the views with the clock of the event loop
+		d.now = s.eventLoop.Now
+	}
 
 	s.eventLoop.RegisterHandler(TimeoutEvent{}, func(event any) {
 		timeoutView := event.(TimeoutEvent).View
@@ -76,6 +93,10 @@ func (s *Synchronizer) InitModule(mods *modules.Core) {
 		s.OnRemoteTimeout(timeoutMsg)
 	})
 
+	s.eventLoop.RegisterHandler(hotstuff.CheckpointMsg{}, func(event any) {
+		s.OnCheckpoint(event.(hotstuff.CheckpointMsg))
+	})
+
 	s.eventLoop.RegisterHandler(hotstuff.Update{}, func(event any) {
 		update := event.(hotstuff.Update)
 		s.handleUpdateEvent(update.Block, update.QuorumSize)
@@ -97,15 +118,29 @@ func New(viewDuration ViewDuration) modules.Synchronizer {
 	return &Synchronizer{
 		currentView: 1,
 
-		duration: viewDuration,
-		timer:    time.AfterFunc(0, func() {}), // dummy timer that will be replaced after start() is called
+		duration:  viewDuration,
+		stopTimer: func() bool { return false },
 
 		timeouts: make(map[hotstuff.View]map[hotstuff.ID]hotstuff.TimeoutMsg),
+
+		ownCheckpoints:   make(map[uint64]hotstuff.CheckpointSnapshot),
+		checkpointShares: make(map[uint64]map[hotstuff.ID]hotstuff.CheckpointMsg),
 	}
 }
 
 func (s *Synchronizer) startTimeoutTimer() {
-	s.timer = time.AfterFunc(s.duration.Duration(), func() {
+	s.timerStarted = true
+	s.resetTimer(s.duration.Duration())
+}
+
+// resetTimer stops the timeout timer, and restarts it with the duration d if it has been started.
+// The timer uses the clock of the event loop, which may be a simulated clock.
+func (s *Synchronizer) resetTimer(d time.Duration) {
+	s.stopTimer()
+	if !s.timerStarted {
+		return
+	}
+	s.stopTimer = s.eventLoop.AfterFunc(d, func() {
 		// The event loop will execute onLocalTimeout for us.
 		s.eventLoop.AddEvent(TimeoutEvent{s.View()})
 	})
@@ -117,7 +152,7 @@ func (s *Synchronizer) Start(ctx context.Context) {
 
 	go func() {
 		<-ctx.Done()
-		s.timer.Stop()
+		s.stopTimer()
 	}()
 
 	// start the initial proposal
@@ -327,7 +362,7 @@ func (s *Synchronizer) AdvanceView(syncInfo hotstuff.SyncInfo, isReconfig bool)
 		return
 	}
 
-	s.timer.Stop()
+	s.stopTimer()
 
 	if !timeout {
 		s.duration.ViewSucceeded()
@@ -342,10 +377,10 @@ func (s *Synchronizer) AdvanceView(syncInfo hotstuff.SyncInfo, isReconfig bool)
 	//s.logger.Info("Duration of the view is ", s.duration.Duration())
 	if isReconfig {
 		s.currentView = newView + 1
-		s.timer.Reset(s.duration.StartTimeout())
+		s.resetTimer(s.duration.StartTimeout())
 	} else {
 		s.currentView = newView
-		s.timer.Reset(duration)
+		s.resetTimer(duration)
 	}
 	s.eventLoop.AddEvent(ViewChangeEvent{View: newView, Timeout: timeout})
 	leader := s.leaderRotation.GetLeader(newView)
@@ -370,6 +405,26 @@ func (s *Synchronizer) updateHighQC(qc hotstuff.QuorumCert) {
 	if newBlock.View() > s.highQC.View() {
 		s.highQC = qc
 		s.logger.Debug("HighQC updated")
+		if s.storage != nil {
+			s.storage.StoreHighQC(qc)
+		}
+	}
+}
+
+// Recover restores the high QC that was stored by a previous run of the replica,
+// and starts in the view after the high QC and the last view that the replica voted in.
+func (s *Synchronizer) Recover(storage modules.StableStorage) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if qc, ok := storage.HighQC(); ok && qc.View() > s.highQC.View() {
+		s.highQC = qc
+	}
+	view := s.highQC.View()
+	if lastVote := storage.LastVote(); lastVote > view {
+		view = lastVote
+	}
+	if view+1 > s.currentView {
+		s.currentView = view + 1
 	}
 }
 
@@ -382,43 +437,43 @@ func (s *Synchronizer) updateHighTC(tc hotstuff.TimeoutCert) {
 }
 
 func (s *Synchronizer) Pause(qc hotstuff.QuorumCert) {
-	s.timer.Stop()
+	s.stopTimer()
 	s.performCheckPoint(qc)
 }
 
 func (s *Synchronizer) Resume(qc hotstuff.QuorumCert) {
 	s.performCheckPoint(qc)
-	s.timer = time.AfterFunc(s.duration.Duration(), func() {
-		// The event loop will execute onLocalTimeout for us.
-		s.eventLoop.AddEvent(TimeoutEvent{View: s.currentView})
-	})
+	s.startTimeoutTimer()
 	s.AdvanceView(hotstuff.NewSyncInfo().WithQC(qc), true)
 }
 
+// performCheckPoint catches up with the block certified by the QC of a reconfiguration, and commits it.
+// The blocks between the committed block and the certified block are fetched by the BlockChain in batches, such that
+// a replica that was paused, or that has fallen far behind, does not walk the chain block by block.
+// The certified block is only committed if the QC is valid and the block extends the committed block.
 func (s *Synchronizer) performCheckPoint(qc hotstuff.QuorumCert) {
-	// Check if the latest qc received from the reconfiguration request
-	// is higher than the qc we have seen.
-
-	currentHighQCView := s.highQC.View()
-
-	if currentHighQCView < s.highTC.View() {
-		currentHighQCView = s.highTC.View()
-	}
-	for currentHighQCView < qc.View()-1 {
-		block, ok := s.blockChain.Get(qc.BlockHash())
-		if !ok {
-			break
-		}
-		s.acceptor.Proposed(block.Command())
-		s.blockChain.Store(block)
-		currentHighQCView = block.QuorumCert().View()
+	committed := s.consensus.CommittedBlock()
+	if qc.View() <= committed.View() {
+		return
 	}
-	// This should repeatedly committ the inner blocks.
 	block, ok := s.blockChain.Get(qc.BlockHash())
-	if ok {
-		s.consensus.Commit(block)
+	if !ok {
+		s.logger.Warnf("Checkpoint: could not fetch block %.8s", qc.BlockHash())
+		return
+	}
+	if !s.crypto.VerifyQuorumCert(qc) {
+		s.logger.Warnf("Checkpoint: invalid QC for block %.8s", qc.BlockHash())
+		return
+	}
+	if !s.blockChain.Extends(block, committed) {
+		s.logger.Warnf("Checkpoint: block %.8s does not extend the committed block %.8s", block.Hash(), committed.Hash())
+		return
+	}
+	if !block.Command().IsReconfiguration() {
+		s.acceptor.Proposed(block.Command())
 	}
-	s.logger.Info("checkpoint completed", currentHighQCView, qc.View())
+	s.consensus.Commit(block)
+	s.logger.Infof("Checkpoint completed: caught up from view %d to view %d", committed.View(), block.View())
 }
 
 var _ modules.Synchronizer = (*Synchronizer)(nil)
@@ -31,6 +31,7 @@ func NewViewDuration(sampleSize uint64, startTimeout, maxTimeout, multiplier flo
 		max:          maxTimeout,
 		mul:          multiplier,
 		startTimeout: startTimeout,
+		now:          time.Now,
 	}
 }
 
@@ -46,6 +47,7 @@ type viewDuration struct {
 	prevM2       float64   // m2 calculated from the last period
 	max          float64   // upper bound on view timeout
 	startTimeout float64   // is the configured start timeout
+	now          func() time.Time
 }
 
 func (v *viewDuration) StartTimeout() time.Duration {
@@ -59,7 +61,7 @@ func (v *viewDuration) ViewSucceeded() {
 		return
 	}
 
-	duration := float64(time.Since(v.startTime)) / float64(time.Millisecond)
+	duration := float64(v.now().Sub(v.startTime)) / float64(time.Millisecond)
 	v.count++
 
 	// Reset m2 occasionally such that we will pick up on changes in variance faster.
@@ -93,7 +95,7 @@ func (v *viewDuration) ViewTimeout() {
 
 // ViewStarted records the start time of a view.
 func (v *viewDuration) ViewStarted() {
-	v.startTime = time.Now()
+	v.startTime = v.now()
 }
 
 // Duration returns the upper bound of the 95% confidence interval for the mean view duration.
@@ -108,7 +110,8 @@ func (v *viewDuration) Duration() time.Duration {
 			c = float64(v.limit) + float64(v.count%v.limit)
 			m2 += v.prevM2
 		}
-		dev = math.Sqrt(m2 / c)
+		// rounding errors can make m2 slightly negative when the views take the same time
+		dev = math.Sqrt(max(m2, 0) / c)
 	}
 
 	duration := v.mean + dev*conf
@@ -0,0 +1,22 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+)
+
+// TestViewDurationEqualViews tests that views of the same duration give that duration,
+// even when rounding errors make the sum of squares slightly negative.
+func TestViewDurationEqualViews(t *testing.T) {
+	now := time.Unix(0, 0)
+	d := NewViewDuration(1000, 565.4, 0, 1.2).(*viewDuration)
+	d.now = func() time.Time { return now }
+	for range 3 {
+		d.ViewStarted()
+		now = now.Add(62900 * time.Microsecond)
+		d.ViewSucceeded()
+	}
+	if got, want := d.Duration(), 62900*time.Microsecond; got < want-time.Microsecond || got > want+time.Microsecond {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package twins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+)
+
+func TestSimulationCheckpoints(t *testing.T) {
+	withoutFaultyNodes(t)
+	result, err := Simulate(SimulationConfig{
+		Replicas:           4,
+		Latency:            10 * time.Millisecond,
+		CheckpointInterval: 10,
+		Duration:           2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe || result.Commits == 0 {
+		t.Fatalf("expected the replicas to commit blocks safely: %+v", result)
+	}
+	checkStableCheckpoints(t, result, 4)
+}
+
+func TestSimulationCheckpointsComplaintCache(t *testing.T) {
+	withoutFaultyNodes(t)
+	// the replicas must agree on the digest of the ranking state, even though each replica records the latencies
+	// of the blocks that it proposed, and numbers the complaints that it raised
+	result, err := Simulate(SimulationConfig{
+		Replicas:           4,
+		Latency:            10 * time.Millisecond,
+		Modules:            []string{"complaintcache"},
+		CheckpointInterval: 10,
+		Duration:           2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe || result.Commits == 0 {
+		t.Fatalf("expected the replicas to commit blocks safely: %+v", result)
+	}
+	checkStableCheckpoints(t, result, 4)
+}
+
+func TestSimulationCheckpointStateTransfer(t *testing.T) {
+	withoutFaultyNodes(t)
+	// replica 4 is crashed until the other replicas have discarded the blocks that it is missing,
+	// so it can only catch up by installing a stable checkpoint
+	result, err := Simulate(SimulationConfig{
+		Replicas:           4,
+		Latency:            10 * time.Millisecond,
+		CheckpointInterval: 10,
+		Faults:             []Fault{{Replica: 4, Crash: true, Start: 100 * time.Millisecond, End: 1800 * time.Millisecond}},
+		Duration:           3 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe || result.Commits == 0 {
+		t.Fatalf("expected the replicas to commit blocks safely: %+v", result)
+	}
+	checkStableCheckpoints(t, result, 4)
+	if result.Executed[4]+1 < result.Executed[1] {
+		t.Errorf("expected replica 4 to catch up, executed %d blocks, replica 1 executed %d", result.Executed[4], result.Executed[1])
+	}
+}
+
+// checkStableCheckpoints checks that every replica has a stable checkpoint,
+// and that the replicas agree on the checkpoints at the same height.
+func checkStableCheckpoints(t *testing.T, result SimulationResult, replicas int) {
+	t.Helper()
+	checkpoints := make(map[uint64]hotstuff.Checkpoint)
+	for id := hotstuff.ID(1); id <= hotstuff.ID(replicas); id++ {
+		cert, ok := result.Checkpoints[id]
+		if !ok {
+			t.Errorf("replica %d has no stable checkpoint", id)
+			continue
+		}
+		checkpoint := cert.Checkpoint
+		if checkpoint.Height == 0 || cert.Signature == nil {
+			t.Errorf("replica %d has an invalid stable checkpoint: %v", id, checkpoint)
+		}
+		if other, ok := checkpoints[checkpoint.Height]; ok && !other.Equals(checkpoint) {
+			t.Errorf("replica %d has checkpoint %v, expected %v", id, checkpoint, other)
+		}
+		checkpoints[checkpoint.Height] = checkpoint
+	}
+}
@@ -0,0 +1,137 @@
+package twins
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/relab/hotstuff"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// The consensus code paths that are counted by the nodes in a scenario.
+const (
+	pathPropose             = "propose"              // a proposal was handled
+	pathConflictingProposal = "conflicting-proposal" // a second, different proposal was handled for the same view
+	pathVote                = "vote"                 // a vote was handled
+	pathNewView             = "new-view"             // a new view message was handled
+	pathTimeoutMsg          = "timeout-msg"          // a timeout message was handled
+	pathLocalTimeout        = "local-timeout"        // a local timeout occurred
+	pathViewChangeQC        = "view-change-qc"       // the view changed because of a quorum certificate
+	pathViewChangeTimeout   = "view-change-timeout"  // the view changed because of a timeout certificate
+	pathCommit              = "commit"               // a block was committed
+	pathFork                = "fork"                 // a block was pruned from the chain without being committed
+	pathFetch               = "fetch"                // a missing block was fetched from another node
+	pathFetchFailed         = "fetch-failed"         // a missing block could not be fetched
+	pathContribution        = "kauri-contribution"   // a Kauri contribution was handled
+	pathDrop                = "drop"                 // a message was dropped by the partitions
+)
+
+var paths = []string{
+	pathPropose,
+	pathConflictingProposal,
+	pathVote,
+	pathNewView,
+	pathTimeoutMsg,
+	pathLocalTimeout,
+	pathViewChangeQC,
+	pathViewChangeTimeout,
+	pathCommit,
+	pathFork,
+	pathFetch,
+	pathFetchFailed,
+	pathContribution,
+	pathDrop,
+}
+
+// Coverage counts the leaders, partitions and consensus code paths that were exercised by a corpus of scenarios.
+type Coverage struct {
+	Scenarios int `json:"scenarios"`
+	// Leaders counts the views that each replica was the leader of.
+	Leaders map[hotstuff.ID]int `json:"leaders"`
+	// Partitions counts the views that had each set of partitions, such as "{1 2 3} {4 5}".
+	Partitions map[string]int `json:"partitions"`
+	// Paths counts the scenarios in which each consensus code path was taken by at least one node.
+	Paths map[string]int `json:"paths"`
+}
+
+// NewCoverage returns an empty coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{
+		Leaders:    make(map[hotstuff.ID]int),
+		Partitions: make(map[string]int),
+		Paths:      make(map[string]int),
+	}
+}
+
+// Add adds a scenario and the result of executing it to the coverage.
+func (c *Coverage) Add(scenario Scenario, result ScenarioResult) {
+	c.Scenarios++
+	for _, view := range scenario {
+		c.Leaders[view.Leader]++
+		c.Partitions[partitionsKey(view.Partitions)]++
+	}
+	for path, count := range result.Paths {
+		if count > 0 {
+			c.Paths[path]++
+		}
+	}
+}
+
+// Missing returns the consensus code paths that were not taken in any scenario.
+func (c *Coverage) Missing() []string {
+	var missing []string
+	for _, path := range paths {
+		if c.Paths[path] == 0 {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+func (c *Coverage) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "scenarios: %d\n", c.Scenarios)
+
+	leaders := maps.Keys(c.Leaders)
+	slices.Sort(leaders)
+	sb.WriteString("leaders (views):")
+	for _, id := range leaders {
+		fmt.Fprintf(&sb, " %d (%d)", id, c.Leaders[id])
+	}
+	sb.WriteString("\n")
+
+	fmt.Fprintf(&sb, "partitions: %d distinct\n", len(c.Partitions))
+
+	sb.WriteString("paths (scenarios):")
+	for _, path := range paths {
+		fmt.Fprintf(&sb, " %s (%d)", path, c.Paths[path])
+	}
+	sb.WriteString("\n")
+
+	if missing := c.Missing(); len(missing) > 0 {
+		fmt.Fprintf(&sb, "paths not taken: %s\n", strings.Join(missing, ", "))
+	}
+	return sb.String()
+}
+
+// partitionsKey returns a string that identifies the set of partitions,
+// independent of the order of the partitions and of the nodes in them.
+func partitionsKey(partitions []NodeSet) string {
+	keys := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		if len(partition) == 0 {
+			continue
+		}
+		ids := maps.Keys(partition)
+		slices.Sort(ids)
+		parts := make([]string, len(ids))
+		for i, id := range ids {
+			parts[i] = fmt.Sprint(id)
+		}
+		keys = append(keys, "{"+strings.Join(parts, " ")+"}")
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " ")
+}
@@ -0,0 +1,43 @@
+package twins
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestCoverage(t *testing.T) {
+	allNodesSet := NodeSet{1: {}, 2: {}, 3: {}, 4: {}}
+	s := Scenario{
+		{Leader: 1, Partitions: []NodeSet{allNodesSet}},
+		{Leader: 2, Partitions: []NodeSet{{1: {}, 2: {}, 3: {}}, {4: {}}}},
+		{Leader: 3, Partitions: []NodeSet{{4: {}}, {3: {}, 2: {}, 1: {}}}},
+		{Leader: 3, Partitions: []NodeSet{allNodesSet}},
+	}
+	result, err := ExecuteScenario(s, 4, 0, 100, "chainedhotstuff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCoverage()
+	c.Add(s, result)
+	c.Add(s, result)
+
+	if c.Scenarios != 2 {
+		t.Errorf("expected 2 scenarios, got %d", c.Scenarios)
+	}
+	if c.Leaders[1] != 2 || c.Leaders[2] != 2 || c.Leaders[3] != 4 {
+		t.Errorf("unexpected leaders: %v", c.Leaders)
+	}
+	if c.Partitions["{1 2 3} {4}"] != 4 || len(c.Partitions) != 2 {
+		t.Errorf("unexpected partitions: %v", c.Partitions)
+	}
+	for _, path := range []string{pathPropose, pathVote, pathCommit, pathDrop} {
+		if c.Paths[path] != 2 {
+			t.Errorf("expected path %q to be taken in both scenarios, got %d", path, c.Paths[path])
+		}
+	}
+	if missing := c.Missing(); !slices.Contains(missing, pathContribution) {
+		t.Errorf("expected Kauri's contributions to be missing, got %v", missing)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/kauri"
 	"github.com/relab/hotstuff/logging"
 )
 
@@ -69,16 +70,26 @@ func NewGenerator(logger logging.Logger, settings Settings) *Generator {
 	g.allNodes = append(g.allNodes, twins...)
 	g.allNodes = append(g.allNodes, nodes...)
 
-	partitionScenarios := genPartitionScenarios(twins, nodes, settings.Partitions, 1)
-
-	// assign each replica as leader to each partition scenario
-	for _, p := range partitionScenarios {
-		for _, node := range nodes {
+	if settings.Tree {
+		// Kauri disseminates the proposals from the root of the tree, so the leader is fixed.
+		for _, p := range genTreePartitionScenarios(twins, nodes) {
 			g.leadersPartitions = append(g.leadersPartitions, View{
-				Leader:     node.ReplicaID,
+				Leader:     treeRoot,
 				Partitions: p,
 			})
 		}
+	} else {
+		partitionScenarios := genPartitionScenarios(twins, nodes, settings.Partitions, 1)
+
+		// assign each replica as leader to each partition scenario
+		for _, p := range partitionScenarios {
+			for _, node := range nodes {
+				g.leadersPartitions = append(g.leadersPartitions, View{
+					Leader:     node.ReplicaID,
+					Partitions: p,
+				})
+			}
+		}
 	}
 
 	g.remaining = int64(math.Pow(float64(len(g.leadersPartitions)), float64(g.settings.Views)))
@@ -303,3 +314,136 @@ func genPartitionScenarios(twins, nodes []NodeID, k uint8, min uint8) (partition
 	}
 	return
 }
+
+// treeRoot is the replica at the root of the tree used in tree scenarios.
+const treeRoot = hotstuff.ID(1)
+
+// treePositions returns the IDs of the replicas in order of their positions in the tree used in tree scenarios.
+func treePositions(numReplicas int) []hotstuff.ID {
+	ids := make([]hotstuff.ID, numReplicas)
+	for i := range ids {
+		ids[i] = treeRoot + hotstuff.ID(i)
+	}
+	return ids
+}
+
+// treeHeight returns the number of levels of a tree with the given number of replicas.
+func treeHeight(numReplicas int) int {
+	height := 0
+	for levelSize, remaining := 1, numReplicas; remaining > 0; levelSize *= kauri.MaxChild {
+		remaining -= levelSize
+		height++
+	}
+	return height
+}
+
+// subTree returns the tree positions of the subtree rooted at the given position, including the position itself.
+func subTree(pos, numReplicas int) []int {
+	positions := []int{pos}
+	for i := 0; i < len(positions); i++ {
+		for c := 1; c <= kauri.MaxChild; c++ {
+			if child := positions[i]*kauri.MaxChild + c; child < numReplicas {
+				positions = append(positions, child)
+			}
+		}
+	}
+	return positions
+}
+
+// treeCut determines how an internal node of the tree is cut off from the rest of the tree.
+type treeCut uint8
+
+const (
+	notCut     treeCut = iota // the node is connected
+	isolated                  // the node is cut off from both its parent and its children
+	subTreeCut                // the node is cut off from its parent, together with its subtree
+	numTreeCuts
+)
+
+// genTreePartitionScenarios generates partition scenarios that cut off internal nodes of the tree given by treePositions.
+// Each internal node is either connected, isolated, or cut off together with its subtree, and the nodes that are cut off
+// together form a partition. The first twin of a replica is placed in the partition of the replica,
+// and the second twin is placed in each of the partitions in turn.
+func genTreePartitionScenarios(twins, nodes []NodeID) (partitionScenarios [][]NodeSet) {
+	// the network IDs of each replica; a replica with twins has two.
+	replicas := make(map[hotstuff.ID][]uint32)
+	for _, node := range append(append([]NodeID{}, twins...), nodes...) {
+		replicas[node.ReplicaID] = append(replicas[node.ReplicaID], node.NetworkID)
+	}
+	numReplicas := len(replicas)
+	positions := treePositions(numReplicas)
+
+	var internal []int
+	for pos := 1; pos*kauri.MaxChild+1 < numReplicas; pos++ {
+		internal = append(internal, pos)
+	}
+
+	cuts := make([]treeCut, len(internal))
+	for {
+		// the partition of each tree position; partition 0 is connected to the root.
+		partition := make([]int, numReplicas)
+		numPartitions := 1
+		for i, cut := range cuts {
+			switch cut {
+			case isolated:
+				partition[internal[i]] = numPartitions
+			case subTreeCut:
+				for _, pos := range subTree(internal[i], numReplicas) {
+					partition[pos] = numPartitions
+				}
+			default:
+				continue
+			}
+			numPartitions++
+		}
+		partitionScenarios = append(partitionScenarios, assignTwins(replicas, positions, partition, numPartitions)...)
+
+		// advance to the next combination of cuts
+		i := 0
+		for ; i < len(cuts); i++ {
+			cuts[i]++
+			if cuts[i] < numTreeCuts {
+				break
+			}
+			cuts[i] = notCut
+		}
+		if i == len(cuts) {
+			return partitionScenarios
+		}
+	}
+}
+
+// assignTwins returns the partition scenarios where each replica is in the partition of its tree position,
+// and the second twin of each replica with twins is placed in each of the partitions in turn.
+func assignTwins(replicas map[hotstuff.ID][]uint32, positions []hotstuff.ID, partition []int, numPartitions int) [][]NodeSet {
+	partitions := make([]NodeSet, numPartitions)
+	for i := range partitions {
+		partitions[i] = make(NodeSet)
+	}
+	var secondTwins []uint32
+	for pos, id := range positions {
+		networkIDs := replicas[id]
+		partitions[partition[pos]].Add(networkIDs[0])
+		secondTwins = append(secondTwins, networkIDs[1:]...)
+	}
+
+	scenarios := [][]NodeSet{partitions}
+	for _, twin := range secondTwins {
+		var next [][]NodeSet
+		for _, s := range scenarios {
+			for i := range s {
+				c := make([]NodeSet, len(s))
+				for j := range s {
+					c[j] = make(NodeSet, len(s[j])+1)
+					for id := range s[j] {
+						c[j].Add(id)
+					}
+				}
+				c[i].Add(twin)
+				next = append(next, c)
+			}
+		}
+		scenarios = next
+	}
+	return scenarios
+}
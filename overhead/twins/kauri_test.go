@@ -0,0 +1,130 @@
+package twins
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff"
+	_ "github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	_ "github.com/relab/hotstuff/ranking"
+)
+
+// withoutFaultyNodes disables the artificial delay of the faulty nodes, which would stall the event loops of the twins network.
+func withoutFaultyNodes(t *testing.T) {
+	faultyNodes := hotstuff.FaultyNodes
+	hotstuff.FaultyNodes = nil
+	t.Cleanup(func() { hotstuff.FaultyNodes = faultyNodes })
+}
+
+func TestTreePartitionScenarios(t *testing.T) {
+	nodes, twins := assignNodeIDs(7, 0)
+	scenarios := genTreePartitionScenarios(twins, nodes)
+	// each of the two internal nodes below the root is connected, isolated, or cut off with its subtree
+	if len(scenarios) != 9 {
+		t.Fatalf("expected 9 partition scenarios, got %d", len(scenarios))
+	}
+	found := false
+	for _, partitions := range scenarios {
+		if len(partitions) == 2 && partitions[0].Contains(1) && len(partitions[1]) == 3 &&
+			partitions[1].Contains(2) && partitions[1].Contains(4) && partitions[1].Contains(5) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a scenario where the subtree of replica 2 is cut off: %v", scenarios)
+	}
+}
+
+func TestKauriIsolatedInternalNode(t *testing.T) {
+	withoutFaultyNodes(t)
+	all := make(NodeSet)
+	connected := make(NodeSet)
+	for i := uint32(1); i <= 7; i++ {
+		all.Add(i)
+		if i != 2 {
+			connected.Add(i)
+		}
+	}
+	// replica 2 is an internal node of the tree, and is cut off in views 2 to 4
+	var s Scenario
+	for v := 1; v <= 8; v++ {
+		if v >= 2 && v <= 4 {
+			s = append(s, View{Leader: treeRoot, Partitions: []NodeSet{connected, {2: {}}}})
+		} else {
+			s = append(s, View{Leader: treeRoot, Partitions: []NodeSet{all}})
+		}
+	}
+	result, err := ExecuteScenario(s, 7, 0, 150, "chainedhotstuff", "kauri", "complaintcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe {
+		t.Fatalf("scenario was not safe:\n%s", result.NetworkLog)
+	}
+	if result.Commits == 0 {
+		t.Error("expected the replicas to commit blocks after replica 2 was reconnected")
+	}
+	if !result.SuspicionsAgree {
+		t.Error("expected the replicas to agree on the suspicion state")
+	}
+}
+
+func TestKauriTreeScenarios(t *testing.T) {
+	withoutFaultyNodes(t)
+	const numNodes = 7
+	g := NewGenerator(logging.New(""), Settings{
+		NumNodes: numNodes,
+		Views:    8,
+		Tree:     true,
+	})
+	g.Shuffle(1)
+
+	for i := 0; i < 10; i++ {
+		s, err := g.NextScenario()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := ExecuteScenario(s, numNodes, 0, 150, "chainedhotstuff", "kauri", "complaintcache")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Safe {
+			t.Errorf("scenario was not safe:\n%v", s)
+		}
+		if !result.SuspicionsAgree {
+			t.Errorf("replicas disagree on the suspicion state:\n%v", s)
+		}
+	}
+}
+
+func TestCheckSuspicions(t *testing.T) {
+	withoutFaultyNodes(t)
+	var s Scenario
+	all := NodeSet{1: {}, 2: {}, 3: {}, 4: {}}
+	for v := 0; v < 4; v++ {
+		s = append(s, View{Leader: 1, Partitions: []NodeSet{all}})
+	}
+	network := NewPartitionedNetwork(s)
+	nodes, _ := assignNodeIDs(4, 0)
+	if err := network.createTwinsNodes(nodes, s, "chainedhotstuff", "complaintcache"); err != nil {
+		t.Fatal(err)
+	}
+	network.run(20)
+	if !checkSuspicions(network) {
+		t.Fatal("expected the replicas to agree on the suspicion state")
+	}
+
+	// a replica that commits a complaint that the others have not seen disagrees with them
+	var ranking modules.Ranking
+	network.nodes[2].mods.Get(&ranking)
+	ranking.CommitComplaints([]*hotstuff.Complaint{{
+		Complainee:    2,
+		Complainant:   3,
+		ComplaintType: hotstuff.Suspicion,
+		ID:            1000,
+	}})
+	if checkSuspicions(network) {
+		t.Error("expected the replicas to disagree on the suspicion state")
+	}
+}
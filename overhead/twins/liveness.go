@@ -0,0 +1,81 @@
+package twins
+
+import (
+	"github.com/relab/hotstuff"
+)
+
+// CheckLiveness checks that every correct node committed a new block within the given number of views after the last
+// partition of the scenario healed. It returns the correct nodes that did not. A node is correct if its replica has
+// no twins. The scenario must contain the given number of views after the healed view, since all messages from views
+// past the end of the scenario are dropped; otherwise, liveness cannot be checked and no nodes are returned.
+// The scenario must be executed for enough ticks that the nodes can pass through all of its views.
+func CheckLiveness(scenario Scenario, result ScenarioResult, views int) (stalled []NodeID) {
+	healed := healedView(scenario, len(result.NodeViews))
+	if views <= 0 || int(healed)+views-1 > len(scenario) {
+		return nil
+	}
+	deadline := healed + hotstuff.View(views)
+
+	twins := make(map[hotstuff.ID]int)
+	for id := range result.NodeViews {
+		twins[id.ReplicaID]++
+	}
+
+	for id := range result.NodeViews {
+		if twins[id.ReplicaID] > 1 {
+			continue
+		}
+		committed := false
+		for i, block := range result.NodeCommits[id] {
+			if block.View() >= healed && result.NodeCommitViews[id][i] < deadline {
+				committed = true
+				break
+			}
+		}
+		if !committed {
+			stalled = append(stalled, id)
+		}
+	}
+	return stalled
+}
+
+// Heal returns the scenario followed by the given number of views in which the nodes are not partitioned,
+// such that the liveness of the scenario can be checked by CheckLiveness. The added views are led by the leader of
+// the last view of the scenario, which is a correct replica in generated scenarios, and the root of the tree when
+// Kauri is used.
+func Heal(scenario Scenario, numNodes, numTwins uint8, views int) Scenario {
+	healed := clone(scenario)
+	if len(scenario) == 0 || views <= 0 {
+		return healed
+	}
+	nodes, twins := assignNodeIDs(numNodes, numTwins)
+	all := make(NodeSet)
+	for _, node := range append(nodes, twins...) {
+		all.Add(node.NetworkID)
+	}
+	leader := scenario[len(scenario)-1].Leader
+	for i := 0; i < views; i++ {
+		healed = append(healed, View{Leader: leader, Partitions: []NodeSet{all}})
+	}
+	return healed
+}
+
+// healedView returns the first view after the last view of the scenario in which the nodes were partitioned.
+// A view is not partitioned if one partition contains all the nodes.
+func healedView(scenario Scenario, numNodes int) hotstuff.View {
+	for i := len(scenario) - 1; i >= 0; i-- {
+		if !connected(scenario[i], numNodes) {
+			return hotstuff.View(i + 2)
+		}
+	}
+	return 1
+}
+
+func connected(view View, numNodes int) bool {
+	for _, partition := range view.Partitions {
+		if len(partition) == numNodes {
+			return true
+		}
+	}
+	return false
+}
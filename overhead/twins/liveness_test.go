@@ -0,0 +1,37 @@
+package twins
+
+import (
+	"testing"
+)
+
+func TestCheckLiveness(t *testing.T) {
+	allNodesSet := make(NodeSet)
+	for i := 1; i <= 4; i++ {
+		allNodesSet.Add(uint32(i))
+	}
+	isolated := NodeSet{1: {}, 2: {}, 3: {}}
+	s := Scenario{
+		{Leader: 1, Partitions: []NodeSet{allNodesSet}},
+		{Leader: 1, Partitions: []NodeSet{isolated, {4: {}}}},
+	}
+	if view := healedView(s, 4); view != 3 {
+		t.Fatalf("expected the partition to heal in view 3, got %d", view)
+	}
+
+	healed := Heal(s, 4, 0, 4)
+	result, err := ExecuteScenario(healed, 4, 0, 100, "chainedhotstuff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stalled := CheckLiveness(healed, result, 4); len(stalled) > 0 {
+		t.Errorf("expected all nodes to commit within 4 views of the partition healing, %v did not", stalled)
+	}
+	// a block cannot be committed in fewer than four views after the partition heals
+	if stalled := CheckLiveness(healed, result, 2); len(stalled) != 4 {
+		t.Errorf("expected all nodes to stall within 2 views of the partition healing, got %v", stalled)
+	}
+	// liveness cannot be checked if the scenario ends before the views have passed
+	if stalled := CheckLiveness(s, result, 4); len(stalled) > 0 {
+		t.Errorf("expected liveness not to be checked, got %v", stalled)
+	}
+}
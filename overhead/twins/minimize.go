@@ -0,0 +1,61 @@
+package twins
+
+// Minimize returns the shortest scenario derived from the given scenario for which fails returns true.
+// It first searches for the shortest failing prefix of the scenario, then removes the remaining views one at a time,
+// and finally heals the partitions of each view, keeping each change only if the scenario still fails.
+// The given scenario is returned unchanged if it does not fail.
+func Minimize(scenario Scenario, fails func(Scenario) bool) Scenario {
+	if !fails(scenario) {
+		return scenario
+	}
+
+	for n := 1; n < len(scenario); n++ {
+		if prefix := clone(scenario[:n]); fails(prefix) {
+			scenario = prefix
+			break
+		}
+	}
+
+	for i := 0; i < len(scenario) && len(scenario) > 1; {
+		shorter := clone(scenario[:i])
+		shorter = append(shorter, clone(scenario[i+1:])...)
+		if fails(shorter) {
+			scenario = shorter
+		} else {
+			i++
+		}
+	}
+
+	for i := range scenario {
+		if len(scenario[i].Partitions) <= 1 {
+			continue
+		}
+		healed := clone(scenario)
+		healed[i].Partitions = []NodeSet{merge(scenario[i].Partitions)}
+		if fails(healed) {
+			scenario = healed
+		}
+	}
+
+	return scenario
+}
+
+// clone returns a copy of the scenario that does not share the partition slices of the views.
+func clone(scenario Scenario) Scenario {
+	c := make(Scenario, len(scenario))
+	for i, view := range scenario {
+		c[i] = View{Leader: view.Leader, Partitions: append([]NodeSet(nil), view.Partitions...)}
+	}
+	return c
+}
+
+// merge returns a node set containing the nodes of all the partitions.
+func merge(partitions []NodeSet) NodeSet {
+	all := make(NodeSet)
+	for _, partition := range partitions {
+		for id := range partition {
+			all.Add(id)
+		}
+	}
+	return all
+}
@@ -0,0 +1,41 @@
+package twins
+
+import (
+	"testing"
+)
+
+func TestMinimize(t *testing.T) {
+	allNodesSet := NodeSet{1: {}, 2: {}, 3: {}, 4: {}}
+	partitioned := []NodeSet{{1: {}, 2: {}}, {3: {}, 4: {}}}
+	s := Scenario{
+		{Leader: 1, Partitions: partitioned},
+		{Leader: 2, Partitions: []NodeSet{allNodesSet}},
+		{Leader: 3, Partitions: partitioned},
+		{Leader: 4, Partitions: partitioned},
+		{Leader: 1, Partitions: partitioned},
+	}
+	// the scenario fails if replica 3 leads a view after a view led by replica 2
+	fails := func(s Scenario) bool {
+		led := false
+		for _, view := range s {
+			led = led || view.Leader == 2
+			if led && view.Leader == 3 {
+				return true
+			}
+		}
+		return false
+	}
+
+	m := Minimize(s, fails)
+	if len(m) != 2 || m[0].Leader != 2 || m[1].Leader != 3 {
+		t.Fatalf("expected the views led by 2 and 3, got:\n%v", m)
+	}
+	for i, view := range m {
+		if len(view.Partitions) != 1 || len(view.Partitions[0]) != 4 {
+			t.Errorf("expected view %d to be healed, got:\n%v", i, m)
+		}
+	}
+	if len(s[2].Partitions) != 2 {
+		t.Error("expected the original scenario to be unchanged")
+	}
+}
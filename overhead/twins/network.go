@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
 	"github.com/relab/hotstuff/blockchain"
 	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/ecdsa"
 	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/kauripb"
+	"github.com/relab/hotstuff/kauri"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
 	"github.com/relab/hotstuff/synchronizer"
@@ -41,11 +45,20 @@ type node struct {
 	leaderRotation modules.LeaderRotation
 	synchronizer   modules.Synchronizer
 	opts           *modules.Options
+	mods           *modules.Core
 
 	id             NodeID
 	executedBlocks []*hotstuff.Block
-	effectiveView  hotstuff.View
-	log            strings.Builder
+	// the view that the node was in when it executed each of the executed blocks
+	commitViews   []hotstuff.View
+	effectiveView hotstuff.View
+	log           strings.Builder
+	// the proposals with complaints that the node has handled, which determine its suspicion state
+	complaintProposals map[hotstuff.Hash]struct{}
+	// the proposals that the node has handled in each view, used to detect conflicting proposals
+	proposals map[hotstuff.View]hotstuff.Hash
+	// the number of times that the node took each of the code paths in the coverage
+	paths map[string]int
 }
 
 func (n *node) InitModule(mods *modules.Core) {
@@ -57,13 +70,73 @@ func (n *node) InitModule(mods *modules.Core) {
 		&n.synchronizer,
 		&n.opts,
 	)
+	n.mods = mods
+	n.eventLoop.RegisterObserver(hotstuff.ProposeMsg{}, func(event any) {
+		n.handledProposal(event.(hotstuff.ProposeMsg))
+	})
+	n.eventLoop.RegisterObserver(hotstuff.VoteMsg{}, func(_ any) {
+		n.paths[pathVote]++
+	})
+	n.eventLoop.RegisterObserver(hotstuff.NewViewMsg{}, func(_ any) {
+		n.paths[pathNewView]++
+	})
+	n.eventLoop.RegisterObserver(hotstuff.TimeoutMsg{}, func(_ any) {
+		n.paths[pathTimeoutMsg]++
+	})
+	n.eventLoop.RegisterObserver(synchronizer.TimeoutEvent{}, func(_ any) {
+		n.paths[pathLocalTimeout]++
+	})
+	n.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		if event.(synchronizer.ViewChangeEvent).Timeout {
+			n.paths[pathViewChangeTimeout]++
+		} else {
+			n.paths[pathViewChangeQC]++
+		}
+	})
+	n.eventLoop.RegisterObserver(kauri.ContributionRecvEvent{}, func(_ any) {
+		n.paths[pathContribution]++
+	})
+}
+
+func (n *node) handledProposal(proposal hotstuff.ProposeMsg) {
+	n.paths[pathPropose]++
+	if len(proposal.Block.Complaints()) > 0 {
+		n.complaintProposals[proposal.Block.Hash()] = struct{}{}
+	}
+	view := proposal.Block.View()
+	if hash, ok := n.proposals[view]; ok && hash != proposal.Block.Hash() {
+		n.paths[pathConflictingProposal]++
+	}
+	n.proposals[view] = proposal.Block.Hash()
 }
 
 type pendingMessage struct {
 	message  any
 	receiver uint32
+	tick     int // the tick at which the message is delivered
+}
+
+type pendingTimer struct {
+	callback func()
+	tick     int // the tick at which the callback is called
+	stopped  bool
+}
+
+// stop prevents the callback of the timer from being called.
+func (t *pendingTimer) stop() bool {
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
 }
 
+// tickDuration is the amount of time that a tick represents to modules that wait for some time, such as Kauri.
+const tickDuration = time.Millisecond
+
+// epoch is the time of the first tick of the network's clock.
+var epoch = time.Unix(0, 0)
+
 // Network is a simulated network that supports twins.
 type Network struct {
 	nodes map[uint32]*node
@@ -76,6 +149,13 @@ type Network struct {
 	dropTypes map[reflect.Type]struct{}
 
 	pendingMessages []pendingMessage
+	pendingTimers   []*pendingTimer
+	ticks           int
+	tickDuration    time.Duration
+	// the network IDs of the nodes, in the order in which the nodes were created
+	order []uint32
+	// the latencies and faults of a simulation; nil in twins scenarios
+	sim *simulation
 
 	logger logging.Logger
 	// the destination of the logger
@@ -85,9 +165,10 @@ type Network struct {
 // NewSimpleNetwork creates a simple network.
 func NewSimpleNetwork() *Network {
 	return &Network{
-		nodes:     make(map[uint32]*node),
-		replicas:  make(map[hotstuff.ID][]*node),
-		dropTypes: make(map[reflect.Type]struct{}),
+		nodes:        make(map[uint32]*node),
+		replicas:     make(map[hotstuff.ID][]*node),
+		dropTypes:    make(map[reflect.Type]struct{}),
+		tickDuration: tickDuration,
 	}
 }
 
@@ -95,10 +176,11 @@ func NewSimpleNetwork() *Network {
 // partitions specifies the network partitions for each view.
 func NewPartitionedNetwork(views []View, dropTypes ...any) *Network {
 	n := &Network{
-		nodes:     make(map[uint32]*node),
-		replicas:  make(map[hotstuff.ID][]*node),
-		views:     views,
-		dropTypes: make(map[reflect.Type]struct{}),
+		nodes:        make(map[uint32]*node),
+		replicas:     make(map[hotstuff.ID][]*node),
+		views:        views,
+		dropTypes:    make(map[reflect.Type]struct{}),
+		tickDuration: tickDuration,
 	}
 	n.logger = logging.NewWithDest(&n.log, "network")
 	for _, t := range dropTypes {
@@ -110,9 +192,13 @@ func NewPartitionedNetwork(views []View, dropTypes ...any) *Network {
 // GetNodeBuilder returns a consensus.Builder instance for a node in the network.
 func (n *Network) GetNodeBuilder(id NodeID, pk hotstuff.PrivateKey) modules.Builder {
 	node := node{
-		id: id,
+		id:                 id,
+		complaintProposals: make(map[hotstuff.Hash]struct{}),
+		proposals:          make(map[hotstuff.View]hotstuff.Hash),
+		paths:              make(map[string]int),
 	}
 	n.nodes[id.NetworkID] = &node
+	n.order = append(n.order, id.NetworkID)
 	n.replicas[id.ReplicaID] = append(n.replicas[id.ReplicaID], &node)
 	builder := modules.NewBuilder(id.ReplicaID, pk)
 	// register node as an anonymous module because that allows configuration to obtain it.
@@ -120,8 +206,21 @@ func (n *Network) GetNodeBuilder(id NodeID, pk hotstuff.PrivateKey) modules.Buil
 	return builder
 }
 
-func (n *Network) createTwinsNodes(nodes []NodeID, _ Scenario, consensusName string) error {
+// createTwinsNodes creates the nodes with the given consensus implementation.
+// Additional modules, such as "kauri" and "complaintcache", can be enabled by name.
+func (n *Network) createTwinsNodes(nodes []NodeID, _ Scenario, consensusName string, moduleNames ...string) error {
 	cg := &commandGenerator{}
+	numReplicas := 0
+	for _, nodeID := range nodes {
+		numReplicas = max(numReplicas, int(nodeID.ReplicaID))
+	}
+	timeout := 5
+	for _, name := range moduleNames {
+		if name == "kauri" {
+			// the leader must wait for the contributions of each level of the tree before it times out.
+			timeout += 2 * treeHeight(numReplicas)
+		}
+	}
 	for _, nodeID := range nodes {
 
 		var err error
@@ -137,29 +236,52 @@ func (n *Network) createTwinsNodes(nodes []NodeID, _ Scenario, consensusName str
 		if !ok {
 			return fmt.Errorf("unknown consensus module: '%s'", consensusName)
 		}
+		eventLoop := eventloop.New(100)
+		eventLoop.SetClock(n)
 		builder.Add(
-			eventloop.New(100),
+			eventLoop,
 			blockchain.New(),
 			consensus.New(consensusModule),
 			consensus.NewVotingMachine(),
 			crypto.NewCache(ecdsa.New(), 100),
-			synchronizer.New(FixedTimeout(0)),
+			// the timeouts are counted in ticks by the timeoutManager, so the synchronizer's own timer must never fire
+			synchronizer.New(FixedTimeout(math.MaxInt64)),
 			logging.NewWithDest(&node.log, fmt.Sprintf("r%dn%d", nodeID.ReplicaID, nodeID.NetworkID)),
 			// twins-specific:
 			&configuration{network: n, node: node},
-			&timeoutManager{network: n, node: node, timeout: 5},
+			&timeoutManager{network: n, node: node, timeout: timeout},
 			leaderRotation(n.views),
 			&commandModule{commandGenerator: cg, node: node},
 		)
+		for _, name := range moduleNames {
+			module, ok := modules.GetModuleUntyped(name)
+			if !ok {
+				return fmt.Errorf("unknown module: '%s'", name)
+			}
+			builder.Add(module)
+		}
 		builder.Options().SetShouldVerifyVotesSync()
+		// Kauri waits two ticks for each level of the tree: one for the proposal and one for the contributions.
+		// The tree is fixed, with the replicas in order of their IDs, so that partitions can target its internal nodes.
+		builder.Options().SetTreeDelta(2 * tickDuration)
+		builder.Options().SetTreePositions(treePositions(numReplicas))
 		builder.Build()
 	}
 	return nil
 }
 
 func (n *Network) run(ticks int) {
+	// the nodes are connected from the start
+	for _, id := range n.order {
+		node := n.nodes[id]
+		node.eventLoop.AddEvent(backend.ConnectedEvent{})
+		for node.eventLoop.Tick(context.Background()) { //revive:disable-line:empty-block
+		}
+	}
+
 	// kick off the initial proposal(s)
-	for _, node := range n.nodes {
+	for _, id := range n.order {
+		node := n.nodes[id]
 		if node.leaderRotation.GetLeader(1) == node.id.ReplicaID {
 			node.consensus.Propose(node.synchronizer.(*synchronizer.Synchronizer).SyncInfo())
 		}
@@ -172,22 +294,74 @@ func (n *Network) run(ticks int) {
 
 // tick performs one tick for each node
 func (n *Network) tick() {
+	n.ticks++
+	remainingMessages := n.pendingMessages[:0]
 	for _, msg := range n.pendingMessages {
-		n.nodes[msg.receiver].eventLoop.AddEvent(msg.message)
+		if msg.tick <= n.ticks {
+			n.nodes[msg.receiver].eventLoop.AddEvent(msg.message)
+		} else {
+			remainingMessages = append(remainingMessages, msg)
+		}
 	}
-	n.pendingMessages = nil
+	n.pendingMessages = remainingMessages
 
-	for _, node := range n.nodes {
-		node.eventLoop.AddEvent(tick{})
+	// the callbacks may start new timers, which are appended to pendingTimers
+	timers := n.pendingTimers
+	n.pendingTimers = nil
+	for _, timer := range timers {
+		if timer.stopped {
+			continue
+		}
+		if timer.tick <= n.ticks {
+			timer.stopped = true
+			timer.callback()
+		} else {
+			n.pendingTimers = append(n.pendingTimers, timer)
+		}
+	}
+
+	for _, id := range n.order {
+		node := n.nodes[id]
+		if n.sim == nil {
+			node.eventLoop.AddEvent(tick{})
+		}
 		// process all events in the node's event queue
 		for node.eventLoop.Tick(context.Background()) { //revive:disable-line:empty-block
 		}
 	}
 }
 
+// Now returns the time of the network's clock, which advances by the tick duration in each tick.
+func (n *Network) Now() time.Time {
+	return epoch.Add(time.Duration(n.ticks) * n.tickDuration)
+}
+
+// AfterFunc calls f in the first tick that is at least the duration d from now.
+// The network is the clock of the nodes' event loops, so that their timers count ticks.
+func (n *Network) AfterFunc(d time.Duration, f func()) (stop func() bool) {
+	timer := &pendingTimer{callback: f, tick: n.ticks + n.durationTicks(d)}
+
+	n.pendingTimers = append(n.pendingTimers, timer)
+	return timer.stop
+}
+
+// durationTicks returns the number of ticks, at least one, that cover the duration d.
+func (n *Network) durationTicks(d time.Duration) int {
+	ticks := d / n.tickDuration
+	if d%n.tickDuration != 0 {
+		ticks++
+	}
+	return max(int(ticks), 1)
+}
+
+var _ eventloop.Clock = (*Network)(nil)
+
 // shouldDrop decides if the sender should drop the message, based on the current view of the sender and the
 // partitions configured for that view.
 func (n *Network) shouldDrop(sender, receiver uint32, message any) bool {
+	if n.sim != nil {
+		return n.sim.isolated(sender, receiver, time.Duration(n.ticks)*n.tickDuration)
+	}
 	node, ok := n.nodes[sender]
 	if !ok {
 		panic(fmt.Errorf("node matching sender id %d was not found", sender))
@@ -231,6 +405,7 @@ type configuration struct {
 	node      *node
 	network   *Network
 	subConfig hotstuff.IDSet
+	ranking   modules.Ranking
 }
 
 // alternative way to get a pointer to the node.
@@ -238,10 +413,38 @@ func (c *configuration) InitModule(mods *modules.Core) {
 	if c.node == nil {
 		mods.TryGet(&c.node)
 	}
+	if c.network.sim != nil && mods.TryGet(&c.ranking) {
+		var eventLoop *eventloop.EventLoop
+		mods.Get(&eventLoop)
+		eventLoop.RegisterHandler(hotstuff.CheckLatencyVector{}, func(event any) {
+			c.checkLatencyVector(event.(hotstuff.CheckLatencyVector))
+		})
+	}
+}
+
+// checkLatencyVector files a suspicion against the replicas whose latency to the proposer, as reported in the
+// latency vector, is more than three times the simulated latency, like the backend does in a real run.
+func (c *configuration) checkLatencyVector(event hotstuff.CheckLatencyVector) {
+	for _, entry := range event.LatencyVector {
+		id := hotstuff.ID(entry >> 24)
+		reported := int64(entry & 0x00FFFFFF)
+		expected := c.network.sim.linkLatency(event.Proposer, id).Microseconds()
+		if expected*3 < reported {
+			c.ranking.AddComplaint(&hotstuff.Complaint{
+				Complainee:    id,
+				Complainant:   event.Proposer,
+				ComplaintType: hotstuff.Suspicion,
+			})
+		}
+	}
 }
 
+// GetLatency returns the simulated latency between the replicas, or 0 in twins scenarios.
 func (c *configuration) GetLatency(sender hotstuff.ID, receiver hotstuff.ID) time.Duration {
-	return time.Now().Sub(time.Now())
+	if c.network.sim != nil {
+		return c.network.sim.linkLatency(sender, receiver)
+	}
+	return 0
 }
 func (c *configuration) broadcastMessage(message any) {
 	for id := range c.network.replicas {
@@ -260,8 +463,13 @@ func (c *configuration) sendMessage(id hotstuff.ID, message any) {
 		panic(fmt.Errorf("attempt to send message to replica %d, but this replica does not exist", id))
 	}
 	for _, node := range nodes {
+		if c.network.sim != nil {
+			c.network.simulateMessage(c.node.id.NetworkID, node.id.NetworkID, message)
+			continue
+		}
 		if c.shouldDrop(node.id, message) {
 			c.network.logger.Infof("node %v -> node %v: DROP %T(%v)", c.node.id, node.id, message, message)
+			c.node.paths[pathDrop]++
 			continue
 		}
 		c.network.logger.Infof("node %v -> node %v: SEND %T(%v)", c.node.id, node.id, message, message)
@@ -270,6 +478,7 @@ func (c *configuration) sendMessage(id hotstuff.ID, message any) {
 			pendingMessage{
 				receiver: uint32(node.id.NetworkID),
 				message:  message,
+				tick:     c.network.ticks + 1,
 			},
 		)
 	}
@@ -304,8 +513,16 @@ func (c *configuration) Replica(id hotstuff.ID) (r modules.Replica, ok bool) {
 	return nil, false
 }
 
-func (c *configuration) GetCommittees(size int, isPhase bool) map[int][]hotstuff.ID {
-	return make(map[int][]hotstuff.ID)
+// GetCommittees returns committees of the given size, numbered from 1, with the replicas in order of their IDs.
+// All replicas have the same latency in the twins network, so there is no need to group nearby replicas.
+func (c *configuration) GetCommittees(size int, _ bool) map[int][]hotstuff.ID {
+	ids := maps.Keys(c.network.replicas)
+	slices.Sort(ids)
+	committees := make(map[int][]hotstuff.ID)
+	for i := 0; size > 0 && (i+1)*size <= len(ids); i++ {
+		committees[i+1] = ids[i*size : (i+1)*size]
+	}
+	return committees
 }
 
 // SubConfig returns a subconfiguration containing the replicas specified in the ids slice.
@@ -337,6 +554,8 @@ func (c *configuration) QuorumSize(view hotstuff.View) int {
 
 // Propose sends the block to all replicas in the configuration.
 func (c *configuration) Propose(proposal hotstuff.ProposeMsg) {
+	// the sender has handled the proposal itself, either as the leader or as a Kauri parent
+	c.node.handledProposal(proposal)
 	c.broadcastMessage(proposal)
 }
 
@@ -354,13 +573,50 @@ func (c *configuration) Fetch(_ context.Context, hash hotstuff.Hash) (block *hot
 			}
 			block, ok = node.blockChain.LocalGet(hash)
 			if ok {
+				c.node.paths[pathFetch]++
 				return block, true
 			}
 		}
 	}
+	c.node.paths[pathFetchFailed]++
 	return nil, false
 }
 
+// Checkpoint sends the signed checkpoint to all replicas.
+func (c *configuration) Checkpoint(msg hotstuff.CheckpointMsg) {
+	c.broadcastMessage(msg)
+}
+
+// FetchCheckpoint requests a stable checkpoint whose height is at least the given height from all the replicas.
+// The replicas are asked in ID order, such that the result does not depend on the order of the map.
+func (c *configuration) FetchCheckpoint(_ context.Context, height uint64) (snapshot hotstuff.CheckpointSnapshot, ok bool) {
+	ids := maps.Keys(c.network.replicas)
+	slices.Sort(ids)
+	for _, id := range ids {
+		for _, node := range c.network.replicas[id] {
+			checkpointer, isCheckpointer := node.synchronizer.(modules.Checkpointer)
+			if node == c.node || !isCheckpointer || c.shouldDrop(node.id, height) {
+				continue
+			}
+			snapshot, ok = checkpointer.StableCheckpoint()
+			if ok && snapshot.Cert.Checkpoint.Height >= height {
+				return snapshot, true
+			}
+		}
+	}
+	return hotstuff.CheckpointSnapshot{}, false
+}
+
+// SendContribution sends Kauri's contribution to the replica with the given ID.
+func (c *configuration) SendContribution(id hotstuff.ID, contribution *kauripb.Contribution) {
+	c.sendMessage(id, kauri.ContributionRecvEvent{Contribution: contribution})
+}
+
+var (
+	_ kauri.Network                   = (*configuration)(nil)
+	_ modules.CheckpointConfiguration = (*configuration)(nil)
+)
+
 type replica struct {
 	// pointer to the node that wants to contact this replica.
 	config *configuration
@@ -451,9 +707,15 @@ type timeoutManager struct {
 	network   *Network
 	countdown int
 	timeout   int
+	view      hotstuff.View // the view that the countdown was started in
 }
 
 func (tm *timeoutManager) advance() {
+	// the view may have changed in this tick, before the view change event was handled
+	if view := tm.synchronizer.View(); view > tm.view {
+		tm.view = view
+		tm.countdown = tm.timeout
+	}
 	tm.countdown--
 	if tm.countdown == 0 {
 		view := tm.synchronizer.View()
@@ -467,6 +729,7 @@ func (tm *timeoutManager) advance() {
 }
 
 func (tm *timeoutManager) viewChange(event synchronizer.ViewChangeEvent) {
+	tm.view = event.View
 	tm.countdown = tm.timeout
 	if event.Timeout {
 		tm.network.logger.Infof("node %v entered view %d after timeout", tm.node.id, event.View)
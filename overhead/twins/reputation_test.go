@@ -0,0 +1,81 @@
+package twins
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/modules"
+)
+
+// TestReputationOldViews checks that the reputation-based leader rotation returns the same
+// leaders on all replicas for every committed view, regardless of the order of the lookups.
+func TestReputationOldViews(t *testing.T) {
+	const numNodes = 4
+	allNodesSet := make(NodeSet)
+	for i := 1; i <= numNodes; i++ {
+		allNodesSet.Add(uint32(i))
+	}
+	var s Scenario
+	for i := 0; i < 12; i++ {
+		s = append(s, View{Leader: hotstuff.ID(i%numNodes + 1), Partitions: []NodeSet{allNodesSet}})
+	}
+	network := NewPartitionedNetwork(s)
+	nodes, _ := assignNodeIDs(numNodes, 0)
+	if err := network.createTwinsNodes(nodes, s, "chainedhotstuff"); err != nil {
+		t.Fatal(err)
+	}
+	network.run(200)
+
+	lastView := hotstuff.View(0)
+	for _, node := range network.nodes {
+		if v := node.consensus.CommittedBlock().View(); lastView == 0 || v < lastView {
+			lastView = v
+		}
+	}
+	if lastView < 5 {
+		t.Fatalf("expected replicas to commit beyond view 5, got %d", lastView)
+	}
+	lastView += hotstuff.View(network.nodes[1].consensus.ChainLength())
+
+	newRepBased := func(n *node) modules.LeaderRotation {
+		lr := leaderrotation.NewRepBased()
+		lr.(modules.Module).InitModule(n.mods)
+		return lr
+	}
+
+	var want []hotstuff.ID
+	for _, n := range network.nodes {
+		forward := newRepBased(n)
+		got := make([]hotstuff.ID, lastView+1)
+		for v := hotstuff.View(1); v <= lastView; v++ {
+			got[v] = forward.GetLeader(v)
+			if got[v] == 0 {
+				t.Errorf("%v: GetLeader(%d) = 0", n.id, v)
+			}
+		}
+		if want == nil {
+			want = got
+		}
+		for v := hotstuff.View(1); v <= lastView; v++ {
+			if got[v] != want[v] {
+				t.Errorf("%v: GetLeader(%d) = %d, want %d", n.id, v, got[v], want[v])
+			}
+		}
+
+		// a fresh instance asked about the views in reverse order, concurrently, must agree.
+		backward := newRepBased(n)
+		var wg sync.WaitGroup
+		for v := lastView; v >= 1; v-- {
+			wg.Add(1)
+			go func(v hotstuff.View) {
+				defer wg.Done()
+				if leader := backward.GetLeader(v); leader != want[v] {
+					t.Errorf("%v: GetLeader(%d) = %d, want %d", n.id, v, leader, want[v])
+				}
+			}(v)
+		}
+		wg.Wait()
+	}
+}
@@ -2,12 +2,19 @@ package twins
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/kauri"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
 )
 
 // View specifies the leader id and the partition scenario for a single view.
@@ -38,29 +45,41 @@ func (s Scenario) String() string {
 
 // ScenarioResult contains the result and logs from executing a scenario.
 type ScenarioResult struct {
-	Safe        bool
-	Commits     int
-	NetworkLog  string
-	NodeLogs    map[NodeID]string
-	NodeCommits map[NodeID][]*hotstuff.Block
+	Safe    bool
+	Commits int
+	// SuspicionsAgree is true if the replicas that handled the same proposals with complaints
+	// have the same suspicion matrix. It is always true if no ranking module is enabled.
+	SuspicionsAgree bool
+	NetworkLog      string
+	NodeLogs        map[NodeID]string
+	NodeCommits     map[NodeID][]*hotstuff.Block
+	// NodeCommitViews contains the view that each node was in when it committed each of its NodeCommits.
+	NodeCommitViews map[NodeID][]hotstuff.View
+	// NodeViews contains the view of each node at the end of the scenario.
+	NodeViews map[NodeID]hotstuff.View
+	// Paths counts the number of times that the nodes took each consensus code path, such as votes,
+	// timeouts and fetches. See Coverage.
+	Paths map[string]int
 }
 
 // ExecuteScenario executes a twins scenario.
-func ExecuteScenario(scenario Scenario, numNodes, numTwins uint8, numTicks int, consensusName string) (result ScenarioResult, err error) {
-	// Network simulator that blocks proposals, votes, and fetch requests between nodes that are in different partitions.
-	// Timeout and NewView messages are permitted.
+// Additional modules, such as "kauri" and "complaintcache", can be enabled on all nodes by name.
+func ExecuteScenario(scenario Scenario, numNodes, numTwins uint8, numTicks int, consensusName string, moduleNames ...string) (result ScenarioResult, err error) {
+	// Network simulator that blocks proposals, votes, Kauri contributions, and fetch requests between nodes
+	// that are in different partitions.
 	network := NewPartitionedNetwork(scenario,
 		hotstuff.ProposeMsg{},
 		hotstuff.VoteMsg{},
 		hotstuff.Hash{},
 		hotstuff.NewViewMsg{},
 		hotstuff.TimeoutMsg{},
+		kauri.ContributionRecvEvent{},
 	)
 
 	nodes, twins := assignNodeIDs(numNodes, numTwins)
 	nodes = append(nodes, twins...)
 
-	err = network.createTwinsNodes(nodes, scenario, consensusName)
+	err = network.createTwinsNodes(nodes, scenario, consensusName, moduleNames...)
 	if err != nil {
 		return ScenarioResult{}, err
 	}
@@ -68,22 +87,63 @@ func ExecuteScenario(scenario Scenario, numNodes, numTwins uint8, numTicks int,
 	network.run(numTicks)
 
 	nodeLogs := make(map[NodeID]string)
+	commitViews := make(map[NodeID][]hotstuff.View)
+	views := make(map[NodeID]hotstuff.View)
+	paths := make(map[string]int)
 	for _, node := range network.nodes {
 		nodeLogs[node.id] = node.log.String()
+		commitViews[node.id] = node.commitViews
+		views[node.id] = node.synchronizer.View()
+		for path, count := range node.paths {
+			paths[path] += count
+		}
 	}
 
 	// check if the majority of replicas have committed the same blocks
 	safe, commits := checkCommits(network)
 
 	return ScenarioResult{
-		Safe:        safe,
-		Commits:     commits,
-		NetworkLog:  network.log.String(),
-		NodeLogs:    nodeLogs,
-		NodeCommits: getBlocks(network),
+		Safe:            safe,
+		Commits:         commits,
+		SuspicionsAgree: checkSuspicions(network),
+		NetworkLog:      network.log.String(),
+		NodeLogs:        nodeLogs,
+		NodeCommits:     getBlocks(network),
+		NodeCommitViews: commitViews,
+		NodeViews:       views,
+		Paths:           paths,
 	}, nil
 }
 
+// checkSuspicions checks that the replicas agree on the suspicion state.
+// The complaints are part of the proposals, and not of the blocks,
+// so only the replicas that handled the same proposals with complaints are compared.
+func checkSuspicions(network *Network) bool {
+	matrices := make(map[string]map[hotstuff.ID]map[hotstuff.ID]int)
+	for _, replica := range network.replicas {
+		if len(replica) != 1 {
+			continue
+		}
+		var ranking modules.Ranking
+		if !replica[0].mods.TryGet(&ranking) {
+			return true
+		}
+		hashes := make([]string, 0, len(replica[0].complaintProposals))
+		for hash := range replica[0].complaintProposals {
+			hashes = append(hashes, string(hash[:]))
+		}
+		sort.Strings(hashes)
+		key := strings.Join(hashes, "")
+
+		matrix := ranking.GetSuspicionMatrix()
+		if other, ok := matrices[key]; ok && !reflect.DeepEqual(matrix, other) {
+			return false
+		}
+		matrices[key] = matrix
+	}
+	return true
+}
+
 func checkCommits(network *Network) (safe bool, commits int) {
 	i := 0
 	for {
@@ -154,6 +214,7 @@ func (cg *commandGenerator) next() hotstuff.Command {
 type commandModule struct {
 	commandGenerator *commandGenerator
 	node             *node
+	batchSize        int // the number of commands in each block, for the throughput metric; 0 in twins scenarios
 }
 
 // Accept returns true if the replica should accept the command, false otherwise.
@@ -175,6 +236,52 @@ func (cm commandModule) Get(_ context.Context) (cmd hotstuff.Command, ok bool) {
 // Exec executes the given command.
 func (cm commandModule) Exec(block *hotstuff.Block) {
 	cm.node.executedBlocks = append(cm.node.executedBlocks, block)
+	cm.node.commitViews = append(cm.node.commitViews, cm.node.synchronizer.View())
+	cm.node.paths[pathCommit]++
+	if cm.batchSize > 0 {
+		cm.node.eventLoop.AddEvent(hotstuff.CommitEvent{Commands: cm.batchSize})
+	}
+}
+
+func (cm commandModule) Fork(_ *hotstuff.Block) {
+	cm.node.paths[pathFork]++
+}
+
+// ExecutionSnapshot returns the blocks that the node has executed, which are the state of the node's executor.
+func (cm commandModule) ExecutionSnapshot() []byte {
+	var b []byte
+	for _, block := range cm.node.executedBlocks {
+		// a block with a valid timestamp can always be marshaled
+		pb, _ := proto.Marshal(hotstuffpb.BlockToProto(block))
+		b = binary.AppendUvarint(b, uint64(len(pb)))
+		b = append(b, pb...)
+	}
+	return b
+}
+
+// RestoreExecution replaces the blocks that the node has executed with those of the snapshot,
+// such that the safety check compares the node's executed blocks with those of the other nodes.
+func (cm commandModule) RestoreExecution(snapshot []byte) error {
+	var blocks []*hotstuff.Block
+	for len(snapshot) > 0 {
+		n, size := binary.Uvarint(snapshot)
+		if size <= 0 || n > uint64(len(snapshot)-size) {
+			return fmt.Errorf("invalid execution snapshot")
+		}
+		pb := new(hotstuffpb.Block)
+		if err := proto.Unmarshal(snapshot[size:size+int(n)], pb); err != nil {
+			return err
+		}
+		blocks = append(blocks, hotstuffpb.BlockFromProto(pb))
+		snapshot = snapshot[size+int(n):]
+	}
+	view := cm.node.synchronizer.View()
+	cm.node.executedBlocks = blocks
+	cm.node.commitViews = cm.node.commitViews[:0]
+	for range blocks {
+		cm.node.commitViews = append(cm.node.commitViews, view)
+	}
+	return nil
 }
 
-func (commandModule) Fork(_ *hotstuff.Block) {}
+var _ modules.ExecutionState = commandModule{}
@@ -2,7 +2,9 @@ package twins
 
 import (
 	"testing"
+	"time"
 
+	"github.com/relab/hotstuff"
 	_ "github.com/relab/hotstuff/consensus/chainedhotstuff"
 )
 
@@ -30,3 +32,57 @@ func TestBasicScenario(t *testing.T) {
 		t.Error("Expected one commit")
 	}
 }
+
+// TestRogueProposal checks that replicas ignore a proposal from a replica that is not the leader of the view.
+func TestRogueProposal(t *testing.T) {
+	const rogue = hotstuff.ID(2)
+	allNodesSet := make(NodeSet)
+	for i := 1; i <= 4; i++ {
+		allNodesSet.Add(uint32(i))
+	}
+	var s Scenario
+	for i := 0; i < 6; i++ {
+		s = append(s, View{Leader: 1, Partitions: []NodeSet{allNodesSet}})
+	}
+	network := NewPartitionedNetwork(s)
+	nodes, _ := assignNodeIDs(4, 0)
+	if err := network.createTwinsNodes(nodes, s, "chainedhotstuff"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the rogue proposal for view 1 is delivered before the leader's proposal.
+	genesis := hotstuff.GetGenesis()
+	proposal := hotstuff.ProposeMsg{
+		ID: rogue,
+		Block: hotstuff.NewBlock(
+			genesis.Hash(),
+			hotstuff.NewQuorumCert(nil, 0, genesis.Hash(), nil),
+			"rogue",
+			1,
+			rogue,
+			time.Now(),
+		),
+	}
+	for _, node := range network.nodes {
+		if node.id.ReplicaID != rogue {
+			network.pendingMessages = append(network.pendingMessages,
+				pendingMessage{receiver: node.id.NetworkID, message: proposal})
+		}
+	}
+	network.run(100)
+
+	safe, commits := checkCommits(network)
+	if !safe {
+		t.Errorf("Expected no safety violations")
+	}
+	if commits == 0 {
+		t.Errorf("Expected the leader's proposals to be committed")
+	}
+	for _, node := range network.nodes {
+		for _, block := range node.executedBlocks {
+			if block.Proposer() == rogue {
+				t.Errorf("%v executed rogue block %v", node.id, block)
+			}
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package twins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/byzantine"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/bls12"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/kauri"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/metrics"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+// SimulationConfig contains the settings of a simulation.
+type SimulationConfig struct {
+	Replicas       int
+	Consensus      string   // defaults to chainedhotstuff
+	Crypto         string   // ecdsa (the default) or bls12
+	LeaderRotation string   // defaults to round-robin
+	Modules        []string // additional modules, such as kauri
+	BatchSize      int      // the number of commands that each block counts as in the throughput metric; defaults to 1
+	// Byzantine maps the IDs of byzantine replicas to the names of their strategies, such as silence or forgelatency.
+	Byzantine map[hotstuff.ID]string
+
+	ViewTimeout       time.Duration // duration of the first view; derived from the latencies if 0
+	MaxTimeout        time.Duration // upper limit on view timeouts
+	TimeoutSamples    uint64        // number of previous views to consider when predicting view duration; defaults to 1000
+	TimeoutMultiplier float64       // defaults to 1.2
+	TreeDelta         time.Duration // time that kauri waits for each level of the tree; derived from the latencies if 0
+	TreePositions     []hotstuff.ID // replica IDs in tree position order; chosen by kauri if empty
+	LeafPlacement     string        // algorithm that places the leaves of the kauri tree; chosen by the tree optimizer if empty
+	// CheckpointInterval is the number of committed blocks between checkpoints; checkpoints are disabled if 0.
+	CheckpointInterval uint64
+
+	// Locations contains the location of each replica, in ID order, which determines the latencies between them.
+	// If there are fewer locations than replicas, the locations are reused in order.
+	// If there are no locations, the latency between all replicas is Latency.
+	Locations []string
+	Latency   time.Duration
+	Jitter    time.Duration // the maximum random delay added to each message
+	Faults    []Fault
+
+	Duration   time.Duration // the virtual duration of the simulation
+	Resolution time.Duration // the duration of a tick of the virtual clock; defaults to one microsecond
+	Seed       int64         // seed of the jitter and message drops, and the shared random seed of the replicas
+
+	Metrics             []string       // replica metrics to enable
+	MeasurementInterval time.Duration  // metrics are disabled if 0
+	MetricsLogger       metrics.Logger // receives the measurements; discarded if nil
+	Logs                io.Writer      // receives the logs of the replicas; discarded if nil
+}
+
+// Fault is injected into a simulation from Start until End, or the end of the simulation if End is 0.
+// The fault affects the given replica, or all replicas if Replica is 0.
+type Fault struct {
+	Replica  hotstuff.ID
+	Start    time.Duration
+	End      time.Duration
+	Crash    bool          // the replica neither sends nor receives messages
+	DropRate float64       // the fraction of the replica's messages that are dropped
+	Delay    time.Duration // added to the latency of the replica's messages
+}
+
+func (f Fault) activeAt(t time.Duration) bool {
+	return t >= f.Start && (f.End == 0 || t < f.End)
+}
+
+func (f Fault) affects(id uint32) bool {
+	return f.Replica == 0 || uint32(f.Replica) == id
+}
+
+// SimulationResult contains the outcome of a simulation.
+type SimulationResult struct {
+	Safe     bool                          // all replicas committed the same blocks
+	Commits  int                           // the number of blocks committed by any replica
+	Executed map[hotstuff.ID]int           // the number of blocks committed by each replica
+	Views    map[hotstuff.ID]hotstuff.View // the view of each replica at the end of the simulation
+	Messages int                           // the number of messages sent
+	Dropped  int                           // the number of messages dropped by faults
+	// Suspicions contains the suspicion matrix of each replica, if the ranking module is enabled.
+	Suspicions map[hotstuff.ID]map[hotstuff.ID]map[hotstuff.ID]int
+	// Checkpoints contains the latest stable checkpoint of each replica, if checkpoints are enabled.
+	Checkpoints map[hotstuff.ID]hotstuff.CheckpointCert
+}
+
+// simulation determines the latencies and faults of the messages in a simulated network.
+type simulation struct {
+	seed      uint64
+	locations []string
+	latency   time.Duration
+	jitter    time.Duration
+	faults    []Fault
+	sent      map[[2]uint32]uint64 // the number of messages sent on each link
+	messages  int
+	dropped   int
+}
+
+func newSimulation(cfg SimulationConfig) (*simulation, error) {
+	s := &simulation{
+		seed:    uint64(cfg.Seed),
+		latency: cfg.Latency,
+		jitter:  cfg.Jitter,
+		faults:  cfg.Faults,
+		sent:    make(map[[2]uint32]uint64),
+	}
+	if len(cfg.Locations) > 0 {
+		s.locations = make([]string, cfg.Replicas)
+		for i := range s.locations {
+			s.locations[i] = cfg.Locations[i%len(cfg.Locations)]
+		}
+		for _, a := range s.locations {
+			for _, b := range s.locations {
+				if _, ok := backend.LocationLatency(a, b); !ok {
+					return nil, fmt.Errorf("unknown latency between '%s' and '%s'", a, b)
+				}
+			}
+		}
+	}
+	for _, f := range s.faults {
+		if f.DropRate < 0 || f.DropRate > 1 {
+			return nil, fmt.Errorf("invalid drop rate %v", f.DropRate)
+		}
+		if int(f.Replica) > cfg.Replicas {
+			return nil, fmt.Errorf("fault of unknown replica %d", f.Replica)
+		}
+	}
+	return s, nil
+}
+
+// linkLatency returns the latency of messages from the sender to the receiver.
+func (s *simulation) linkLatency(sender, receiver hotstuff.ID) time.Duration {
+	if s.locations == nil {
+		return s.latency
+	}
+	latency, _ := backend.LocationLatency(s.locations[sender-1], s.locations[receiver-1])
+	return latency
+}
+
+// maxLatency returns the highest latency between any two replicas.
+func (s *simulation) maxLatency(replicas int) (latency time.Duration) {
+	for a := 1; a <= replicas; a++ {
+		for b := 1; b <= replicas; b++ {
+			latency = max(latency, s.linkLatency(hotstuff.ID(a), hotstuff.ID(b)))
+		}
+	}
+	return latency
+}
+
+// isolated returns true if the sender or receiver has crashed at time t.
+func (s *simulation) isolated(sender, receiver uint32, t time.Duration) bool {
+	for _, f := range s.faults {
+		if f.Crash && f.activeAt(t) && (f.affects(sender) || f.affects(receiver)) {
+			return true
+		}
+	}
+	return false
+}
+
+// random returns a number in [0, 1) that is determined by the seed, the link, the number of the message on the link,
+// and the purpose of the number. This makes the simulation deterministic even if the replicas send their messages
+// to different receivers in a different order, such as when iterating over a map.
+func (s *simulation) random(sender, receiver uint32, n uint64, purpose int) float64 {
+	x := s.seed ^ uint64(sender)<<48 ^ uint64(receiver)<<32 ^ n<<8 ^ uint64(purpose)
+	// splitmix64
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x>>11) / (1 << 53)
+}
+
+// simulateMessage delivers the message to the receiver after the latency between them, unless a fault drops it.
+func (n *Network) simulateMessage(sender, receiver uint32, message any) {
+	s := n.sim
+	link := [2]uint32{sender, receiver}
+	count := s.sent[link]
+	s.sent[link]++
+	s.messages++
+
+	now := time.Duration(n.ticks) * n.tickDuration
+	if s.isolated(sender, receiver, now) {
+		s.dropped++
+		return
+	}
+	delay := s.linkLatency(hotstuff.ID(sender), hotstuff.ID(receiver))
+	if s.jitter > 0 {
+		delay += time.Duration(s.random(sender, receiver, count, 0) * float64(s.jitter))
+	}
+	for i, f := range s.faults {
+		if !f.activeAt(now) || !f.affects(sender) {
+			continue
+		}
+		if f.DropRate > 0 && s.random(sender, receiver, count, i+1) < f.DropRate {
+			s.dropped++
+			return
+		}
+		delay += f.Delay
+	}
+	n.pendingMessages = append(n.pendingMessages, pendingMessage{
+		receiver: receiver,
+		message:  message,
+		tick:     n.ticks + n.durationTicks(delay),
+	})
+}
+
+// Simulate runs the replicas in a simulated network under virtual time. The messages are delayed by the latencies
+// between the locations of the replicas, and the timers of the replicas, such as the view timeouts,
+// expire according to the virtual clock. Thus, a simulation of many replicas finishes much faster than a real run,
+// and gives the same result with the same seed. The replicas log the same metrics as in a real run.
+func Simulate(cfg SimulationConfig) (result SimulationResult, err error) {
+	if cfg.Replicas < 1 {
+		return result, fmt.Errorf("a simulation needs at least one replica")
+	}
+	if cfg.Duration <= 0 {
+		return result, fmt.Errorf("the duration of the simulation must be positive")
+	}
+	if cfg.LeafPlacement != "" && !kauri.IsLeafPlacement(cfg.LeafPlacement) {
+		return result, fmt.Errorf("unknown leaf placement: '%s'", cfg.LeafPlacement)
+	}
+	setSimulationDefaults(&cfg)
+
+	sim, err := newSimulation(cfg)
+	if err != nil {
+		return result, err
+	}
+	network := &Network{
+		nodes:        make(map[uint32]*node),
+		replicas:     make(map[hotstuff.ID][]*node),
+		dropTypes:    make(map[reflect.Type]struct{}),
+		tickDuration: cfg.Resolution,
+		sim:          sim,
+	}
+	network.logger = logging.NewWithDest(&network.log, "network")
+
+	maxLatency := sim.maxLatency(cfg.Replicas)
+	if cfg.TreeDelta == 0 {
+		// an internal node must wait for the votes of its children, which take a round trip
+		cfg.TreeDelta = 2*maxLatency + 10*time.Millisecond
+	}
+	if cfg.ViewTimeout == 0 {
+		// the proposal and the votes take a round trip, and kauri waits for each level of the tree in the worst case
+		cfg.ViewTimeout = 4*maxLatency + 100*time.Millisecond
+		if slices.Contains(cfg.Modules, "kauri") {
+			cfg.ViewTimeout += time.Duration(treeHeight(cfg.Replicas)) * cfg.TreeDelta
+		}
+	}
+
+	if err := network.createSimulatedNodes(cfg); err != nil {
+		return result, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, id := range network.order {
+		node := network.nodes[id]
+		node.eventLoop.AddEvent(backend.ConnectedEvent{})
+		for node.eventLoop.Tick(ctx) { //revive:disable-line:empty-block
+		}
+	}
+	for _, id := range network.order {
+		node := network.nodes[id]
+		cfg.MetricsLogger.Log(&types.StartEvent{Event: types.NewReplicaEvent(id, network.Now())})
+		node.synchronizer.Start(ctx)
+		for node.eventLoop.Tick(ctx) { //revive:disable-line:empty-block
+		}
+	}
+	network.simulate(cfg.Duration)
+
+	result.Safe, result.Commits = checkCommits(network)
+	result.Executed = make(map[hotstuff.ID]int)
+	result.Views = make(map[hotstuff.ID]hotstuff.View)
+	for _, node := range network.nodes {
+		result.Executed[node.id.ReplicaID] = len(node.executedBlocks)
+		result.Views[node.id.ReplicaID] = node.synchronizer.View()
+		var ranking modules.Ranking
+		if node.mods.TryGet(&ranking) {
+			if result.Suspicions == nil {
+				result.Suspicions = make(map[hotstuff.ID]map[hotstuff.ID]map[hotstuff.ID]int)
+			}
+			result.Suspicions[node.id.ReplicaID] = ranking.GetSuspicionMatrix()
+		}
+		if checkpointer, ok := node.synchronizer.(modules.Checkpointer); ok {
+			if snapshot, ok := checkpointer.StableCheckpoint(); ok {
+				if result.Checkpoints == nil {
+					result.Checkpoints = make(map[hotstuff.ID]hotstuff.CheckpointCert)
+				}
+				result.Checkpoints[node.id.ReplicaID] = snapshot.Cert
+			}
+		}
+	}
+	result.Messages = sim.messages
+	result.Dropped = sim.dropped
+	return result, nil
+}
+
+func setSimulationDefaults(cfg *SimulationConfig) {
+	if cfg.Consensus == "" {
+		cfg.Consensus = "chainedhotstuff"
+	}
+	if cfg.Crypto == "" {
+		cfg.Crypto = "ecdsa"
+	}
+	if cfg.LeaderRotation == "" {
+		cfg.LeaderRotation = "round-robin"
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1
+	}
+	if cfg.TimeoutSamples == 0 {
+		cfg.TimeoutSamples = 1000
+	}
+	if cfg.TimeoutMultiplier == 0 {
+		cfg.TimeoutMultiplier = 1.2
+	}
+	if cfg.Resolution <= 0 {
+		cfg.Resolution = time.Microsecond
+	}
+	if cfg.MetricsLogger == nil {
+		cfg.MetricsLogger = metrics.NopLogger()
+	}
+	if cfg.Logs == nil {
+		cfg.Logs = io.Discard
+	}
+}
+
+// createSimulatedNodes creates a node for each replica, with the same modules as a replica in a real run,
+// except that the network delivers the messages and is the clock of the event loops.
+func (n *Network) createSimulatedNodes(cfg SimulationConfig) error {
+	cg := &commandGenerator{}
+	for i := 1; i <= cfg.Replicas; i++ {
+		id := hotstuff.ID(i)
+		var pk hotstuff.PrivateKey
+		var err error
+		switch cfg.Crypto {
+		case "ecdsa":
+			pk, err = keygen.GenerateECDSAPrivateKey()
+		case "bls12":
+			pk, err = bls12.GeneratePrivateKey()
+		default:
+			return fmt.Errorf("unknown crypto implementation: '%s'", cfg.Crypto)
+		}
+		if err != nil {
+			return err
+		}
+		builder := n.GetNodeBuilder(NodeID{ReplicaID: id, NetworkID: uint32(id)}, pk)
+		node := n.nodes[uint32(id)]
+
+		consensusRules, ok := modules.GetModule[consensus.Rules](cfg.Consensus)
+		if !ok {
+			return fmt.Errorf("unknown consensus module: '%s'", cfg.Consensus)
+		}
+		var byzantineModules []any
+		if strategy := cfg.Byzantine[id]; strategy != "" {
+			byz, ok := modules.GetModule[byzantine.Byzantine](strategy)
+			if !ok {
+				return fmt.Errorf("unknown byzantine strategy: '%s'", strategy)
+			}
+			consensusRules = byz.Wrap(consensusRules)
+			if ext, ok := byz.(byzantine.Extension); ok {
+				byzantineModules = ext.Extensions()
+			}
+		}
+		cryptoImpl, ok := modules.GetModule[modules.CryptoBase](cfg.Crypto)
+		if !ok {
+			return fmt.Errorf("unknown crypto implementation: '%s'", cfg.Crypto)
+		}
+		leaderRotation, ok := modules.GetModule[modules.LeaderRotation](cfg.LeaderRotation)
+		if !ok {
+			return fmt.Errorf("unknown leader-rotation algorithm: '%s'", cfg.LeaderRotation)
+		}
+		eventLoop := eventloop.New(1000)
+		eventLoop.SetClock(n)
+		builder.Add(
+			eventLoop,
+			consensus.New(consensusRules),
+			consensus.NewVotingMachine(),
+			crypto.NewCache(cryptoImpl, 100),
+			leaderRotation,
+			synchronizer.New(synchronizer.NewViewDuration(
+				cfg.TimeoutSamples,
+				float64(cfg.ViewTimeout)/float64(time.Millisecond),
+				float64(cfg.MaxTimeout)/float64(time.Millisecond),
+				cfg.TimeoutMultiplier,
+			)),
+			cfg.MetricsLogger,
+			blockchain.New(),
+			logging.NewWithDest(cfg.Logs, fmt.Sprintf("hs%d", id)),
+			&configuration{network: n, node: node},
+			&commandModule{commandGenerator: cg, node: node, batchSize: cfg.BatchSize},
+		)
+		builder.Add(byzantineModules...)
+		if cfg.MeasurementInterval > 0 {
+			builder.Add(metrics.GetReplicaMetrics(cfg.Metrics...)...)
+			builder.Add(metrics.NewTicker(cfg.MeasurementInterval))
+		}
+		for _, name := range cfg.Modules {
+			module, ok := modules.GetModuleUntyped(name)
+			if !ok {
+				return fmt.Errorf("unknown module: '%s'", name)
+			}
+			builder.Add(module)
+		}
+		// the votes must be verified in the event loop, since a verification in the background
+		// would finish at an arbitrary tick
+		builder.Options().SetShouldVerifyVotesSync()
+		builder.Options().SetSharedRandomSeed(cfg.Seed)
+		builder.Options().SetTreeDelta(cfg.TreeDelta)
+		builder.Options().SetCheckpointInterval(cfg.CheckpointInterval)
+		builder.Options().SetLeafPlacement(cfg.LeafPlacement)
+		if len(cfg.TreePositions) > 0 {
+			builder.Options().SetTreePositions(cfg.TreePositions)
+		}
+		builder.Build()
+	}
+	return nil
+}
+
+// simulate runs the network for the duration of virtual time, skipping the ticks in which nothing happens.
+func (n *Network) simulate(duration time.Duration) {
+	end := n.ticks + n.durationTicks(duration)
+	for {
+		next := n.nextTick()
+		if next > end {
+			break
+		}
+		n.ticks = next - 1
+		n.tick()
+	}
+	n.ticks = end
+}
+
+// nextTick returns the first tick in which a message is delivered or a timer expires.
+func (n *Network) nextTick() int {
+	next := math.MaxInt
+	for _, msg := range n.pendingMessages {
+		if msg.tick < next {
+			next = msg.tick
+		}
+	}
+	for _, timer := range n.pendingTimers {
+		if !timer.stopped && timer.tick < next {
+			next = timer.tick
+		}
+	}
+	return max(next, n.ticks+1)
+}
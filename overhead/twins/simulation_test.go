@@ -0,0 +1,148 @@
+package twins
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/kauri"
+	_ "github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/metrics"
+	"optitree/opt"
+)
+
+func TestSimulationDeterministic(t *testing.T) {
+	withoutFaultyNodes(t)
+	simulate := func() (SimulationResult, []byte) {
+		var measurements bytes.Buffer
+		logger, err := metrics.NewJSONLogger(&measurements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Simulate(SimulationConfig{
+			Replicas:            7,
+			LeaderRotation:      "fixed",
+			Modules:             []string{"kauri"},
+			Locations:           []string{"Frankfurt", "Tokyo", "Oregon", "Stockholm"},
+			Jitter:              5 * time.Millisecond,
+			Faults:              []Fault{{Replica: 7, DropRate: 0.2}},
+			Duration:            3 * time.Second,
+			Seed:                42,
+			Metrics:             []string{"throughput", "consensus-phases", "tree-changes", "timeouts"},
+			MeasurementInterval: 500 * time.Millisecond,
+			MetricsLogger:       logger,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return result, measurements.Bytes()
+	}
+	first, firstMeasurements := simulate()
+	second, secondMeasurements := simulate()
+	if !first.Safe || first.Commits == 0 {
+		t.Fatalf("expected the replicas to commit blocks safely: %+v", first)
+	}
+	if first.Dropped == 0 {
+		t.Error("expected the fault to drop messages")
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("simulations with the same seed differ:\n%+v\n%+v", first, second)
+	}
+	if !bytes.Equal(firstMeasurements, secondMeasurements) {
+		t.Error("simulations with the same seed logged different measurements")
+	}
+	if !bytes.Contains(firstMeasurements, []byte("types.ConsensusPhase")) {
+		t.Error("expected the consensus phases to be measured")
+	}
+}
+
+func TestSimulationCrashedReplica(t *testing.T) {
+	withoutFaultyNodes(t)
+	result, err := Simulate(SimulationConfig{
+		Replicas: 4,
+		Latency:  10 * time.Millisecond,
+		Faults:   []Fault{{Replica: 4, Crash: true}},
+		Duration: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Safe || result.Commits == 0 {
+		t.Fatalf("expected the correct replicas to commit blocks: %+v", result)
+	}
+	if result.Executed[4] != 0 {
+		t.Errorf("expected the crashed replica to commit no blocks, got %d", result.Executed[4])
+	}
+}
+
+func TestSimulationManyReplicas(t *testing.T) {
+	withoutFaultyNodes(t)
+	const numReplicas = 73
+	treePositions := make([]hotstuff.ID, numReplicas)
+	for i := range treePositions {
+		treePositions[i] = hotstuff.ID(i + 1)
+	}
+	tests := []struct {
+		name          string
+		replicas      int
+		treePositions []hotstuff.ID
+	}{
+		{name: "fixed tree", replicas: numReplicas, treePositions: treePositions},
+		// the tree is built by Kauri, which uses the leaf placement when the tree is too large for the tree optimizer
+		{name: "kauri tree", replicas: numReplicas},
+		// the tree optimizer handles trees of height two, which is the largest tree that it builds
+		{name: "optitree", replicas: opt.TreeSize(kauri.MaxChild)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			result, err := Simulate(SimulationConfig{
+				Replicas:       tt.replicas,
+				LeaderRotation: "fixed",
+				Modules:        []string{"kauri"},
+				TreePositions:  tt.treePositions,
+				Locations:      []string{"Frankfurt", "Stockholm", "Ireland", "London", "Paris", "Milan"},
+				Duration:       2 * time.Second,
+				Seed:           1,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Safe || result.Commits == 0 {
+				t.Fatalf("expected the replicas to commit blocks safely: %+v", result)
+			}
+			t.Logf("simulated %d replicas for 2s in %v", tt.replicas, time.Since(start))
+		})
+	}
+}
+
+func TestSimulationLeafPlacement(t *testing.T) {
+	withoutFaultyNodes(t)
+	for _, placement := range []string{kauri.LeafPlacementGreedy, kauri.LeafPlacementHungarian, kauri.LeafPlacementBalanced, kauri.LeafPlacementQuorum} {
+		t.Run(placement, func(t *testing.T) {
+			result, err := Simulate(SimulationConfig{
+				Replicas:       7,
+				LeaderRotation: "fixed",
+				Modules:        []string{"kauri"},
+				LeafPlacement:  placement,
+				Locations:      []string{"Frankfurt", "Tokyo", "Oregon", "Stockholm"},
+				Duration:       3 * time.Second,
+				Seed:           1,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Safe || result.Commits == 0 {
+				t.Fatalf("expected the replicas to commit blocks safely: %+v", result)
+			}
+		})
+	}
+	if _, err := Simulate(SimulationConfig{Replicas: 4, Duration: time.Second, LeafPlacement: "random"}); err == nil {
+		t.Error("expected an unknown leaf placement to be rejected")
+	}
+}
@@ -23,6 +23,7 @@ type twinsJSON struct {
 	Ticks      int               `json:"ticks"`
 	Shuffle    bool              `json:"shuffle"`
 	Seed       int64             `json:"seed"`
+	Tree       bool              `json:"tree,omitempty"`
 	Scenarios  []json.RawMessage `json:"scenarios"`
 
 	scenario int
@@ -37,6 +38,7 @@ func (t twinsJSON) Settings() Settings {
 		Ticks:      t.Ticks,
 		Shuffle:    t.Shuffle,
 		Seed:       t.Seed,
+		Tree:       t.Tree,
 	}
 }
 
@@ -72,6 +74,8 @@ type Settings struct {
 	Ticks      int
 	Shuffle    bool
 	Seed       int64
+	// Tree generates partitions that cut off internal nodes of a Kauri tree, with a fixed leader at the root.
+	Tree bool
 }
 
 // JSONWriter writes scenarios to JSON.
@@ -111,6 +115,11 @@ func (jwr *JSONWriter) Close() error {
 
 // ToJSON returns a JSONWriter that can be used to write scenarios as JSON.
 func ToJSON(settings Settings, wr io.Writer) (*JSONWriter, error) {
+	// the tree setting is omitted unless it is set, so that other scenarios are written as before
+	tree := ""
+	if settings.Tree {
+		tree = "\n\t\"tree\": true,"
+	}
 	head := fmt.Sprintf(`{
 	"num_nodes": %d,
 	"num_twins": %d,
@@ -118,7 +127,7 @@ func ToJSON(settings Settings, wr io.Writer) (*JSONWriter, error) {
 	"views": %d,
 	"ticks": %d,
 	"shuffle": %t,
-	"seed": %d,
+	"seed": %d,%s
 	"scenarios": [`,
 		settings.NumNodes,
 		settings.NumTwins,
@@ -127,6 +136,7 @@ func ToJSON(settings Settings, wr io.Writer) (*JSONWriter, error) {
 		settings.Ticks,
 		settings.Shuffle,
 		settings.Seed,
+		tree,
 	)
 
 	_, err := io.WriteString(wr, head)
@@ -3,6 +3,7 @@ package hotstuff
 import (
 	"bytes"
 	"crypto"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
@@ -395,11 +396,91 @@ func writeParticipants(wr io.Writer, participants IDSet) (err error) {
 	return err
 }
 
+// ReconfigurationMsg changes the set of active replicas and the quorum size from View onwards.
 type ReconfigurationMsg struct {
 	QuorumSize        int
 	ActiveReplicas    []ID
 	View              View
 	QuorumCertificate QuorumCert
+	// Certificate is set if the operator requested the reconfiguration.
+	Certificate OperatorCertificate
+}
+
+// OperatorCertificate is the operator's signature of a reconfiguration that the operator requested.
+// It allows the reconfiguration to remove replicas that are not suspected.
+type OperatorCertificate struct {
+	// Serial increases with each reconfiguration that the operator requests, such that an old request cannot be replayed.
+	Serial uint64
+	// Signature is the ASN.1 encoded ECDSA signature of OperatorHash(activeReplicas, Serial), or nil if there is no certificate.
+	Signature []byte
+}
+
+// OperatorHash returns the hash that the operator signs to request a reconfiguration to the active replicas.
+func OperatorHash(activeReplicas []ID, serial uint64) Hash {
+	buf := []byte(reconfigurationTag)
+	buf = binary.LittleEndian.AppendUint64(buf, serial)
+	for _, id := range activeReplicas {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(id))
+	}
+	return sha256.Sum256(buf)
+}
+
+// reconfigurationTag prefixes commands that carry a reconfiguration.
+// Client command batches are protobuf messages, which never begin with a zero byte.
+const reconfigurationTag = "\x00reconfiguration"
+
+// NewReconfigurationCommand returns a command that changes the active replicas
+// and the quorum size when the block containing it is committed.
+func NewReconfigurationCommand(activeReplicas []ID, quorumSize int) Command {
+	return NewCertifiedReconfigurationCommand(activeReplicas, quorumSize, OperatorCertificate{})
+}
+
+// NewCertifiedReconfigurationCommand returns a reconfiguration command that carries the operator's certificate.
+func NewCertifiedReconfigurationCommand(activeReplicas []ID, quorumSize int, certificate OperatorCertificate) Command {
+	buf := []byte(reconfigurationTag)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(quorumSize))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(activeReplicas)))
+	for _, id := range activeReplicas {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(id))
+	}
+	if certificate.Signature != nil {
+		buf = binary.LittleEndian.AppendUint64(buf, certificate.Serial)
+		buf = append(buf, certificate.Signature...)
+	}
+	return Command(buf)
+}
+
+// ReconfigurationFromCommand returns the reconfiguration carried by the command, if any.
+// The View and QuorumCertificate fields of the returned message are not set.
+func ReconfigurationFromCommand(cmd Command) (ReconfigurationMsg, bool) {
+	data, ok := strings.CutPrefix(string(cmd), reconfigurationTag)
+	if !ok || len(data) < 8 {
+		return ReconfigurationMsg{}, false
+	}
+	n := int(binary.LittleEndian.Uint32([]byte(data[4:8])))
+	end := 8 + 4*n
+	if len(data) < end || (len(data) > end && len(data) <= end+8) {
+		return ReconfigurationMsg{}, false
+	}
+	msg := ReconfigurationMsg{
+		QuorumSize:     int(binary.LittleEndian.Uint32([]byte(data[:4]))),
+		ActiveReplicas: make([]ID, 0, n),
+	}
+	for i := 8; i < end; i += 4 {
+		msg.ActiveReplicas = append(msg.ActiveReplicas, ID(binary.LittleEndian.Uint32([]byte(data[i:i+4]))))
+	}
+	if len(data) > end {
+		msg.Certificate = OperatorCertificate{
+			Serial:    binary.LittleEndian.Uint64([]byte(data[end : end+8])),
+			Signature: []byte(data[end+8:]),
+		}
+	}
+	return msg, true
+}
+
+// IsReconfiguration returns true if the command carries a reconfiguration instead of client commands.
+func (cmd Command) IsReconfiguration() bool {
+	return strings.HasPrefix(string(cmd), reconfigurationTag)
 }
 
 type Complaint struct {